@@ -101,6 +101,68 @@ func (AckCode) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_cc02b310faf1c402, []int{1}
 }
 
+type ExportFormat int32
+
+const (
+	// Each event is a protocol buffer prefixed by its uvarint encoded length.
+	ExportFormat_PROTO ExportFormat = 0
+	// Each event is a JSON object on its own line.
+	ExportFormat_JSON_LINES ExportFormat = 1
+)
+
+var ExportFormat_name = map[int32]string{
+	0: "PROTO",
+	1: "JSON_LINES",
+}
+
+var ExportFormat_value = map[string]int32{
+	"PROTO":      0,
+	"JSON_LINES": 1,
+}
+
+func (x ExportFormat) String() string {
+	return proto.EnumName(ExportFormat_name, int32(x))
+}
+
+func (ExportFormat) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_cc02b310faf1c402, []int{2}
+}
+
+type Backoff int32
+
+const (
+	// Use the default backoff, which is BACKOFF_EXPONENTIAL.
+	Backoff_BACKOFF_DEFAULT Backoff = 0
+	// The requeue delay doubles with each requeue.
+	Backoff_BACKOFF_EXPONENTIAL Backoff = 1
+	// The requeue delay increases by the base delay with each requeue.
+	Backoff_BACKOFF_LINEAR Backoff = 2
+	// The requeue delay is always the base delay.
+	Backoff_BACKOFF_CONSTANT Backoff = 3
+)
+
+var Backoff_name = map[int32]string{
+	0: "BACKOFF_DEFAULT",
+	1: "BACKOFF_EXPONENTIAL",
+	2: "BACKOFF_LINEAR",
+	3: "BACKOFF_CONSTANT",
+}
+
+var Backoff_value = map[string]int32{
+	"BACKOFF_DEFAULT":     0,
+	"BACKOFF_EXPONENTIAL": 1,
+	"BACKOFF_LINEAR":      2,
+	"BACKOFF_CONSTANT":    3,
+}
+
+func (x Backoff) String() string {
+	return proto.EnumName(Backoff_name, int32(x))
+}
+
+func (Backoff) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_cc02b310faf1c402, []int{3}
+}
+
 // Events wrap arbitrary data published on a particular topic and retrived on a particular channel.
 // The same event retrieved on different channels may have a different state and requeue_count, as
 // these fields are channel specific.
@@ -126,6 +188,26 @@ type Event struct {
 	// Number of attempts to send the event on the channel it is recieved on.
 	// Output only.
 	RequeueCount int32 `protobuf:"varint,7,opt,name=requeue_count,json=requeueCount,proto3" json:"requeue_count,omitempty"`
+	// Arbitrary key-value pairs describing the event, such as its content type or the identity of its
+	// producer.
+	Metadata map[string]string `protobuf:"bytes,8,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// The number of times the event is requeued on a channel before it is dequeued with
+	// DEQUEUED_ERROR. Defaults to the requeue_limit of the topic's TopicConfig, or the server's
+	// default requeue limit. -1 removes the limit.
+	RequeueLimit int32 `protobuf:"varint,9,opt,name=requeue_limit,json=requeueLimit,proto3" json:"requeue_limit,omitempty"`
+	// If set, the event is stored immediately but isn't delivered to any channel until this time,
+	// represented as the number of nanoseconds since the unix epoch. Events that aren't delivered
+	// yet are scheduled.
+	DeliverAt int64 `protobuf:"fixed64,10,opt,name=deliver_at,json=deliverAt,proto3" json:"deliver_at,omitempty"`
+	// Queued events with a higher priority are delivered to each channel before queued events with a
+	// lower priority. Events with the same priority are delivered in create_time order. Defaults to
+	// zero, and may be negative. Each channel buffers up to 20 events for delivery in the order they
+	// were buffered, so an event may wait behind up to 20 events with a lower priority.
+	Priority int32 `protobuf:"varint,11,opt,name=priority,proto3" json:"priority,omitempty"`
+	// Whether deliver_at is after the time the event was retrieved, so the event hasn't been sent to
+	// any channel yet.
+	// Output only.
+	Scheduled bool `protobuf:"varint,12,opt,name=scheduled,proto3" json:"scheduled,omitempty"`
 }
 
 func (m *Event) Reset()         { *m = Event{} }
@@ -210,6 +292,41 @@ func (m *Event) GetRequeueCount() int32 {
 	return 0
 }
 
+func (m *Event) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *Event) GetRequeueLimit() int32 {
+	if m != nil {
+		return m.RequeueLimit
+	}
+	return 0
+}
+
+func (m *Event) GetDeliverAt() int64 {
+	if m != nil {
+		return m.DeliverAt
+	}
+	return 0
+}
+
+func (m *Event) GetPriority() int32 {
+	if m != nil {
+		return m.Priority
+	}
+	return 0
+}
+
+func (m *Event) GetScheduled() bool {
+	if m != nil {
+		return m.Scheduled
+	}
+	return false
+}
+
 type PubRequest struct {
 	// The event to publish.
 	// Required.
@@ -266,6 +383,98 @@ func (m *PubRequest) GetAwaitChannel() string {
 	return ""
 }
 
+type PubBatchRequest struct {
+	// The events to publish. If an event with the same id and topic as one of the events already
+	// exists, it is only published if the payloads match, otherwise none of the events are published.
+	// Required.
+	Events []*Event `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+}
+
+func (m *PubBatchRequest) Reset()         { *m = PubBatchRequest{} }
+func (m *PubBatchRequest) String() string { return proto.CompactTextString(m) }
+func (*PubBatchRequest) ProtoMessage()    {}
+func (*PubBatchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cc02b310faf1c402, []int{2}
+}
+func (m *PubBatchRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PubBatchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PubBatchRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PubBatchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PubBatchRequest.Merge(m, src)
+}
+func (m *PubBatchRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *PubBatchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PubBatchRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PubBatchRequest proto.InternalMessageInfo
+
+func (m *PubBatchRequest) GetEvents() []*Event {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+type PubBatchResponse struct {
+	// The published events, in the same order as the events of the request.
+	Events []*Event `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+}
+
+func (m *PubBatchResponse) Reset()         { *m = PubBatchResponse{} }
+func (m *PubBatchResponse) String() string { return proto.CompactTextString(m) }
+func (*PubBatchResponse) ProtoMessage()    {}
+func (*PubBatchResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cc02b310faf1c402, []int{3}
+}
+func (m *PubBatchResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PubBatchResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PubBatchResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PubBatchResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PubBatchResponse.Merge(m, src)
+}
+func (m *PubBatchResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *PubBatchResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PubBatchResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PubBatchResponse proto.InternalMessageInfo
+
+func (m *PubBatchResponse) GetEvents() []*Event {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
 type SubRequest struct {
 	// The channel to subscribe to. Each time an event is queued, it is only sent to one subscriber
 	// per channel.
@@ -284,15 +493,19 @@ type SubRequest struct {
 	// of milliseconds.
 	IdleTimeoutMilliseconds int32 `protobuf:"varint,7,opt,name=idle_timeout_milliseconds,json=idleTimeoutMilliseconds,proto3" json:"idle_timeout_milliseconds,omitempty"`
 	// Number of milliseconds to wait before requeuing the event if it is not dequeued.
-	// Defaults to 8000.
+	// Defaults to the requeue_delay_milliseconds of the topic's TopicConfig, or 8000 if the topic
+	// has none.
 	RequeueDelayMilliseconds int32 `protobuf:"varint,6,opt,name=requeue_delay_milliseconds,json=requeueDelayMilliseconds,proto3" json:"requeue_delay_milliseconds,omitempty"`
+	// If set, a copy of each event that reaches its requeue limit while the subscription is open is
+	// published to this topic. Defaults to the dead_letter_topic of the topic's TopicConfig.
+	DeadLetterTopic string `protobuf:"bytes,8,opt,name=dead_letter_topic,json=deadLetterTopic,proto3" json:"dead_letter_topic,omitempty"`
 }
 
 func (m *SubRequest) Reset()         { *m = SubRequest{} }
 func (m *SubRequest) String() string { return proto.CompactTextString(m) }
 func (*SubRequest) ProtoMessage()    {}
 func (*SubRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cc02b310faf1c402, []int{2}
+	return fileDescriptor_cc02b310faf1c402, []int{4}
 }
 func (m *SubRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -356,6 +569,13 @@ func (m *SubRequest) GetRequeueDelayMilliseconds() int32 {
 	return 0
 }
 
+func (m *SubRequest) GetDeadLetterTopic() string {
+	if m != nil {
+		return m.DeadLetterTopic
+	}
+	return ""
+}
+
 type AckRequest struct {
 	// The channel to update the event's status on.
 	// Required.
@@ -369,13 +589,16 @@ type AckRequest struct {
 	// See the definition of AckCode for details.
 	// Required.
 	Code AckCode `protobuf:"varint,4,opt,name=code,proto3,enum=deq.AckCode" json:"code,omitempty"`
+	// A description of the error that occured handling the event, if any. The last error of an event
+	// on a channel is included in the metadata of the event's dead letter.
+	Error string `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
 }
 
 func (m *AckRequest) Reset()         { *m = AckRequest{} }
 func (m *AckRequest) String() string { return proto.CompactTextString(m) }
 func (*AckRequest) ProtoMessage()    {}
 func (*AckRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cc02b310faf1c402, []int{3}
+	return fileDescriptor_cc02b310faf1c402, []int{5}
 }
 func (m *AckRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -432,6 +655,13 @@ func (m *AckRequest) GetCode() AckCode {
 	return AckCode_UNSPECIFIED
 }
 
+func (m *AckRequest) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
 type AckResponse struct {
 }
 
@@ -439,7 +669,7 @@ func (m *AckResponse) Reset()         { *m = AckResponse{} }
 func (m *AckResponse) String() string { return proto.CompactTextString(m) }
 func (*AckResponse) ProtoMessage()    {}
 func (*AckResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cc02b310faf1c402, []int{4}
+	return fileDescriptor_cc02b310faf1c402, []int{6}
 }
 func (m *AckResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -487,7 +717,7 @@ func (m *GetRequest) Reset()         { *m = GetRequest{} }
 func (m *GetRequest) String() string { return proto.CompactTextString(m) }
 func (*GetRequest) ProtoMessage()    {}
 func (*GetRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cc02b310faf1c402, []int{5}
+	return fileDescriptor_cc02b310faf1c402, []int{7}
 }
 func (m *GetRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -567,7 +797,7 @@ func (m *ListRequest) Reset()         { *m = ListRequest{} }
 func (m *ListRequest) String() string { return proto.CompactTextString(m) }
 func (*ListRequest) ProtoMessage()    {}
 func (*ListRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cc02b310faf1c402, []int{6}
+	return fileDescriptor_cc02b310faf1c402, []int{8}
 }
 func (m *ListRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -646,7 +876,7 @@ func (m *ListResponse) Reset()         { *m = ListResponse{} }
 func (m *ListResponse) String() string { return proto.CompactTextString(m) }
 func (*ListResponse) ProtoMessage()    {}
 func (*ListResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cc02b310faf1c402, []int{7}
+	return fileDescriptor_cc02b310faf1c402, []int{9}
 }
 func (m *ListResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -693,7 +923,7 @@ func (m *DelRequest) Reset()         { *m = DelRequest{} }
 func (m *DelRequest) String() string { return proto.CompactTextString(m) }
 func (*DelRequest) ProtoMessage()    {}
 func (*DelRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cc02b310faf1c402, []int{8}
+	return fileDescriptor_cc02b310faf1c402, []int{10}
 }
 func (m *DelRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -736,21 +966,26 @@ func (m *DelRequest) GetTopic() string {
 	return ""
 }
 
-type TopicsRequest struct {
+type DelTopicRequest struct {
+	// Required. The topic to delete.
+	Topic string `protobuf:"bytes,1,opt,name=topic,proto3" json:"topic,omitempty"`
+	// If positive, only events created before this time are deleted, represented as the number of
+	// nanoseconds since the unix epoch. Otherwise, every event of the topic is deleted.
+	Before int64 `protobuf:"fixed64,2,opt,name=before,proto3" json:"before,omitempty"`
 }
 
-func (m *TopicsRequest) Reset()         { *m = TopicsRequest{} }
-func (m *TopicsRequest) String() string { return proto.CompactTextString(m) }
-func (*TopicsRequest) ProtoMessage()    {}
-func (*TopicsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cc02b310faf1c402, []int{9}
+func (m *DelTopicRequest) Reset()         { *m = DelTopicRequest{} }
+func (m *DelTopicRequest) String() string { return proto.CompactTextString(m) }
+func (*DelTopicRequest) ProtoMessage()    {}
+func (*DelTopicRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cc02b310faf1c402, []int{11}
 }
-func (m *TopicsRequest) XXX_Unmarshal(b []byte) error {
+func (m *DelTopicRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *TopicsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *DelTopicRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_TopicsRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_DelTopicRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -760,34 +995,49 @@ func (m *TopicsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error
 		return b[:n], nil
 	}
 }
-func (m *TopicsRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_TopicsRequest.Merge(m, src)
+func (m *DelTopicRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DelTopicRequest.Merge(m, src)
 }
-func (m *TopicsRequest) XXX_Size() int {
+func (m *DelTopicRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *TopicsRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_TopicsRequest.DiscardUnknown(m)
+func (m *DelTopicRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DelTopicRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_TopicsRequest proto.InternalMessageInfo
+var xxx_messageInfo_DelTopicRequest proto.InternalMessageInfo
 
-type TopicsResponse struct {
-	Topics []string `protobuf:"bytes,1,rep,name=topics,proto3" json:"topics,omitempty"`
+func (m *DelTopicRequest) GetTopic() string {
+	if m != nil {
+		return m.Topic
+	}
+	return ""
 }
 
-func (m *TopicsResponse) Reset()         { *m = TopicsResponse{} }
-func (m *TopicsResponse) String() string { return proto.CompactTextString(m) }
-func (*TopicsResponse) ProtoMessage()    {}
-func (*TopicsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cc02b310faf1c402, []int{10}
+func (m *DelTopicRequest) GetBefore() int64 {
+	if m != nil {
+		return m.Before
+	}
+	return 0
 }
-func (m *TopicsResponse) XXX_Unmarshal(b []byte) error {
+
+type DelTopicResponse struct {
+	// The number of events deleted.
+	DeletedCount int64 `protobuf:"varint,1,opt,name=deleted_count,json=deletedCount,proto3" json:"deleted_count,omitempty"`
+}
+
+func (m *DelTopicResponse) Reset()         { *m = DelTopicResponse{} }
+func (m *DelTopicResponse) String() string { return proto.CompactTextString(m) }
+func (*DelTopicResponse) ProtoMessage()    {}
+func (*DelTopicResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cc02b310faf1c402, []int{12}
+}
+func (m *DelTopicResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *TopicsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *DelTopicResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_TopicsResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_DelTopicResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -797,40 +1047,43 @@ func (m *TopicsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, erro
 		return b[:n], nil
 	}
 }
-func (m *TopicsResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_TopicsResponse.Merge(m, src)
+func (m *DelTopicResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DelTopicResponse.Merge(m, src)
 }
-func (m *TopicsResponse) XXX_Size() int {
+func (m *DelTopicResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *TopicsResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_TopicsResponse.DiscardUnknown(m)
+func (m *DelTopicResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DelTopicResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_TopicsResponse proto.InternalMessageInfo
+var xxx_messageInfo_DelTopicResponse proto.InternalMessageInfo
 
-func (m *TopicsResponse) GetTopics() []string {
+func (m *DelTopicResponse) GetDeletedCount() int64 {
 	if m != nil {
-		return m.Topics
+		return m.DeletedCount
 	}
-	return nil
+	return 0
 }
 
-type Empty struct {
+type BackupRequest struct {
+	// If non-zero, only data modified after this version is backed up, creating an incremental
+	// backup. Use the version of a previous backup.
+	Since uint64 `protobuf:"fixed64,1,opt,name=since,proto3" json:"since,omitempty"`
 }
 
-func (m *Empty) Reset()         { *m = Empty{} }
-func (m *Empty) String() string { return proto.CompactTextString(m) }
-func (*Empty) ProtoMessage()    {}
-func (*Empty) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cc02b310faf1c402, []int{11}
+func (m *BackupRequest) Reset()         { *m = BackupRequest{} }
+func (m *BackupRequest) String() string { return proto.CompactTextString(m) }
+func (*BackupRequest) ProtoMessage()    {}
+func (*BackupRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cc02b310faf1c402, []int{13}
 }
-func (m *Empty) XXX_Unmarshal(b []byte) error {
+func (m *BackupRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *Empty) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *BackupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_Empty.Marshal(b, m, deterministic)
+		return xxx_messageInfo_BackupRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -840,37 +1093,44 @@ func (m *Empty) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *Empty) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Empty.Merge(m, src)
+func (m *BackupRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BackupRequest.Merge(m, src)
 }
-func (m *Empty) XXX_Size() int {
+func (m *BackupRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *Empty) XXX_DiscardUnknown() {
-	xxx_messageInfo_Empty.DiscardUnknown(m)
+func (m *BackupRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BackupRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Empty proto.InternalMessageInfo
+var xxx_messageInfo_BackupRequest proto.InternalMessageInfo
 
-// EventV0 is used for upgrading from a V0 database, and should not be used by clients.
-type EventV0 struct {
-	Payload *Any   `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
-	Id      []byte `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
-	Key     []byte `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+func (m *BackupRequest) GetSince() uint64 {
+	if m != nil {
+		return m.Since
+	}
+	return 0
 }
 
-func (m *EventV0) Reset()         { *m = EventV0{} }
-func (m *EventV0) String() string { return proto.CompactTextString(m) }
-func (*EventV0) ProtoMessage()    {}
-func (*EventV0) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cc02b310faf1c402, []int{12}
+type BackupChunk struct {
+	// The next chunk of the backup.
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	// The version of the backup. Only set on the last chunk.
+	Version uint64 `protobuf:"fixed64,2,opt,name=version,proto3" json:"version,omitempty"`
 }
-func (m *EventV0) XXX_Unmarshal(b []byte) error {
+
+func (m *BackupChunk) Reset()         { *m = BackupChunk{} }
+func (m *BackupChunk) String() string { return proto.CompactTextString(m) }
+func (*BackupChunk) ProtoMessage()    {}
+func (*BackupChunk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cc02b310faf1c402, []int{14}
+}
+func (m *BackupChunk) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *EventV0) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *BackupChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_EventV0.Marshal(b, m, deterministic)
+		return xxx_messageInfo_BackupChunk.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -880,57 +1140,49 @@ func (m *EventV0) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *EventV0) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_EventV0.Merge(m, src)
+func (m *BackupChunk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BackupChunk.Merge(m, src)
 }
-func (m *EventV0) XXX_Size() int {
+func (m *BackupChunk) XXX_Size() int {
 	return m.Size()
 }
-func (m *EventV0) XXX_DiscardUnknown() {
-	xxx_messageInfo_EventV0.DiscardUnknown(m)
+func (m *BackupChunk) XXX_DiscardUnknown() {
+	xxx_messageInfo_BackupChunk.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_EventV0 proto.InternalMessageInfo
-
-func (m *EventV0) GetPayload() *Any {
-	if m != nil {
-		return m.Payload
-	}
-	return nil
-}
+var xxx_messageInfo_BackupChunk proto.InternalMessageInfo
 
-func (m *EventV0) GetId() []byte {
+func (m *BackupChunk) GetData() []byte {
 	if m != nil {
-		return m.Id
+		return m.Data
 	}
 	return nil
 }
 
-func (m *EventV0) GetKey() []byte {
+func (m *BackupChunk) GetVersion() uint64 {
 	if m != nil {
-		return m.Key
+		return m.Version
 	}
-	return nil
+	return 0
 }
 
-// Any is used for upgrading from a V0 database, and should not be used by clients.
-type Any struct {
-	TypeUrl string `protobuf:"bytes,1,opt,name=type_url,json=typeUrl,proto3" json:"type_url,omitempty"`
-	Value   []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+type RestoreRequest struct {
+	// The next chunk of the backup.
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
 }
 
-func (m *Any) Reset()         { *m = Any{} }
-func (m *Any) String() string { return proto.CompactTextString(m) }
-func (*Any) ProtoMessage()    {}
-func (*Any) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cc02b310faf1c402, []int{13}
+func (m *RestoreRequest) Reset()         { *m = RestoreRequest{} }
+func (m *RestoreRequest) String() string { return proto.CompactTextString(m) }
+func (*RestoreRequest) ProtoMessage()    {}
+func (*RestoreRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cc02b310faf1c402, []int{15}
 }
-func (m *Any) XXX_Unmarshal(b []byte) error {
+func (m *RestoreRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *Any) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *RestoreRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_Any.Marshal(b, m, deterministic)
+		return xxx_messageInfo_RestoreRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalTo(b)
@@ -940,1226 +1192,7281 @@ func (m *Any) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *Any) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Any.Merge(m, src)
+func (m *RestoreRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreRequest.Merge(m, src)
 }
-func (m *Any) XXX_Size() int {
+func (m *RestoreRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *Any) XXX_DiscardUnknown() {
-	xxx_messageInfo_Any.DiscardUnknown(m)
+func (m *RestoreRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Any proto.InternalMessageInfo
+var xxx_messageInfo_RestoreRequest proto.InternalMessageInfo
 
-func (m *Any) GetTypeUrl() string {
+func (m *RestoreRequest) GetData() []byte {
 	if m != nil {
-		return m.TypeUrl
-	}
-	return ""
-}
-
-func (m *Any) GetValue() []byte {
-	if m != nil {
-		return m.Value
+		return m.Data
 	}
 	return nil
 }
 
-func init() {
-	proto.RegisterEnum("deq.EventState", EventState_name, EventState_value)
-	proto.RegisterEnum("deq.AckCode", AckCode_name, AckCode_value)
-	proto.RegisterType((*Event)(nil), "deq.Event")
-	proto.RegisterType((*PubRequest)(nil), "deq.PubRequest")
-	proto.RegisterType((*SubRequest)(nil), "deq.SubRequest")
-	proto.RegisterType((*AckRequest)(nil), "deq.AckRequest")
-	proto.RegisterType((*AckResponse)(nil), "deq.AckResponse")
-	proto.RegisterType((*GetRequest)(nil), "deq.GetRequest")
-	proto.RegisterType((*ListRequest)(nil), "deq.ListRequest")
-	proto.RegisterType((*ListResponse)(nil), "deq.ListResponse")
-	proto.RegisterType((*DelRequest)(nil), "deq.DelRequest")
-	proto.RegisterType((*TopicsRequest)(nil), "deq.TopicsRequest")
-	proto.RegisterType((*TopicsResponse)(nil), "deq.TopicsResponse")
-	proto.RegisterType((*Empty)(nil), "deq.Empty")
-	proto.RegisterType((*EventV0)(nil), "deq.EventV0")
-	proto.RegisterType((*Any)(nil), "deq.Any")
+type RestoreResponse struct {
 }
 
-func init() { proto.RegisterFile("deq.proto", fileDescriptor_cc02b310faf1c402) }
-
-var fileDescriptor_cc02b310faf1c402 = []byte{
-	// 897 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x55, 0xcd, 0x8e, 0xe3, 0x44,
-	0x10, 0x4e, 0xc7, 0xb1, 0x93, 0x54, 0x7e, 0xc6, 0xd3, 0xfb, 0xe7, 0x0d, 0x52, 0x88, 0x0c, 0x48,
-	0xd1, 0x22, 0xad, 0x96, 0x80, 0x38, 0x20, 0x38, 0x84, 0xc4, 0xac, 0x22, 0x66, 0x93, 0xd9, 0xb6,
-	0x83, 0xb8, 0x59, 0x1e, 0xbb, 0x17, 0xac, 0x38, 0x76, 0x26, 0xb6, 0x67, 0x37, 0xfb, 0x14, 0x48,
-	0x3c, 0x01, 0x12, 0x0f, 0xc3, 0x09, 0xed, 0x91, 0x23, 0x9a, 0xb9, 0xf1, 0x14, 0xa8, 0x7f, 0x1c,
-	0x27, 0xb0, 0x73, 0x99, 0x5b, 0xbe, 0xaf, 0xaa, 0xbe, 0xae, 0x2e, 0x7f, 0x5d, 0x81, 0x66, 0x40,
-	0x2f, 0x9f, 0x6e, 0xb6, 0x49, 0x96, 0x60, 0x25, 0xa0, 0x97, 0xe6, 0x3f, 0x08, 0x54, 0xeb, 0x8a,
-	0xc6, 0x19, 0xee, 0x42, 0x35, 0x0c, 0x0c, 0x34, 0x40, 0xc3, 0x26, 0xa9, 0x86, 0x01, 0xbe, 0x0f,
-	0x6a, 0x96, 0x6c, 0x42, 0xdf, 0xa8, 0x72, 0x4a, 0x00, 0x6c, 0x40, 0x7d, 0xe3, 0xed, 0xa2, 0xc4,
-	0x0b, 0x0c, 0x65, 0x80, 0x86, 0x6d, 0x52, 0x40, 0xfc, 0x21, 0xb4, 0xfc, 0x2d, 0xf5, 0x32, 0xea,
-	0x66, 0xe1, 0x9a, 0x1a, 0xb5, 0x01, 0x1a, 0xea, 0x04, 0x04, 0xe5, 0x84, 0x6b, 0x8a, 0xbf, 0x80,
-	0x4e, 0x40, 0x5f, 0x79, 0x79, 0x94, 0xb9, 0x69, 0xe6, 0x65, 0xd4, 0x50, 0x07, 0x68, 0xd8, 0x1d,
-	0x9d, 0x3c, 0x65, 0x2d, 0xf1, 0x1e, 0x6c, 0x46, 0x93, 0xb6, 0xcc, 0xe2, 0x08, 0x7f, 0x02, 0xaa,
-	0xc8, 0xd6, 0xde, 0x9f, 0x2d, 0xa2, 0xf8, 0x23, 0xe8, 0x6c, 0xe9, 0x65, 0x4e, 0x73, 0xea, 0xfa,
-	0x49, 0x1e, 0x67, 0x46, 0x7d, 0x80, 0x86, 0x2a, 0x69, 0x4b, 0x72, 0xc2, 0x38, 0xd3, 0x06, 0x38,
-	0xcf, 0x2f, 0x08, 0xa3, 0xd2, 0x0c, 0x0f, 0x40, 0xa5, 0x4c, 0x87, 0xdf, 0xb9, 0x35, 0x82, 0x52,
-	0x99, 0x88, 0x00, 0x13, 0xf5, 0x5e, 0x7b, 0x61, 0xe6, 0xfa, 0x3f, 0x7b, 0x71, 0x4c, 0x23, 0x39,
-	0x8a, 0x36, 0x27, 0x27, 0x82, 0x33, 0xff, 0x44, 0x00, 0x76, 0xa9, 0x6a, 0x40, 0xbd, 0xc8, 0x16,
-	0xb3, 0x2c, 0xe0, 0x2d, 0x03, 0x7d, 0x08, 0xda, 0xab, 0x24, 0x8a, 0x92, 0xd7, 0x7c, 0x1c, 0x0d,
-	0x22, 0x11, 0xfe, 0x0a, 0x1e, 0x87, 0x41, 0x24, 0x86, 0x99, 0xe4, 0x99, 0xbb, 0x0e, 0xa3, 0x28,
-	0x4c, 0xa9, 0x9f, 0xc4, 0x41, 0x2a, 0x2f, 0xf7, 0x88, 0x25, 0x38, 0x22, 0xfe, 0xe2, 0x20, 0x8c,
-	0xbf, 0x86, 0x5e, 0x31, 0x8c, 0x80, 0x46, 0xde, 0xee, 0xb8, 0x58, 0xe3, 0xc5, 0x86, 0xcc, 0x98,
-	0xb2, 0x84, 0xc3, 0x6a, 0x73, 0x07, 0x30, 0xf6, 0x57, 0x77, 0xbd, 0xcf, 0x63, 0x68, 0xf0, 0xe1,
-	0xb9, 0xa1, 0x70, 0x48, 0x93, 0xd4, 0x39, 0x9e, 0x05, 0x78, 0x00, 0x35, 0x3f, 0x09, 0x84, 0x35,
-	0xba, 0xa3, 0x36, 0x9f, 0xf7, 0xd8, 0x5f, 0x4d, 0x92, 0x80, 0x12, 0x1e, 0x31, 0x3b, 0xd0, 0xe2,
-	0x47, 0xa7, 0x9b, 0x24, 0x4e, 0xa9, 0xb9, 0x06, 0x78, 0x4e, 0xb3, 0xa2, 0x93, 0x43, 0x65, 0x74,
-	0xac, 0x7c, 0xab, 0x57, 0x8b, 0xd6, 0x95, 0xff, 0xb5, 0xce, 0xbf, 0x21, 0x6f, 0xa5, 0x41, 0x04,
-	0x30, 0x7f, 0x47, 0xd0, 0x3a, 0x0b, 0xd3, 0xfd, 0x81, 0x7b, 0x55, 0x74, 0x8b, 0x6a, 0xf5, 0x58,
-	0xf5, 0x01, 0x68, 0xeb, 0x30, 0x2e, 0x2f, 0xae, 0xae, 0xc3, 0x78, 0x16, 0x70, 0xda, 0x7b, 0xc3,
-	0xe8, 0x9a, 0xa4, 0xbd, 0x37, 0xb3, 0x00, 0x7f, 0x00, 0xcd, 0x8d, 0xf7, 0x13, 0x75, 0xd3, 0xf0,
-	0xad, 0x78, 0x0a, 0x2a, 0x69, 0x30, 0xc2, 0x0e, 0xdf, 0x52, 0xdc, 0x83, 0xc6, 0x96, 0x5e, 0xd1,
-	0x6d, 0x4a, 0x03, 0xfe, 0xbd, 0x1a, 0x64, 0x8f, 0xcd, 0x11, 0xb4, 0x45, 0x97, 0x62, 0x4a, 0xd8,
-	0x04, 0x8d, 0xcf, 0x21, 0x35, 0xd0, 0x40, 0xf9, 0x8f, 0x91, 0x65, 0xc4, 0xfc, 0x06, 0x60, 0x4a,
-	0xa3, 0xbb, 0x4e, 0xd2, 0x3c, 0x81, 0x8e, 0xc3, 0x7e, 0xa4, 0x52, 0xc1, 0x1c, 0x42, 0xb7, 0x20,
-	0x64, 0x17, 0x0f, 0x41, 0xe3, 0xb9, 0xa2, 0x8b, 0x26, 0x91, 0xc8, 0xac, 0x83, 0x6a, 0xad, 0x37,
-	0xd9, 0xce, 0x5c, 0x40, 0x9d, 0xf7, 0xf4, 0xc3, 0x33, 0x6c, 0x96, 0x4b, 0x44, 0xbc, 0xbd, 0x86,
-	0xf0, 0x42, 0xbc, 0x2b, 0xd7, 0x89, 0x58, 0x47, 0x55, 0xbe, 0x63, 0xd8, 0x3a, 0xd2, 0x41, 0x59,
-	0xd1, 0x9d, 0x5c, 0x3a, 0xec, 0xa7, 0xf9, 0x25, 0x28, 0xe3, 0x78, 0xc7, 0x2e, 0x93, 0xed, 0x36,
-	0xd4, 0xcd, 0xb7, 0x7b, 0x87, 0x32, 0xbc, 0xdc, 0xf2, 0xcf, 0x7c, 0xe5, 0x45, 0x39, 0x95, 0x32,
-	0x02, 0x3c, 0x71, 0x00, 0xca, 0xfd, 0x81, 0x1f, 0xc0, 0xe9, 0x72, 0x6e, 0x9f, 0x5b, 0x93, 0xd9,
-	0x77, 0x33, 0x6b, 0xea, 0xda, 0xce, 0xd8, 0xb1, 0xf4, 0x0a, 0x06, 0xd0, 0x5e, 0x2e, 0xad, 0xa5,
-	0x35, 0xd5, 0x11, 0x3e, 0x81, 0xd6, 0xd4, 0x12, 0xc8, 0x5d, 0x7c, 0xaf, 0x57, 0x31, 0x86, 0xee,
-	0x9e, 0xb0, 0x08, 0x59, 0x10, 0x5d, 0x79, 0xf2, 0x2b, 0x82, 0xba, 0x34, 0x33, 0x2b, 0x38, 0xd0,
-	0xd4, 0x2b, 0xb8, 0x0b, 0x20, 0x0b, 0x98, 0x00, 0xc2, 0xa7, 0xd0, 0x29, 0xb0, 0xa8, 0xaf, 0xe2,
-	0xfb, 0xa0, 0x13, 0x49, 0x4d, 0x16, 0x73, 0xdb, 0x19, 0xcf, 0x1d, 0x5d, 0x61, 0x27, 0x15, 0xec,
-	0xd9, 0x6c, 0x6e, 0x8d, 0x89, 0x5e, 0xc3, 0x8f, 0xe0, 0x5e, 0xc1, 0x59, 0x3f, 0x9e, 0x2f, 0xe6,
-	0xd6, 0xdc, 0x99, 0x8d, 0xcf, 0x74, 0x95, 0xa9, 0x12, 0xcb, 0xb6, 0x1c, 0xd7, 0x99, 0xbd, 0xb0,
-	0x16, 0x4b, 0x47, 0xd7, 0x46, 0xbf, 0x55, 0x41, 0x99, 0x5a, 0x2f, 0xb1, 0x09, 0xca, 0x79, 0x7e,
-	0x81, 0xc5, 0xf6, 0x2c, 0x77, 0x60, 0xef, 0xc0, 0x2b, 0xf8, 0x63, 0x50, 0xec, 0x7d, 0x8e, 0xfd,
-	0xde, 0x9c, 0x67, 0x08, 0x0f, 0x41, 0x19, 0xfb, 0x2b, 0x99, 0x55, 0xee, 0x89, 0x9e, 0x5e, 0x12,
-	0x7b, 0x5f, 0x2a, 0xcf, 0x69, 0x26, 0x33, 0xcb, 0x77, 0x7c, 0x74, 0xe6, 0xa7, 0x50, 0x63, 0x5e,
-	0xc6, 0xa2, 0xfa, 0xe0, 0xf1, 0xf5, 0x4e, 0x0f, 0x98, 0x52, 0x70, 0x4a, 0x23, 0x29, 0x58, 0xda,
-	0xb9, 0x10, 0x64, 0x2e, 0xc3, 0x9f, 0x81, 0x26, 0x8c, 0x89, 0x31, 0x67, 0x8f, 0x6c, 0xdb, 0xbb,
-	0x77, 0xc4, 0x09, 0xd9, 0x6f, 0x8d, 0x3f, 0xae, 0xfb, 0xe8, 0xdd, 0x75, 0x1f, 0xfd, 0x7d, 0xdd,
-	0x47, 0xbf, 0xdc, 0xf4, 0x2b, 0xef, 0x6e, 0xfa, 0x95, 0xbf, 0x6e, 0xfa, 0x95, 0x0b, 0x8d, 0xff,
-	0x51, 0x7e, 0xfe, 0x6f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x85, 0xb2, 0xe7, 0x6e, 0x35, 0x07, 0x00,
-	0x00,
+func (m *RestoreResponse) Reset()         { *m = RestoreResponse{} }
+func (m *RestoreResponse) String() string { return proto.CompactTextString(m) }
+func (*RestoreResponse) ProtoMessage()    {}
+func (*RestoreResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cc02b310faf1c402, []int{16}
+}
+func (m *RestoreResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RestoreResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RestoreResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RestoreResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreResponse.Merge(m, src)
+}
+func (m *RestoreResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *RestoreResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreResponse.DiscardUnknown(m)
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+var xxx_messageInfo_RestoreResponse proto.InternalMessageInfo
 
-// DEQClient is the client API for DEQ service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type DEQClient interface {
-	// Pub publishes an event on its topic.
-	Pub(ctx context.Context, in *PubRequest, opts ...grpc.CallOption) (*Event, error)
-	// Sub subscribers to events on a topic and channel. All events are stored until deleted, so all
-	// events with a default_state of QUEUED are queued on a new channel, even those published before
-	// before the subscriber connected.
-	Sub(ctx context.Context, in *SubRequest, opts ...grpc.CallOption) (DEQ_SubClient, error)
-	// Ack updates an event's processing state on a channel. See the definition of AckCode for
-	// available functionality.
-	Ack(ctx context.Context, in *AckRequest, opts ...grpc.CallOption) (*AckResponse, error)
-	// Get retrieves an event on a channel without modifying its status or place in the queue.
-	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*Event, error)
-	// List retrieves a list of events on a channel, sorted by event id, without modifying their
-	// status or place in the queue.
-	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error)
-	// Del deletes an event. Currently unimplemented.
-	Del(ctx context.Context, in *DelRequest, opts ...grpc.CallOption) (*Empty, error)
-	// Topics returns all topics for which an event has been published.
-	Topics(ctx context.Context, in *TopicsRequest, opts ...grpc.CallOption) (*TopicsResponse, error)
+type ExportRequest struct {
+	// Required. The topic to export.
+	Topic string `protobuf:"bytes,1,opt,name=topic,proto3" json:"topic,omitempty"`
+	// The format of the exported events.
+	Format ExportFormat `protobuf:"varint,2,opt,name=format,proto3,enum=deq.ExportFormat" json:"format,omitempty"`
+	// If true, the state of each event on every channel is exported along with the event.
+	ChannelStates bool `protobuf:"varint,3,opt,name=channel_states,json=channelStates,proto3" json:"channel_states,omitempty"`
 }
 
-type dEQClient struct {
-	cc *grpc.ClientConn
+func (m *ExportRequest) Reset()         { *m = ExportRequest{} }
+func (m *ExportRequest) String() string { return proto.CompactTextString(m) }
+func (*ExportRequest) ProtoMessage()    {}
+func (*ExportRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cc02b310faf1c402, []int{17}
 }
-
-func NewDEQClient(cc *grpc.ClientConn) DEQClient {
-	return &dEQClient{cc}
+func (m *ExportRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-
-func (c *dEQClient) Pub(ctx context.Context, in *PubRequest, opts ...grpc.CallOption) (*Event, error) {
-	out := new(Event)
-	err := c.cc.Invoke(ctx, "/deq.DEQ/Pub", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *ExportRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ExportRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return out, nil
 }
-
-func (c *dEQClient) Sub(ctx context.Context, in *SubRequest, opts ...grpc.CallOption) (DEQ_SubClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_DEQ_serviceDesc.Streams[0], "/deq.DEQ/Sub", opts...)
-	if err != nil {
-		return nil, err
-	}
-	x := &dEQSubClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
-	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
-	}
-	return x, nil
+func (m *ExportRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportRequest.Merge(m, src)
 }
-
-type DEQ_SubClient interface {
-	Recv() (*Event, error)
-	grpc.ClientStream
+func (m *ExportRequest) XXX_Size() int {
+	return m.Size()
 }
-
-type dEQSubClient struct {
-	grpc.ClientStream
+func (m *ExportRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportRequest.DiscardUnknown(m)
 }
 
-func (x *dEQSubClient) Recv() (*Event, error) {
-	m := new(Event)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+var xxx_messageInfo_ExportRequest proto.InternalMessageInfo
+
+func (m *ExportRequest) GetTopic() string {
+	if m != nil {
+		return m.Topic
 	}
-	return m, nil
+	return ""
 }
 
-func (c *dEQClient) Ack(ctx context.Context, in *AckRequest, opts ...grpc.CallOption) (*AckResponse, error) {
-	out := new(AckResponse)
-	err := c.cc.Invoke(ctx, "/deq.DEQ/Ack", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *ExportRequest) GetFormat() ExportFormat {
+	if m != nil {
+		return m.Format
 	}
-	return out, nil
+	return ExportFormat_PROTO
 }
 
-func (c *dEQClient) Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*Event, error) {
-	out := new(Event)
-	err := c.cc.Invoke(ctx, "/deq.DEQ/Get", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *ExportRequest) GetChannelStates() bool {
+	if m != nil {
+		return m.ChannelStates
 	}
-	return out, nil
+	return false
 }
 
-func (c *dEQClient) List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error) {
-	out := new(ListResponse)
-	err := c.cc.Invoke(ctx, "/deq.DEQ/List", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type ExportChunk struct {
+	// The next chunk of the export.
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
 }
 
-func (c *dEQClient) Del(ctx context.Context, in *DelRequest, opts ...grpc.CallOption) (*Empty, error) {
-	out := new(Empty)
-	err := c.cc.Invoke(ctx, "/deq.DEQ/Del", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *ExportChunk) Reset()         { *m = ExportChunk{} }
+func (m *ExportChunk) String() string { return proto.CompactTextString(m) }
+func (*ExportChunk) ProtoMessage()    {}
+func (*ExportChunk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cc02b310faf1c402, []int{18}
+}
+func (m *ExportChunk) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ExportChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ExportChunk.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return out, nil
+}
+func (m *ExportChunk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportChunk.Merge(m, src)
+}
+func (m *ExportChunk) XXX_Size() int {
+	return m.Size()
+}
+func (m *ExportChunk) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportChunk.DiscardUnknown(m)
 }
 
-func (c *dEQClient) Topics(ctx context.Context, in *TopicsRequest, opts ...grpc.CallOption) (*TopicsResponse, error) {
-	out := new(TopicsResponse)
-	err := c.cc.Invoke(ctx, "/deq.DEQ/Topics", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_ExportChunk proto.InternalMessageInfo
+
+func (m *ExportChunk) GetData() []byte {
+	if m != nil {
+		return m.Data
 	}
-	return out, nil
+	return nil
 }
 
-// DEQServer is the server API for DEQ service.
-type DEQServer interface {
-	// Pub publishes an event on its topic.
-	Pub(context.Context, *PubRequest) (*Event, error)
-	// Sub subscribers to events on a topic and channel. All events are stored until deleted, so all
-	// events with a default_state of QUEUED are queued on a new channel, even those published before
-	// before the subscriber connected.
-	Sub(*SubRequest, DEQ_SubServer) error
-	// Ack updates an event's processing state on a channel. See the definition of AckCode for
-	// available functionality.
-	Ack(context.Context, *AckRequest) (*AckResponse, error)
-	// Get retrieves an event on a channel without modifying its status or place in the queue.
-	Get(context.Context, *GetRequest) (*Event, error)
-	// List retrieves a list of events on a channel, sorted by event id, without modifying their
-	// status or place in the queue.
-	List(context.Context, *ListRequest) (*ListResponse, error)
-	// Del deletes an event. Currently unimplemented.
-	Del(context.Context, *DelRequest) (*Empty, error)
-	// Topics returns all topics for which an event has been published.
-	Topics(context.Context, *TopicsRequest) (*TopicsResponse, error)
+type ImportRequest struct {
+	// The format of the imported events. Only read from the first request of the stream.
+	Format ExportFormat `protobuf:"varint,1,opt,name=format,proto3,enum=deq.ExportFormat" json:"format,omitempty"`
+	// The next chunk of the export.
+	Data []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
 }
 
-func RegisterDEQServer(s *grpc.Server, srv DEQServer) {
-	s.RegisterService(&_DEQ_serviceDesc, srv)
+func (m *ImportRequest) Reset()         { *m = ImportRequest{} }
+func (m *ImportRequest) String() string { return proto.CompactTextString(m) }
+func (*ImportRequest) ProtoMessage()    {}
+func (*ImportRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cc02b310faf1c402, []int{19}
 }
-
-func _DEQ_Pub_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PubRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(DEQServer).Pub(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/deq.DEQ/Pub",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DEQServer).Pub(ctx, req.(*PubRequest))
+func (m *ImportRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ImportRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ImportRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return interceptor(ctx, in, info, handler)
+}
+func (m *ImportRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportRequest.Merge(m, src)
+}
+func (m *ImportRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ImportRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportRequest.DiscardUnknown(m)
 }
 
-func _DEQ_Sub_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(SubRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+var xxx_messageInfo_ImportRequest proto.InternalMessageInfo
+
+func (m *ImportRequest) GetFormat() ExportFormat {
+	if m != nil {
+		return m.Format
 	}
-	return srv.(DEQServer).Sub(m, &dEQSubServer{stream})
+	return ExportFormat_PROTO
 }
 
-type DEQ_SubServer interface {
-	Send(*Event) error
-	grpc.ServerStream
+func (m *ImportRequest) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
 }
 
-type dEQSubServer struct {
-	grpc.ServerStream
+type ImportResponse struct {
+	// The number of events imported.
+	ImportedCount int64 `protobuf:"varint,1,opt,name=imported_count,json=importedCount,proto3" json:"imported_count,omitempty"`
 }
 
-func (x *dEQSubServer) Send(m *Event) error {
-	return x.ServerStream.SendMsg(m)
+func (m *ImportResponse) Reset()         { *m = ImportResponse{} }
+func (m *ImportResponse) String() string { return proto.CompactTextString(m) }
+func (*ImportResponse) ProtoMessage()    {}
+func (*ImportResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cc02b310faf1c402, []int{20}
 }
-
-func _DEQ_Ack_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AckRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(DEQServer).Ack(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/deq.DEQ/Ack",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DEQServer).Ack(ctx, req.(*AckRequest))
+func (m *ImportResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ImportResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ImportResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return interceptor(ctx, in, info, handler)
+}
+func (m *ImportResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportResponse.Merge(m, src)
+}
+func (m *ImportResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ImportResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportResponse.DiscardUnknown(m)
 }
 
-func _DEQ_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(DEQServer).Get(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/deq.DEQ/Get",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DEQServer).Get(ctx, req.(*GetRequest))
+var xxx_messageInfo_ImportResponse proto.InternalMessageInfo
+
+func (m *ImportResponse) GetImportedCount() int64 {
+	if m != nil {
+		return m.ImportedCount
 	}
-	return interceptor(ctx, in, info, handler)
+	return 0
 }
 
-func _DEQ_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(DEQServer).List(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/deq.DEQ/List",
+type TopicsRequest struct {
+}
+
+func (m *TopicsRequest) Reset()         { *m = TopicsRequest{} }
+func (m *TopicsRequest) String() string { return proto.CompactTextString(m) }
+func (*TopicsRequest) ProtoMessage()    {}
+func (*TopicsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cc02b310faf1c402, []int{21}
+}
+func (m *TopicsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TopicsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TopicsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DEQServer).List(ctx, req.(*ListRequest))
+}
+func (m *TopicsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TopicsRequest.Merge(m, src)
+}
+func (m *TopicsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *TopicsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TopicsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TopicsRequest proto.InternalMessageInfo
+
+type TopicsResponse struct {
+	Topics []string `protobuf:"bytes,1,rep,name=topics,proto3" json:"topics,omitempty"`
+}
+
+func (m *TopicsResponse) Reset()         { *m = TopicsResponse{} }
+func (m *TopicsResponse) String() string { return proto.CompactTextString(m) }
+func (*TopicsResponse) ProtoMessage()    {}
+func (*TopicsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cc02b310faf1c402, []int{22}
+}
+func (m *TopicsResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TopicsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TopicsResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return interceptor(ctx, in, info, handler)
+}
+func (m *TopicsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TopicsResponse.Merge(m, src)
+}
+func (m *TopicsResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *TopicsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TopicsResponse.DiscardUnknown(m)
 }
 
-func _DEQ_Del_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DelRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+var xxx_messageInfo_TopicsResponse proto.InternalMessageInfo
+
+func (m *TopicsResponse) GetTopics() []string {
+	if m != nil {
+		return m.Topics
 	}
-	if interceptor == nil {
-		return srv.(DEQServer).Del(ctx, in)
+	return nil
+}
+
+type Schema struct {
+	// The topic of the schema.
+	Topic string `protobuf:"bytes,1,opt,name=topic,proto3" json:"topic,omitempty"`
+	// The full name of the protobuf message type of the topic's payloads.
+	MessageType string `protobuf:"bytes,2,opt,name=message_type,json=messageType,proto3" json:"message_type,omitempty"`
+	// A serialized google.protobuf.FileDescriptorSet that contains message_type and every type it
+	// depends on.
+	FileDescriptorSet []byte `protobuf:"bytes,3,opt,name=file_descriptor_set,json=fileDescriptorSet,proto3" json:"file_descriptor_set,omitempty"`
+	// Incremented each time the topic's schema is updated, starting at 1.
+	Version int64 `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (m *Schema) Reset()         { *m = Schema{} }
+func (m *Schema) String() string { return proto.CompactTextString(m) }
+func (*Schema) ProtoMessage()    {}
+func (*Schema) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cc02b310faf1c402, []int{23}
+}
+func (m *Schema) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Schema) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Schema.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/deq.DEQ/Del",
+}
+func (m *Schema) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Schema.Merge(m, src)
+}
+func (m *Schema) XXX_Size() int {
+	return m.Size()
+}
+func (m *Schema) XXX_DiscardUnknown() {
+	xxx_messageInfo_Schema.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Schema proto.InternalMessageInfo
+
+func (m *Schema) GetTopic() string {
+	if m != nil {
+		return m.Topic
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DEQServer).Del(ctx, req.(*DelRequest))
+	return ""
+}
+
+func (m *Schema) GetMessageType() string {
+	if m != nil {
+		return m.MessageType
 	}
-	return interceptor(ctx, in, info, handler)
+	return ""
 }
 
-func _DEQ_Topics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(TopicsRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (m *Schema) GetFileDescriptorSet() []byte {
+	if m != nil {
+		return m.FileDescriptorSet
 	}
-	if interceptor == nil {
-		return srv.(DEQServer).Topics(ctx, in)
+	return nil
+}
+
+func (m *Schema) GetVersion() int64 {
+	if m != nil {
+		return m.Version
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/deq.DEQ/Topics",
+	return 0
+}
+
+type RegisterSchemaRequest struct {
+	// Required. The topic to register the schema of.
+	Topic string `protobuf:"bytes,1,opt,name=topic,proto3" json:"topic,omitempty"`
+	// The full name of the protobuf message type of the topic's payloads. Defaults to the topic.
+	MessageType string `protobuf:"bytes,2,opt,name=message_type,json=messageType,proto3" json:"message_type,omitempty"`
+	// Required. A serialized google.protobuf.FileDescriptorSet that contains message_type and every
+	// type it depends on, such as the output of protoc --include_imports --descriptor_set_out.
+	FileDescriptorSet []byte `protobuf:"bytes,3,opt,name=file_descriptor_set,json=fileDescriptorSet,proto3" json:"file_descriptor_set,omitempty"`
+}
+
+func (m *RegisterSchemaRequest) Reset()         { *m = RegisterSchemaRequest{} }
+func (m *RegisterSchemaRequest) String() string { return proto.CompactTextString(m) }
+func (*RegisterSchemaRequest) ProtoMessage()    {}
+func (*RegisterSchemaRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cc02b310faf1c402, []int{24}
+}
+func (m *RegisterSchemaRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RegisterSchemaRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RegisterSchemaRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DEQServer).Topics(ctx, req.(*TopicsRequest))
+}
+func (m *RegisterSchemaRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RegisterSchemaRequest.Merge(m, src)
+}
+func (m *RegisterSchemaRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *RegisterSchemaRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RegisterSchemaRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RegisterSchemaRequest proto.InternalMessageInfo
+
+func (m *RegisterSchemaRequest) GetTopic() string {
+	if m != nil {
+		return m.Topic
 	}
-	return interceptor(ctx, in, info, handler)
+	return ""
 }
 
-var _DEQ_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "deq.DEQ",
-	HandlerType: (*DEQServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "Pub",
-			Handler:    _DEQ_Pub_Handler,
-		},
-		{
-			MethodName: "Ack",
-			Handler:    _DEQ_Ack_Handler,
-		},
-		{
-			MethodName: "Get",
-			Handler:    _DEQ_Get_Handler,
-		},
-		{
-			MethodName: "List",
-			Handler:    _DEQ_List_Handler,
-		},
-		{
-			MethodName: "Del",
-			Handler:    _DEQ_Del_Handler,
-		},
-		{
-			MethodName: "Topics",
-			Handler:    _DEQ_Topics_Handler,
-		},
-	},
-	Streams: []grpc.StreamDesc{
-		{
-			StreamName:    "Sub",
-			Handler:       _DEQ_Sub_Handler,
-			ServerStreams: true,
-		},
-	},
-	Metadata: "deq.proto",
+func (m *RegisterSchemaRequest) GetMessageType() string {
+	if m != nil {
+		return m.MessageType
+	}
+	return ""
 }
 
-func (m *Event) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *RegisterSchemaRequest) GetFileDescriptorSet() []byte {
+	if m != nil {
+		return m.FileDescriptorSet
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *Event) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Id) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintDeq(dAtA, i, uint64(len(m.Id)))
-		i += copy(dAtA[i:], m.Id)
-	}
-	if len(m.Topic) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintDeq(dAtA, i, uint64(len(m.Topic)))
-		i += copy(dAtA[i:], m.Topic)
-	}
-	if len(m.Payload) > 0 {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintDeq(dAtA, i, uint64(len(m.Payload)))
-		i += copy(dAtA[i:], m.Payload)
-	}
-	if m.CreateTime != 0 {
-		dAtA[i] = 0x21
-		i++
-		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.CreateTime))
-		i += 8
-	}
-	if m.DefaultState != 0 {
-		dAtA[i] = 0x28
-		i++
-		i = encodeVarintDeq(dAtA, i, uint64(m.DefaultState))
-	}
-	if m.State != 0 {
-		dAtA[i] = 0x30
-		i++
-		i = encodeVarintDeq(dAtA, i, uint64(m.State))
-	}
-	if m.RequeueCount != 0 {
-		dAtA[i] = 0x38
-		i++
-		i = encodeVarintDeq(dAtA, i, uint64(m.RequeueCount))
-	}
-	return i, nil
+type GetSchemaRequest struct {
+	// Required. The topic to get the schema of.
+	Topic string `protobuf:"bytes,1,opt,name=topic,proto3" json:"topic,omitempty"`
 }
 
-func (m *PubRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (m *GetSchemaRequest) Reset()         { *m = GetSchemaRequest{} }
+func (m *GetSchemaRequest) String() string { return proto.CompactTextString(m) }
+func (*GetSchemaRequest) ProtoMessage()    {}
+func (*GetSchemaRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cc02b310faf1c402, []int{25}
 }
-
-func (m *PubRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Event != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintDeq(dAtA, i, uint64(m.Event.Size()))
-		n1, err := m.Event.MarshalTo(dAtA[i:])
+func (m *GetSchemaRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetSchemaRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetSchemaRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i += n1
-	}
-	if len(m.AwaitChannel) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintDeq(dAtA, i, uint64(len(m.AwaitChannel)))
-		i += copy(dAtA[i:], m.AwaitChannel)
+		return b[:n], nil
 	}
-	return i, nil
+}
+func (m *GetSchemaRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetSchemaRequest.Merge(m, src)
+}
+func (m *GetSchemaRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetSchemaRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetSchemaRequest.DiscardUnknown(m)
 }
 
-func (m *SubRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_GetSchemaRequest proto.InternalMessageInfo
+
+func (m *GetSchemaRequest) GetTopic() string {
+	if m != nil {
+		return m.Topic
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *SubRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Channel) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintDeq(dAtA, i, uint64(len(m.Channel)))
-		i += copy(dAtA[i:], m.Channel)
-	}
-	if len(m.Topic) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintDeq(dAtA, i, uint64(len(m.Topic)))
-		i += copy(dAtA[i:], m.Topic)
-	}
-	if m.Follow {
-		dAtA[i] = 0x28
-		i++
-		if m.Follow {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
+// TopicConfig is the configuration of a topic. Zero values use the server's defaults.
+type TopicConfig struct {
+	// The topic of the configuration.
+	Topic string `protobuf:"bytes,1,opt,name=topic,proto3" json:"topic,omitempty"`
+	// The number of times an event is requeued on a channel before it is dequeued with
+	// DEQUEUED_ERROR. -1 removes the limit.
+	RequeueLimit int32 `protobuf:"varint,2,opt,name=requeue_limit,json=requeueLimit,proto3" json:"requeue_limit,omitempty"`
+	// The backoff of the topic's subscribers.
+	Backoff Backoff `protobuf:"varint,3,opt,name=backoff,proto3,enum=deq.Backoff" json:"backoff,omitempty"`
+	// The base delay of the backoff. Overridden by SubRequest.requeue_delay_milliseconds.
+	RequeueDelayMilliseconds int32 `protobuf:"varint,4,opt,name=requeue_delay_milliseconds,json=requeueDelayMilliseconds,proto3" json:"requeue_delay_milliseconds,omitempty"`
+	// The default_state of new events on the topic that don't specify one.
+	DefaultState EventState `protobuf:"varint,5,opt,name=default_state,json=defaultState,proto3,enum=deq.EventState" json:"default_state,omitempty"`
+	// If positive, events older than this are deleted.
+	RetentionMaxAgeMilliseconds int64 `protobuf:"varint,6,opt,name=retention_max_age_milliseconds,json=retentionMaxAgeMilliseconds,proto3" json:"retention_max_age_milliseconds,omitempty"`
+	// If positive, the oldest events are deleted once the topic has more than this many events.
+	RetentionMaxCount int64 `protobuf:"varint,7,opt,name=retention_max_count,json=retentionMaxCount,proto3" json:"retention_max_count,omitempty"`
+	// If set, a copy of each event that reaches its requeue limit on a channel is published to this
+	// topic, with metadata describing the failure.
+	DeadLetterTopic string `protobuf:"bytes,8,opt,name=dead_letter_topic,json=deadLetterTopic,proto3" json:"dead_letter_topic,omitempty"`
+}
+
+func (m *TopicConfig) Reset()         { *m = TopicConfig{} }
+func (m *TopicConfig) String() string { return proto.CompactTextString(m) }
+func (*TopicConfig) ProtoMessage()    {}
+func (*TopicConfig) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cc02b310faf1c402, []int{26}
+}
+func (m *TopicConfig) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TopicConfig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TopicConfig.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
 		}
-		i++
+		return b[:n], nil
 	}
-	if m.RequeueDelayMilliseconds != 0 {
-		dAtA[i] = 0x30
-		i++
-		i = encodeVarintDeq(dAtA, i, uint64(m.RequeueDelayMilliseconds))
+}
+func (m *TopicConfig) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TopicConfig.Merge(m, src)
+}
+func (m *TopicConfig) XXX_Size() int {
+	return m.Size()
+}
+func (m *TopicConfig) XXX_DiscardUnknown() {
+	xxx_messageInfo_TopicConfig.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TopicConfig proto.InternalMessageInfo
+
+func (m *TopicConfig) GetTopic() string {
+	if m != nil {
+		return m.Topic
 	}
-	if m.IdleTimeoutMilliseconds != 0 {
-		dAtA[i] = 0x38
-		i++
-		i = encodeVarintDeq(dAtA, i, uint64(m.IdleTimeoutMilliseconds))
+	return ""
+}
+
+func (m *TopicConfig) GetRequeueLimit() int32 {
+	if m != nil {
+		return m.RequeueLimit
 	}
-	return i, nil
+	return 0
 }
 
-func (m *AckRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *TopicConfig) GetBackoff() Backoff {
+	if m != nil {
+		return m.Backoff
 	}
-	return dAtA[:n], nil
+	return Backoff_BACKOFF_DEFAULT
 }
 
-func (m *AckRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Channel) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintDeq(dAtA, i, uint64(len(m.Channel)))
-		i += copy(dAtA[i:], m.Channel)
+func (m *TopicConfig) GetRequeueDelayMilliseconds() int32 {
+	if m != nil {
+		return m.RequeueDelayMilliseconds
 	}
-	if len(m.Topic) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintDeq(dAtA, i, uint64(len(m.Topic)))
-		i += copy(dAtA[i:], m.Topic)
+	return 0
+}
+
+func (m *TopicConfig) GetDefaultState() EventState {
+	if m != nil {
+		return m.DefaultState
 	}
-	if len(m.EventId) > 0 {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintDeq(dAtA, i, uint64(len(m.EventId)))
-		i += copy(dAtA[i:], m.EventId)
+	return EventState_UNSPECIFIED_STATE
+}
+
+func (m *TopicConfig) GetRetentionMaxAgeMilliseconds() int64 {
+	if m != nil {
+		return m.RetentionMaxAgeMilliseconds
 	}
-	if m.Code != 0 {
-		dAtA[i] = 0x20
-		i++
-		i = encodeVarintDeq(dAtA, i, uint64(m.Code))
+	return 0
+}
+
+func (m *TopicConfig) GetRetentionMaxCount() int64 {
+	if m != nil {
+		return m.RetentionMaxCount
 	}
-	return i, nil
+	return 0
 }
 
-func (m *AckResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *TopicConfig) GetDeadLetterTopic() string {
+	if m != nil {
+		return m.DeadLetterTopic
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *AckResponse) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	return i, nil
+type SetTopicConfigRequest struct {
+	// Required. The configuration to set. Its topic is required.
+	Config *TopicConfig `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
 }
 
-func (m *GetRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *SetTopicConfigRequest) Reset()         { *m = SetTopicConfigRequest{} }
+func (m *SetTopicConfigRequest) String() string { return proto.CompactTextString(m) }
+func (*SetTopicConfigRequest) ProtoMessage()    {}
+func (*SetTopicConfigRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cc02b310faf1c402, []int{27}
+}
+func (m *SetTopicConfigRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SetTopicConfigRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SetTopicConfigRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return dAtA[:n], nil
+}
+func (m *SetTopicConfigRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetTopicConfigRequest.Merge(m, src)
+}
+func (m *SetTopicConfigRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *SetTopicConfigRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetTopicConfigRequest.DiscardUnknown(m)
 }
 
-func (m *GetRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.EventId) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintDeq(dAtA, i, uint64(len(m.EventId)))
-		i += copy(dAtA[i:], m.EventId)
+var xxx_messageInfo_SetTopicConfigRequest proto.InternalMessageInfo
+
+func (m *SetTopicConfigRequest) GetConfig() *TopicConfig {
+	if m != nil {
+		return m.Config
 	}
-	if len(m.Topic) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintDeq(dAtA, i, uint64(len(m.Topic)))
-		i += copy(dAtA[i:], m.Topic)
-	}
-	if len(m.Channel) > 0 {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintDeq(dAtA, i, uint64(len(m.Channel)))
-		i += copy(dAtA[i:], m.Channel)
-	}
-	if m.Await {
-		dAtA[i] = 0x20
-		i++
-		if m.Await {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i++
-	}
-	return i, nil
+	return nil
 }
 
-func (m *ListRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+type GetTopicConfigRequest struct {
+	// Required. The topic to get the configuration of.
+	Topic string `protobuf:"bytes,1,opt,name=topic,proto3" json:"topic,omitempty"`
 }
 
-func (m *ListRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Topic) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintDeq(dAtA, i, uint64(len(m.Topic)))
-		i += copy(dAtA[i:], m.Topic)
-	}
-	if len(m.Channel) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintDeq(dAtA, i, uint64(len(m.Channel)))
-		i += copy(dAtA[i:], m.Channel)
-	}
-	if len(m.MinId) > 0 {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintDeq(dAtA, i, uint64(len(m.MinId)))
-		i += copy(dAtA[i:], m.MinId)
-	}
-	if len(m.MaxId) > 0 {
-		dAtA[i] = 0x22
-		i++
-		i = encodeVarintDeq(dAtA, i, uint64(len(m.MaxId)))
-		i += copy(dAtA[i:], m.MaxId)
-	}
-	if m.PageSize != 0 {
-		dAtA[i] = 0x28
-		i++
-		i = encodeVarintDeq(dAtA, i, uint64(m.PageSize))
-	}
-	if m.Reversed {
-		dAtA[i] = 0x30
-		i++
-		if m.Reversed {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
+func (m *GetTopicConfigRequest) Reset()         { *m = GetTopicConfigRequest{} }
+func (m *GetTopicConfigRequest) String() string { return proto.CompactTextString(m) }
+func (*GetTopicConfigRequest) ProtoMessage()    {}
+func (*GetTopicConfigRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cc02b310faf1c402, []int{28}
+}
+func (m *GetTopicConfigRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *GetTopicConfigRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_GetTopicConfigRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
 		}
-		i++
+		return b[:n], nil
 	}
-	return i, nil
+}
+func (m *GetTopicConfigRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetTopicConfigRequest.Merge(m, src)
+}
+func (m *GetTopicConfigRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *GetTopicConfigRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetTopicConfigRequest.DiscardUnknown(m)
 }
 
-func (m *ListResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_GetTopicConfigRequest proto.InternalMessageInfo
+
+func (m *GetTopicConfigRequest) GetTopic() string {
+	if m != nil {
+		return m.Topic
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *ListResponse) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Events) > 0 {
-		for _, msg := range m.Events {
-			dAtA[i] = 0xa
-			i++
-			i = encodeVarintDeq(dAtA, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(dAtA[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
+type RedriveRequest struct {
+	// Required. The topic of the events to redrive.
+	Topic string `protobuf:"bytes,1,opt,name=topic,proto3" json:"topic,omitempty"`
+	// Required. The channel to redrive events on.
+	Channel string `protobuf:"bytes,2,opt,name=channel,proto3" json:"channel,omitempty"`
+	// If positive, only events created at or after this time are redriven, represented as the
+	// number of nanoseconds since the unix epoch.
+	MinCreateTime int64 `protobuf:"fixed64,3,opt,name=min_create_time,json=minCreateTime,proto3" json:"min_create_time,omitempty"`
+	// If positive, only events created at or before this time are redriven, represented as the
+	// number of nanoseconds since the unix epoch.
+	MaxCreateTime int64 `protobuf:"fixed64,4,opt,name=max_create_time,json=maxCreateTime,proto3" json:"max_create_time,omitempty"`
+	// If specified, only events with ids lexigraphically greater than or equal to min_id are
+	// redriven.
+	MinId string `protobuf:"bytes,5,opt,name=min_id,json=minId,proto3" json:"min_id,omitempty"`
+	// If specified, only events with ids lexigraphically less than or equal to max_id are redriven.
+	MaxId string `protobuf:"bytes,6,opt,name=max_id,json=maxId,proto3" json:"max_id,omitempty"`
+	// If specified, only events with an index that starts with index_prefix are redriven.
+	IndexPrefix string `protobuf:"bytes,7,opt,name=index_prefix,json=indexPrefix,proto3" json:"index_prefix,omitempty"`
+}
+
+func (m *RedriveRequest) Reset()         { *m = RedriveRequest{} }
+func (m *RedriveRequest) String() string { return proto.CompactTextString(m) }
+func (*RedriveRequest) ProtoMessage()    {}
+func (*RedriveRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cc02b310faf1c402, []int{29}
+}
+func (m *RedriveRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RedriveRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RedriveRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	return i, nil
+}
+func (m *RedriveRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RedriveRequest.Merge(m, src)
+}
+func (m *RedriveRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *RedriveRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RedriveRequest.DiscardUnknown(m)
 }
 
-func (m *DelRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_RedriveRequest proto.InternalMessageInfo
+
+func (m *RedriveRequest) GetTopic() string {
+	if m != nil {
+		return m.Topic
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *DelRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.EventId) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintDeq(dAtA, i, uint64(len(m.EventId)))
-		i += copy(dAtA[i:], m.EventId)
-	}
-	if len(m.Topic) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintDeq(dAtA, i, uint64(len(m.Topic)))
-		i += copy(dAtA[i:], m.Topic)
+func (m *RedriveRequest) GetChannel() string {
+	if m != nil {
+		return m.Channel
 	}
-	return i, nil
+	return ""
 }
 
-func (m *TopicsRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *RedriveRequest) GetMinCreateTime() int64 {
+	if m != nil {
+		return m.MinCreateTime
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *TopicsRequest) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	return i, nil
+func (m *RedriveRequest) GetMaxCreateTime() int64 {
+	if m != nil {
+		return m.MaxCreateTime
+	}
+	return 0
 }
 
-func (m *TopicsResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *RedriveRequest) GetMinId() string {
+	if m != nil {
+		return m.MinId
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *TopicsResponse) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Topics) > 0 {
-		for _, s := range m.Topics {
-			dAtA[i] = 0xa
-			i++
-			l = len(s)
-			for l >= 1<<7 {
-				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
-				l >>= 7
-				i++
-			}
-			dAtA[i] = uint8(l)
-			i++
-			i += copy(dAtA[i:], s)
-		}
+func (m *RedriveRequest) GetMaxId() string {
+	if m != nil {
+		return m.MaxId
 	}
-	return i, nil
+	return ""
 }
 
-func (m *Empty) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *RedriveRequest) GetIndexPrefix() string {
+	if m != nil {
+		return m.IndexPrefix
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *Empty) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	return i, nil
+type RedriveResponse struct {
+	// The number of events redriven.
+	RedrivenCount int64 `protobuf:"varint,1,opt,name=redriven_count,json=redrivenCount,proto3" json:"redriven_count,omitempty"`
 }
 
-func (m *EventV0) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
+func (m *RedriveResponse) Reset()         { *m = RedriveResponse{} }
+func (m *RedriveResponse) String() string { return proto.CompactTextString(m) }
+func (*RedriveResponse) ProtoMessage()    {}
+func (*RedriveResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cc02b310faf1c402, []int{30}
 }
-
-func (m *EventV0) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.Payload != nil {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintDeq(dAtA, i, uint64(m.Payload.Size()))
-		n2, err := m.Payload.MarshalTo(dAtA[i:])
+func (m *RedriveResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RedriveResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RedriveResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
-		i += n2
+		return b[:n], nil
 	}
-	if len(m.Id) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintDeq(dAtA, i, uint64(len(m.Id)))
-		i += copy(dAtA[i:], m.Id)
-	}
-	if len(m.Key) > 0 {
-		dAtA[i] = 0x1a
-		i++
-		i = encodeVarintDeq(dAtA, i, uint64(len(m.Key)))
-		i += copy(dAtA[i:], m.Key)
-	}
-	return i, nil
+}
+func (m *RedriveResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RedriveResponse.Merge(m, src)
+}
+func (m *RedriveResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *RedriveResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RedriveResponse.DiscardUnknown(m)
 }
 
-func (m *Any) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_RedriveResponse proto.InternalMessageInfo
+
+func (m *RedriveResponse) GetRedrivenCount() int64 {
+	if m != nil {
+		return m.RedrivenCount
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *Any) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.TypeUrl) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintDeq(dAtA, i, uint64(len(m.TypeUrl)))
-		i += copy(dAtA[i:], m.TypeUrl)
-	}
-	if len(m.Value) > 0 {
-		dAtA[i] = 0x12
-		i++
-		i = encodeVarintDeq(dAtA, i, uint64(len(m.Value)))
-		i += copy(dAtA[i:], m.Value)
-	}
-	return i, nil
+// Schedule publishes an event to a topic on each of its ticks. The event published on a tick has
+// the id "<name>/<tick>", where tick is the RFC 3339 time of the tick in UTC, and the tick as its
+// create_time, so each tick is published at most once.
+type Schedule struct {
+	// Required. The name of the schedule.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// A cron expression of the schedule's ticks in UTC, such as "*/15 * * * *" or "@daily". Exactly
+	// one of cron and interval_milliseconds is required.
+	Cron string `protobuf:"bytes,2,opt,name=cron,proto3" json:"cron,omitempty"`
+	// The time between the schedule's ticks, which are at multiples of the interval since the unix
+	// epoch. Exactly one of cron and interval_milliseconds is required.
+	IntervalMilliseconds int64 `protobuf:"varint,3,opt,name=interval_milliseconds,json=intervalMilliseconds,proto3" json:"interval_milliseconds,omitempty"`
+	// Required. The topic of the published events.
+	Topic string `protobuf:"bytes,4,opt,name=topic,proto3" json:"topic,omitempty"`
+	// The payload of the published events.
+	Payload []byte `protobuf:"bytes,5,opt,name=payload,proto3" json:"payload,omitempty"`
+	// The metadata of the published events.
+	Metadata map[string]string `protobuf:"bytes,6,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// The time of the last tick that published an event, represented as the number of nanoseconds
+	// since the unix epoch.
+	// Output only.
+	LastTick int64 `protobuf:"fixed64,7,opt,name=last_tick,json=lastTick,proto3" json:"last_tick,omitempty"`
 }
 
-func encodeVarintDeq(dAtA []byte, offset int, v uint64) int {
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
-	}
-	dAtA[offset] = uint8(v)
-	return offset + 1
+func (m *Schedule) Reset()         { *m = Schedule{} }
+func (m *Schedule) String() string { return proto.CompactTextString(m) }
+func (*Schedule) ProtoMessage()    {}
+func (*Schedule) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cc02b310faf1c402, []int{31}
 }
-func (m *Event) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Id)
-	if l > 0 {
-		n += 1 + l + sovDeq(uint64(l))
-	}
-	l = len(m.Topic)
-	if l > 0 {
-		n += 1 + l + sovDeq(uint64(l))
-	}
-	l = len(m.Payload)
-	if l > 0 {
-		n += 1 + l + sovDeq(uint64(l))
-	}
-	if m.CreateTime != 0 {
-		n += 9
-	}
-	if m.DefaultState != 0 {
-		n += 1 + sovDeq(uint64(m.DefaultState))
-	}
-	if m.State != 0 {
-		n += 1 + sovDeq(uint64(m.State))
-	}
-	if m.RequeueCount != 0 {
-		n += 1 + sovDeq(uint64(m.RequeueCount))
+func (m *Schedule) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Schedule) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Schedule.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return n
+}
+func (m *Schedule) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Schedule.Merge(m, src)
+}
+func (m *Schedule) XXX_Size() int {
+	return m.Size()
+}
+func (m *Schedule) XXX_DiscardUnknown() {
+	xxx_messageInfo_Schedule.DiscardUnknown(m)
 }
 
-func (m *PubRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Event != nil {
-		l = m.Event.Size()
-		n += 1 + l + sovDeq(uint64(l))
-	}
-	l = len(m.AwaitChannel)
-	if l > 0 {
-		n += 1 + l + sovDeq(uint64(l))
+var xxx_messageInfo_Schedule proto.InternalMessageInfo
+
+func (m *Schedule) GetName() string {
+	if m != nil {
+		return m.Name
 	}
-	return n
+	return ""
 }
 
-func (m *SubRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Channel)
-	if l > 0 {
-		n += 1 + l + sovDeq(uint64(l))
-	}
-	l = len(m.Topic)
-	if l > 0 {
-		n += 1 + l + sovDeq(uint64(l))
-	}
-	if m.Follow {
-		n += 2
-	}
-	if m.RequeueDelayMilliseconds != 0 {
-		n += 1 + sovDeq(uint64(m.RequeueDelayMilliseconds))
-	}
-	if m.IdleTimeoutMilliseconds != 0 {
-		n += 1 + sovDeq(uint64(m.IdleTimeoutMilliseconds))
+func (m *Schedule) GetCron() string {
+	if m != nil {
+		return m.Cron
 	}
-	return n
+	return ""
 }
 
-func (m *AckRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Channel)
-	if l > 0 {
-		n += 1 + l + sovDeq(uint64(l))
-	}
-	l = len(m.Topic)
-	if l > 0 {
-		n += 1 + l + sovDeq(uint64(l))
-	}
-	l = len(m.EventId)
-	if l > 0 {
-		n += 1 + l + sovDeq(uint64(l))
-	}
-	if m.Code != 0 {
-		n += 1 + sovDeq(uint64(m.Code))
+func (m *Schedule) GetIntervalMilliseconds() int64 {
+	if m != nil {
+		return m.IntervalMilliseconds
 	}
-	return n
+	return 0
 }
 
-func (m *AckResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *Schedule) GetTopic() string {
+	if m != nil {
+		return m.Topic
 	}
-	var l int
-	_ = l
-	return n
+	return ""
 }
 
-func (m *GetRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.EventId)
-	if l > 0 {
-		n += 1 + l + sovDeq(uint64(l))
-	}
-	l = len(m.Topic)
-	if l > 0 {
-		n += 1 + l + sovDeq(uint64(l))
-	}
-	l = len(m.Channel)
-	if l > 0 {
-		n += 1 + l + sovDeq(uint64(l))
-	}
-	if m.Await {
-		n += 2
+func (m *Schedule) GetPayload() []byte {
+	if m != nil {
+		return m.Payload
 	}
-	return n
+	return nil
 }
 
-func (m *ListRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Topic)
-	if l > 0 {
-		n += 1 + l + sovDeq(uint64(l))
-	}
-	l = len(m.Channel)
-	if l > 0 {
-		n += 1 + l + sovDeq(uint64(l))
-	}
-	l = len(m.MinId)
-	if l > 0 {
-		n += 1 + l + sovDeq(uint64(l))
-	}
-	l = len(m.MaxId)
-	if l > 0 {
-		n += 1 + l + sovDeq(uint64(l))
-	}
-	if m.PageSize != 0 {
-		n += 1 + sovDeq(uint64(m.PageSize))
-	}
-	if m.Reversed {
-		n += 2
+func (m *Schedule) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
 	}
-	return n
+	return nil
 }
 
-func (m *ListResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if len(m.Events) > 0 {
-		for _, e := range m.Events {
-			l = e.Size()
-			n += 1 + l + sovDeq(uint64(l))
-		}
+func (m *Schedule) GetLastTick() int64 {
+	if m != nil {
+		return m.LastTick
 	}
-	return n
+	return 0
 }
 
-func (m *DelRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.EventId)
-	if l > 0 {
-		n += 1 + l + sovDeq(uint64(l))
-	}
-	l = len(m.Topic)
-	if l > 0 {
-		n += 1 + l + sovDeq(uint64(l))
-	}
-	return n
+type SetScheduleRequest struct {
+	// Required. The schedule to create or update.
+	Schedule *Schedule `protobuf:"bytes,1,opt,name=schedule,proto3" json:"schedule,omitempty"`
 }
 
-func (m *TopicsRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	return n
+func (m *SetScheduleRequest) Reset()         { *m = SetScheduleRequest{} }
+func (m *SetScheduleRequest) String() string { return proto.CompactTextString(m) }
+func (*SetScheduleRequest) ProtoMessage()    {}
+func (*SetScheduleRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cc02b310faf1c402, []int{32}
+}
+func (m *SetScheduleRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SetScheduleRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SetScheduleRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SetScheduleRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetScheduleRequest.Merge(m, src)
+}
+func (m *SetScheduleRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *SetScheduleRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetScheduleRequest.DiscardUnknown(m)
 }
 
-func (m *TopicsResponse) Size() (n int) {
-	if m == nil {
-		return 0
+var xxx_messageInfo_SetScheduleRequest proto.InternalMessageInfo
+
+func (m *SetScheduleRequest) GetSchedule() *Schedule {
+	if m != nil {
+		return m.Schedule
 	}
-	var l int
-	_ = l
-	if len(m.Topics) > 0 {
-		for _, s := range m.Topics {
-			l = len(s)
-			n += 1 + l + sovDeq(uint64(l))
+	return nil
+}
+
+type ListSchedulesRequest struct {
+}
+
+func (m *ListSchedulesRequest) Reset()         { *m = ListSchedulesRequest{} }
+func (m *ListSchedulesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListSchedulesRequest) ProtoMessage()    {}
+func (*ListSchedulesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cc02b310faf1c402, []int{33}
+}
+func (m *ListSchedulesRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ListSchedulesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ListSchedulesRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	return n
+}
+func (m *ListSchedulesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListSchedulesRequest.Merge(m, src)
+}
+func (m *ListSchedulesRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ListSchedulesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListSchedulesRequest.DiscardUnknown(m)
 }
 
-func (m *Empty) Size() (n int) {
-	if m == nil {
-		return 0
+var xxx_messageInfo_ListSchedulesRequest proto.InternalMessageInfo
+
+type ListSchedulesResponse struct {
+	Schedules []*Schedule `protobuf:"bytes,1,rep,name=schedules,proto3" json:"schedules,omitempty"`
+}
+
+func (m *ListSchedulesResponse) Reset()         { *m = ListSchedulesResponse{} }
+func (m *ListSchedulesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListSchedulesResponse) ProtoMessage()    {}
+func (*ListSchedulesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cc02b310faf1c402, []int{34}
+}
+func (m *ListSchedulesResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ListSchedulesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ListSchedulesResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	var l int
-	_ = l
-	return n
+}
+func (m *ListSchedulesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListSchedulesResponse.Merge(m, src)
+}
+func (m *ListSchedulesResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ListSchedulesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListSchedulesResponse.DiscardUnknown(m)
 }
 
-func (m *EventV0) Size() (n int) {
-	if m == nil {
-		return 0
+var xxx_messageInfo_ListSchedulesResponse proto.InternalMessageInfo
+
+func (m *ListSchedulesResponse) GetSchedules() []*Schedule {
+	if m != nil {
+		return m.Schedules
 	}
-	var l int
-	_ = l
-	if m.Payload != nil {
-		l = m.Payload.Size()
-		n += 1 + l + sovDeq(uint64(l))
+	return nil
+}
+
+type DelScheduleRequest struct {
+	// Required. The name of the schedule to delete.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (m *DelScheduleRequest) Reset()         { *m = DelScheduleRequest{} }
+func (m *DelScheduleRequest) String() string { return proto.CompactTextString(m) }
+func (*DelScheduleRequest) ProtoMessage()    {}
+func (*DelScheduleRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cc02b310faf1c402, []int{35}
+}
+func (m *DelScheduleRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DelScheduleRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DelScheduleRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	l = len(m.Id)
-	if l > 0 {
-		n += 1 + l + sovDeq(uint64(l))
+}
+func (m *DelScheduleRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DelScheduleRequest.Merge(m, src)
+}
+func (m *DelScheduleRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *DelScheduleRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DelScheduleRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DelScheduleRequest proto.InternalMessageInfo
+
+func (m *DelScheduleRequest) GetName() string {
+	if m != nil {
+		return m.Name
 	}
-	l = len(m.Key)
-	if l > 0 {
-		n += 1 + l + sovDeq(uint64(l))
+	return ""
+}
+
+type Empty struct {
+}
+
+func (m *Empty) Reset()         { *m = Empty{} }
+func (m *Empty) String() string { return proto.CompactTextString(m) }
+func (*Empty) ProtoMessage()    {}
+func (*Empty) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cc02b310faf1c402, []int{36}
+}
+func (m *Empty) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Empty) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Empty.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return n
+}
+func (m *Empty) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Empty.Merge(m, src)
+}
+func (m *Empty) XXX_Size() int {
+	return m.Size()
+}
+func (m *Empty) XXX_DiscardUnknown() {
+	xxx_messageInfo_Empty.DiscardUnknown(m)
 }
 
-func (m *Any) Size() (n int) {
-	if m == nil {
-		return 0
+var xxx_messageInfo_Empty proto.InternalMessageInfo
+
+// EventV0 is used for upgrading from a V0 database, and should not be used by clients.
+type EventV0 struct {
+	Payload *Any   `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	Id      []byte `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Key     []byte `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+}
+
+func (m *EventV0) Reset()         { *m = EventV0{} }
+func (m *EventV0) String() string { return proto.CompactTextString(m) }
+func (*EventV0) ProtoMessage()    {}
+func (*EventV0) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cc02b310faf1c402, []int{37}
+}
+func (m *EventV0) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *EventV0) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_EventV0.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	var l int
-	_ = l
-	l = len(m.TypeUrl)
-	if l > 0 {
-		n += 1 + l + sovDeq(uint64(l))
+}
+func (m *EventV0) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EventV0.Merge(m, src)
+}
+func (m *EventV0) XXX_Size() int {
+	return m.Size()
+}
+func (m *EventV0) XXX_DiscardUnknown() {
+	xxx_messageInfo_EventV0.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EventV0 proto.InternalMessageInfo
+
+func (m *EventV0) GetPayload() *Any {
+	if m != nil {
+		return m.Payload
 	}
-	l = len(m.Value)
-	if l > 0 {
-		n += 1 + l + sovDeq(uint64(l))
+	return nil
+}
+
+func (m *EventV0) GetId() []byte {
+	if m != nil {
+		return m.Id
 	}
-	return n
+	return nil
 }
 
-func sovDeq(x uint64) (n int) {
-	for {
-		n++
-		x >>= 7
-		if x == 0 {
-			break
+func (m *EventV0) GetKey() []byte {
+	if m != nil {
+		return m.Key
+	}
+	return nil
+}
+
+// Any is used for upgrading from a V0 database, and should not be used by clients.
+type Any struct {
+	TypeUrl string `protobuf:"bytes,1,opt,name=type_url,json=typeUrl,proto3" json:"type_url,omitempty"`
+	Value   []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (m *Any) Reset()         { *m = Any{} }
+func (m *Any) String() string { return proto.CompactTextString(m) }
+func (*Any) ProtoMessage()    {}
+func (*Any) Descriptor() ([]byte, []int) {
+	return fileDescriptor_cc02b310faf1c402, []int{38}
+}
+func (m *Any) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Any) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Any.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	return n
 }
-func sozDeq(x uint64) (n int) {
-	return sovDeq(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+func (m *Any) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Any.Merge(m, src)
+}
+func (m *Any) XXX_Size() int {
+	return m.Size()
+}
+func (m *Any) XXX_DiscardUnknown() {
+	xxx_messageInfo_Any.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Any proto.InternalMessageInfo
+
+func (m *Any) GetTypeUrl() string {
+	if m != nil {
+		return m.TypeUrl
+	}
+	return ""
+}
+
+func (m *Any) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("deq.EventState", EventState_name, EventState_value)
+	proto.RegisterEnum("deq.AckCode", AckCode_name, AckCode_value)
+	proto.RegisterEnum("deq.ExportFormat", ExportFormat_name, ExportFormat_value)
+	proto.RegisterEnum("deq.Backoff", Backoff_name, Backoff_value)
+	proto.RegisterType((*Event)(nil), "deq.Event")
+	proto.RegisterMapType((map[string]string)(nil), "deq.Event.MetadataEntry")
+	proto.RegisterType((*PubRequest)(nil), "deq.PubRequest")
+	proto.RegisterType((*PubBatchRequest)(nil), "deq.PubBatchRequest")
+	proto.RegisterType((*PubBatchResponse)(nil), "deq.PubBatchResponse")
+	proto.RegisterType((*SubRequest)(nil), "deq.SubRequest")
+	proto.RegisterType((*AckRequest)(nil), "deq.AckRequest")
+	proto.RegisterType((*AckResponse)(nil), "deq.AckResponse")
+	proto.RegisterType((*GetRequest)(nil), "deq.GetRequest")
+	proto.RegisterType((*ListRequest)(nil), "deq.ListRequest")
+	proto.RegisterType((*ListResponse)(nil), "deq.ListResponse")
+	proto.RegisterType((*DelRequest)(nil), "deq.DelRequest")
+	proto.RegisterType((*DelTopicRequest)(nil), "deq.DelTopicRequest")
+	proto.RegisterType((*DelTopicResponse)(nil), "deq.DelTopicResponse")
+	proto.RegisterType((*BackupRequest)(nil), "deq.BackupRequest")
+	proto.RegisterType((*BackupChunk)(nil), "deq.BackupChunk")
+	proto.RegisterType((*RestoreRequest)(nil), "deq.RestoreRequest")
+	proto.RegisterType((*RestoreResponse)(nil), "deq.RestoreResponse")
+	proto.RegisterType((*ExportRequest)(nil), "deq.ExportRequest")
+	proto.RegisterType((*ExportChunk)(nil), "deq.ExportChunk")
+	proto.RegisterType((*ImportRequest)(nil), "deq.ImportRequest")
+	proto.RegisterType((*ImportResponse)(nil), "deq.ImportResponse")
+	proto.RegisterType((*TopicsRequest)(nil), "deq.TopicsRequest")
+	proto.RegisterType((*TopicsResponse)(nil), "deq.TopicsResponse")
+	proto.RegisterType((*Schema)(nil), "deq.Schema")
+	proto.RegisterType((*RegisterSchemaRequest)(nil), "deq.RegisterSchemaRequest")
+	proto.RegisterType((*GetSchemaRequest)(nil), "deq.GetSchemaRequest")
+	proto.RegisterType((*TopicConfig)(nil), "deq.TopicConfig")
+	proto.RegisterType((*SetTopicConfigRequest)(nil), "deq.SetTopicConfigRequest")
+	proto.RegisterType((*GetTopicConfigRequest)(nil), "deq.GetTopicConfigRequest")
+	proto.RegisterType((*RedriveRequest)(nil), "deq.RedriveRequest")
+	proto.RegisterType((*RedriveResponse)(nil), "deq.RedriveResponse")
+	proto.RegisterType((*Schedule)(nil), "deq.Schedule")
+	proto.RegisterMapType((map[string]string)(nil), "deq.Schedule.MetadataEntry")
+	proto.RegisterType((*SetScheduleRequest)(nil), "deq.SetScheduleRequest")
+	proto.RegisterType((*ListSchedulesRequest)(nil), "deq.ListSchedulesRequest")
+	proto.RegisterType((*ListSchedulesResponse)(nil), "deq.ListSchedulesResponse")
+	proto.RegisterType((*DelScheduleRequest)(nil), "deq.DelScheduleRequest")
+	proto.RegisterType((*Empty)(nil), "deq.Empty")
+	proto.RegisterType((*EventV0)(nil), "deq.EventV0")
+	proto.RegisterType((*Any)(nil), "deq.Any")
+}
+
+func init() { proto.RegisterFile("deq.proto", fileDescriptor_cc02b310faf1c402) }
+
+var fileDescriptor_cc02b310faf1c402 = []byte{
+	// 2011 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x58, 0x4b, 0x73, 0xdb, 0xd6,
+	0xf5, 0x17, 0x08, 0xf1, 0x75, 0xf8, 0x82, 0xae, 0x44, 0x9b, 0x86, 0xff, 0x7f, 0x95, 0x46, 0xe2,
+	0x0c, 0xe3, 0x4c, 0x55, 0x57, 0x76, 0xec, 0x34, 0x69, 0x9b, 0xa1, 0x48, 0x4a, 0xc3, 0x46, 0x16,
+	0x15, 0x90, 0xea, 0x74, 0x87, 0x81, 0x80, 0x2b, 0x09, 0x23, 0x10, 0xa0, 0x81, 0x4b, 0x59, 0xcc,
+	0xaa, 0x9b, 0xae, 0xba, 0x68, 0x67, 0xfa, 0x15, 0xba, 0xea, 0x37, 0xe8, 0x37, 0xe8, 0x32, 0xcb,
+	0xce, 0x74, 0xd3, 0xda, 0xdf, 0xa2, 0xab, 0xce, 0x7d, 0xe0, 0x45, 0x51, 0xb6, 0xe3, 0x76, 0xc7,
+	0xfb, 0x3b, 0xcf, 0x7b, 0xce, 0xc1, 0x39, 0xe7, 0x12, 0xca, 0x36, 0x7e, 0xb9, 0x33, 0x0b, 0x7c,
+	0xe2, 0x23, 0xd9, 0xc6, 0x2f, 0xb5, 0x7f, 0xc8, 0x90, 0x1f, 0x5c, 0x61, 0x8f, 0xa0, 0x3a, 0xe4,
+	0x1c, 0xbb, 0x25, 0xb5, 0xa5, 0x4e, 0x59, 0xcf, 0x39, 0x36, 0xda, 0x82, 0x3c, 0xf1, 0x67, 0x8e,
+	0xd5, 0xca, 0x31, 0x88, 0x1f, 0x50, 0x0b, 0x8a, 0x33, 0x73, 0xe1, 0xfa, 0xa6, 0xdd, 0x92, 0xdb,
+	0x52, 0xa7, 0xaa, 0x47, 0x47, 0xf4, 0x23, 0xa8, 0x58, 0x01, 0x36, 0x09, 0x36, 0x88, 0x33, 0xc5,
+	0xad, 0xf5, 0xb6, 0xd4, 0x51, 0x74, 0xe0, 0xd0, 0xc4, 0x99, 0x62, 0xf4, 0x14, 0x6a, 0x36, 0x3e,
+	0x33, 0xe7, 0x2e, 0x31, 0x42, 0x62, 0x12, 0xdc, 0xca, 0xb7, 0xa5, 0x4e, 0x7d, 0xb7, 0xb1, 0x43,
+	0x5d, 0x62, 0x3e, 0x8c, 0x29, 0xac, 0x57, 0x05, 0x17, 0x3b, 0xa1, 0x87, 0x90, 0xe7, 0xdc, 0x85,
+	0xd5, 0xdc, 0x9c, 0x8a, 0x3e, 0x82, 0x5a, 0x80, 0x5f, 0xce, 0xf1, 0x1c, 0x1b, 0x96, 0x3f, 0xf7,
+	0x48, 0xab, 0xd8, 0x96, 0x3a, 0x79, 0xbd, 0x2a, 0xc0, 0x1e, 0xc5, 0xd0, 0x53, 0x28, 0x4d, 0x31,
+	0x31, 0x6d, 0x93, 0x98, 0xad, 0x52, 0x5b, 0xee, 0x54, 0x76, 0x5b, 0x89, 0xba, 0x9d, 0x17, 0x82,
+	0x34, 0xf0, 0x48, 0xb0, 0xd0, 0x63, 0xce, 0xb4, 0x6a, 0xd7, 0x99, 0x3a, 0xa4, 0x55, 0xce, 0xa8,
+	0x3e, 0xa4, 0x18, 0xfa, 0x7f, 0x00, 0x1b, 0xbb, 0xce, 0x15, 0x0e, 0x0c, 0x93, 0xb4, 0x80, 0x5d,
+	0xbe, 0x2c, 0x90, 0x2e, 0x41, 0x2a, 0x94, 0x66, 0x81, 0xe3, 0x07, 0x0e, 0x59, 0xb4, 0x2a, 0x4c,
+	0x3c, 0x3e, 0xa3, 0xff, 0x83, 0x72, 0x68, 0x5d, 0x60, 0x7b, 0xee, 0x62, 0xbb, 0x55, 0x6d, 0x4b,
+	0x9d, 0x92, 0x9e, 0x00, 0xea, 0x57, 0x50, 0xcb, 0x38, 0x86, 0x14, 0x90, 0x2f, 0xf1, 0x42, 0x24,
+	0x8a, 0xfe, 0xa4, 0x99, 0xba, 0x32, 0xdd, 0x39, 0x8e, 0x32, 0xc5, 0x0e, 0x5f, 0xe6, 0xbe, 0x90,
+	0xb4, 0x31, 0xc0, 0xf1, 0xfc, 0x54, 0xa7, 0x8e, 0x86, 0x04, 0xb5, 0x21, 0x8f, 0xe9, 0x4d, 0x99,
+	0x6c, 0x65, 0x17, 0x92, 0xbb, 0xeb, 0x9c, 0x40, 0xaf, 0x6a, 0xbe, 0x32, 0x1d, 0x62, 0x58, 0x17,
+	0xa6, 0xe7, 0x61, 0x57, 0x68, 0xac, 0x32, 0xb0, 0xc7, 0x31, 0xed, 0x73, 0x68, 0x1c, 0xcf, 0x4f,
+	0xf7, 0x4c, 0x62, 0x5d, 0x44, 0x9a, 0x35, 0x28, 0x30, 0x05, 0x61, 0x4b, 0x6a, 0xcb, 0x4b, 0xaa,
+	0x05, 0x45, 0x7b, 0x06, 0x4a, 0x22, 0x16, 0xce, 0x7c, 0x2f, 0xc4, 0xef, 0x25, 0xf7, 0x6f, 0x09,
+	0x60, 0x9c, 0x5c, 0xa2, 0x05, 0xc5, 0xc8, 0x39, 0x1e, 0x82, 0xe8, 0x78, 0x4b, 0xc1, 0xde, 0x81,
+	0xc2, 0x99, 0xef, 0xba, 0xfe, 0x2b, 0x56, 0x6e, 0x25, 0x5d, 0x9c, 0xd0, 0x97, 0x70, 0xcf, 0xb1,
+	0x5d, 0x5e, 0xac, 0xfe, 0x9c, 0x18, 0x53, 0xc7, 0x75, 0x9d, 0x10, 0x5b, 0xbe, 0x67, 0x87, 0xa2,
+	0x78, 0xee, 0x52, 0x86, 0x09, 0xa7, 0xbf, 0x48, 0x91, 0xd1, 0xcf, 0x41, 0x8d, 0x2a, 0xc2, 0xc6,
+	0xae, 0xb9, 0xc8, 0x0a, 0x17, 0x98, 0x70, 0x4b, 0x70, 0xf4, 0x29, 0x43, 0x46, 0xfa, 0x11, 0x6c,
+	0xd8, 0xd8, 0xb4, 0x0d, 0x17, 0x13, 0x82, 0x03, 0x83, 0xfb, 0x5c, 0x62, 0x3e, 0x37, 0x28, 0xe1,
+	0x90, 0xe1, 0x13, 0x0a, 0x6b, 0x7f, 0x90, 0x00, 0xba, 0xd6, 0xe5, 0x87, 0x5e, 0xfe, 0x1e, 0x94,
+	0x58, 0x14, 0x0d, 0x87, 0x7f, 0xae, 0x65, 0xbd, 0xc8, 0xce, 0x43, 0x1b, 0xb5, 0x61, 0xdd, 0xf2,
+	0x6d, 0xfe, 0x9d, 0xd6, 0x77, 0xab, 0x2c, 0xf0, 0x5d, 0xeb, 0xb2, 0xe7, 0xdb, 0x58, 0x67, 0x14,
+	0xaa, 0x12, 0x07, 0x81, 0x1f, 0xb0, 0xc0, 0x95, 0x75, 0x7e, 0xd0, 0x6a, 0x50, 0x61, 0x0e, 0xf1,
+	0x0c, 0x6a, 0x53, 0x80, 0x03, 0x4c, 0x22, 0xff, 0xd2, 0xf6, 0xa4, 0xac, 0xbd, 0x5b, 0xdb, 0x49,
+	0x74, 0x21, 0xf9, 0xc6, 0x85, 0x58, 0xd5, 0x31, 0x07, 0x4b, 0x3a, 0x3f, 0x68, 0x7f, 0x96, 0xa0,
+	0x72, 0xe8, 0x84, 0xb1, 0xc1, 0x58, 0xab, 0x74, 0x8b, 0xd6, 0x5c, 0x56, 0x6b, 0x13, 0x0a, 0x53,
+	0xc7, 0x4b, 0xc2, 0x91, 0x9f, 0x3a, 0xde, 0xd0, 0x66, 0xb0, 0x79, 0x4d, 0xe1, 0x75, 0x01, 0x9b,
+	0xd7, 0x43, 0x1b, 0xdd, 0x87, 0xf2, 0xcc, 0x3c, 0xc7, 0x46, 0xe8, 0x7c, 0xc7, 0xbb, 0x15, 0xfd,
+	0x6c, 0xcd, 0x73, 0x3c, 0x76, 0xbe, 0xc3, 0xf4, 0x93, 0x0e, 0xf0, 0x15, 0x0e, 0x42, 0x6c, 0xb3,
+	0x94, 0x97, 0xf4, 0xf8, 0xac, 0xed, 0x42, 0x95, 0x7b, 0xf9, 0x03, 0xea, 0xfc, 0x17, 0x00, 0x7d,
+	0xec, 0x7e, 0x68, 0x24, 0xb5, 0xaf, 0xa1, 0xd1, 0xc7, 0x2e, 0xab, 0x9a, 0xb7, 0x07, 0xe7, 0x0e,
+	0x14, 0x4e, 0xf1, 0x99, 0x1f, 0xf0, 0x76, 0xa1, 0xe8, 0xe2, 0xa4, 0x3d, 0x07, 0x25, 0x51, 0x20,
+	0xfc, 0xfe, 0x88, 0xb6, 0x6c, 0x17, 0x13, 0x6c, 0x8b, 0xae, 0x4a, 0x35, 0xc9, 0x7a, 0x55, 0x80,
+	0xac, 0xab, 0x6a, 0x0f, 0xa1, 0xb6, 0x67, 0x5a, 0x97, 0xf3, 0x59, 0xca, 0x6e, 0xe8, 0x78, 0x16,
+	0x66, 0xdc, 0x05, 0x9d, 0x1f, 0xb4, 0xaf, 0xa0, 0xc2, 0xd9, 0x7a, 0x17, 0x73, 0xef, 0x12, 0x21,
+	0x58, 0x67, 0x7d, 0x58, 0x62, 0x53, 0x84, 0xfd, 0xa6, 0x79, 0xa3, 0x01, 0x74, 0x7c, 0x8f, 0xf9,
+	0x56, 0xd0, 0xa3, 0xa3, 0xf6, 0x31, 0xd4, 0x75, 0x1c, 0x12, 0x3f, 0xc0, 0x91, 0x91, 0x15, 0xf2,
+	0xda, 0x06, 0x34, 0x62, 0x2e, 0x51, 0x9f, 0xaf, 0xa0, 0x36, 0xb8, 0x9e, 0xf9, 0xc1, 0x3b, 0x2a,
+	0xe6, 0x53, 0xda, 0x25, 0x82, 0xa9, 0x49, 0x98, 0xe1, 0xfa, 0xee, 0x06, 0x4f, 0x10, 0x93, 0xdc,
+	0x67, 0x04, 0x5d, 0x30, 0xa0, 0x87, 0x50, 0x17, 0xd5, 0xc4, 0xc7, 0x58, 0xc8, 0x4a, 0xa9, 0xa4,
+	0xd7, 0x04, 0xca, 0xc6, 0x52, 0xa8, 0x3d, 0x80, 0x0a, 0x17, 0xbf, 0xf5, 0xba, 0xda, 0x11, 0xd4,
+	0x86, 0xd3, 0xb4, 0x6f, 0x89, 0x17, 0xd2, 0xbb, 0xbc, 0x88, 0xf4, 0xe5, 0x52, 0xfa, 0x9e, 0x43,
+	0x3d, 0xd2, 0x27, 0xf2, 0xf7, 0x10, 0xea, 0x0e, 0x43, 0x96, 0x12, 0x58, 0x8b, 0x50, 0x9e, 0xc1,
+	0x06, 0xd4, 0x58, 0xde, 0x43, 0xe1, 0x88, 0xd6, 0x81, 0x7a, 0x04, 0x08, 0x4d, 0x77, 0xa0, 0xc0,
+	0x22, 0xc5, 0x2b, 0xb8, 0xac, 0x8b, 0x93, 0xf6, 0x7b, 0x09, 0x0a, 0x63, 0xeb, 0x02, 0x4f, 0xcd,
+	0x5b, 0x22, 0xfb, 0x00, 0xaa, 0x53, 0x1c, 0x86, 0xf4, 0x33, 0x22, 0x8b, 0x59, 0x34, 0xa3, 0x2a,
+	0x02, 0x9b, 0x2c, 0x66, 0x18, 0xed, 0xc0, 0xe6, 0x99, 0xe3, 0xd2, 0x5e, 0x1a, 0x5a, 0x81, 0x33,
+	0x23, 0x7e, 0x60, 0x84, 0x98, 0x88, 0xfd, 0x62, 0x83, 0x92, 0xfa, 0x31, 0x65, 0x8c, 0x49, 0xba,
+	0x4c, 0xd6, 0xd9, 0x75, 0xe2, 0x32, 0xf9, 0xad, 0x04, 0x4d, 0x1d, 0x9f, 0x3b, 0x21, 0xc1, 0x01,
+	0xf7, 0xea, 0xed, 0x69, 0xff, 0xdf, 0x3b, 0xa7, 0x75, 0x40, 0x39, 0xc0, 0xe4, 0x3d, 0x8c, 0x6b,
+	0xbf, 0x93, 0xa1, 0xc2, 0xa2, 0xdc, 0xf3, 0xbd, 0x33, 0xe7, 0xfc, 0x16, 0x17, 0x6f, 0x6c, 0x1f,
+	0xb9, 0x15, 0xdb, 0xc7, 0x27, 0x50, 0x3c, 0x35, 0xad, 0x4b, 0xff, 0xec, 0xac, 0x25, 0xa7, 0xfa,
+	0xf9, 0x1e, 0xc7, 0xf4, 0x88, 0xf8, 0x8e, 0xc1, 0xb5, 0xfe, 0x8e, 0xc1, 0xf5, 0x61, 0x0b, 0x5c,
+	0x0f, 0xb6, 0x03, 0x4c, 0xb0, 0x47, 0x1c, 0xdf, 0x33, 0x68, 0x97, 0xa5, 0xd1, 0xbe, 0x31, 0x30,
+	0x65, 0xfd, 0x7e, 0xcc, 0xf5, 0xc2, 0xbc, 0xee, 0x9e, 0xe3, 0x8c, 0xe9, 0x1d, 0xd8, 0xcc, 0x2a,
+	0x49, 0x96, 0x3c, 0x59, 0xdf, 0x48, 0x4b, 0xf2, 0x4d, 0xef, 0x87, 0xcc, 0xd8, 0x2e, 0x34, 0xc7,
+	0x98, 0xa4, 0x32, 0x11, 0xa5, 0xad, 0x03, 0x05, 0x8b, 0x01, 0x62, 0x61, 0x52, 0xd8, 0x45, 0xd3,
+	0x8c, 0x82, 0xae, 0xfd, 0x18, 0x9a, 0x07, 0x2b, 0x55, 0xac, 0xce, 0xfc, 0xbf, 0x24, 0xda, 0xce,
+	0xec, 0xc0, 0xb9, 0xc2, 0x1f, 0x3a, 0xc8, 0x3e, 0x81, 0x06, 0x1d, 0x64, 0xe9, 0x8d, 0x5b, 0x66,
+	0xed, 0xbc, 0x36, 0x75, 0xbc, 0x5e, 0xb2, 0x74, 0x53, 0x3e, 0x1a, 0xae, 0x1b, 0x9b, 0x79, 0x6d,
+	0x6a, 0x5e, 0xa7, 0xf8, 0x92, 0xc1, 0x98, 0x5f, 0x3d, 0x18, 0x0b, 0xe9, 0xc1, 0xf8, 0x00, 0xaa,
+	0x8e, 0x67, 0xe3, 0x6b, 0x63, 0x16, 0xe0, 0x33, 0xe7, 0x9a, 0xe5, 0xa1, 0xac, 0x57, 0x18, 0x76,
+	0xcc, 0x20, 0xed, 0x0b, 0x68, 0xc4, 0x57, 0x4c, 0xba, 0x51, 0xc0, 0x21, 0x2f, 0xdb, 0x8d, 0x22,
+	0x94, 0x77, 0xa3, 0xbf, 0xe4, 0xa0, 0x34, 0x16, 0xfb, 0x2f, 0xed, 0x73, 0x9e, 0x39, 0xc5, 0x22,
+	0x2c, 0xec, 0x37, 0xc5, 0xac, 0x40, 0xcc, 0x88, 0xb2, 0xce, 0x7e, 0xa3, 0x27, 0xd0, 0x74, 0x3c,
+	0x82, 0x83, 0x2b, 0xd3, 0xcd, 0x16, 0x97, 0xcc, 0x4c, 0x6c, 0x45, 0xc4, 0x4c, 0x55, 0xc5, 0x41,
+	0x5f, 0xbf, 0xe5, 0x89, 0x93, 0xcf, 0x3e, 0x71, 0x9e, 0xa7, 0xde, 0x0f, 0x05, 0x36, 0xc8, 0xef,
+	0xb3, 0x92, 0x88, 0xbc, 0xbd, 0xf5, 0x09, 0x71, 0x1f, 0xca, 0xae, 0x19, 0x12, 0x83, 0x38, 0xd6,
+	0x25, 0x0b, 0x96, 0xa2, 0x97, 0x28, 0x30, 0x71, 0xac, 0xcb, 0xff, 0x6e, 0xc3, 0xff, 0x1a, 0xd0,
+	0x18, 0x93, 0xc8, 0x81, 0x64, 0x90, 0x94, 0xa2, 0x17, 0x84, 0xa8, 0xdd, 0x5a, 0xc6, 0x51, 0x3d,
+	0x26, 0x6b, 0x77, 0x60, 0x8b, 0xae, 0x2a, 0x11, 0x25, 0x1e, 0x01, 0x7d, 0x68, 0x2e, 0xe1, 0x22,
+	0x8b, 0x9f, 0x25, 0xcf, 0x95, 0x68, 0x9d, 0x59, 0x52, 0x9e, 0xd0, 0xb5, 0x0e, 0xa0, 0x3e, 0x76,
+	0x97, 0xdd, 0x5b, 0x91, 0x54, 0xad, 0x08, 0xf9, 0xc1, 0x74, 0x46, 0x16, 0xda, 0x08, 0x8a, 0xac,
+	0x97, 0xfc, 0xfa, 0x31, 0xd2, 0x92, 0x4c, 0xf0, 0x5b, 0x94, 0xf8, 0x9a, 0xea, 0x2d, 0x92, 0x9c,
+	0xf0, 0x67, 0x2b, 0x1f, 0x83, 0xf4, 0xd9, 0x2a, 0x82, 0xc7, 0xfb, 0x33, 0xfd, 0xa9, 0x3d, 0x03,
+	0xb9, 0xeb, 0x2d, 0xe8, 0x46, 0x45, 0x7b, 0xbc, 0x31, 0x0f, 0xe2, 0xe5, 0x99, 0x9e, 0x4f, 0x02,
+	0x37, 0x1b, 0xde, 0xaa, 0x08, 0xef, 0xa3, 0x09, 0x40, 0xd2, 0xd4, 0x50, 0x13, 0x36, 0x4e, 0x8e,
+	0xc6, 0xc7, 0x83, 0xde, 0x70, 0x7f, 0x38, 0xe8, 0x1b, 0xe3, 0x49, 0x77, 0x32, 0x50, 0xd6, 0x10,
+	0x40, 0xe1, 0xdb, 0x93, 0xc1, 0xc9, 0xa0, 0xaf, 0x48, 0xa8, 0x01, 0x95, 0xfe, 0x80, 0x9f, 0x8c,
+	0xd1, 0x37, 0x4a, 0x0e, 0x21, 0xa8, 0xc7, 0xc0, 0x40, 0xd7, 0x47, 0xba, 0x22, 0x3f, 0xfa, 0x93,
+	0x04, 0x45, 0xb1, 0x67, 0x53, 0x81, 0x94, 0x4e, 0x65, 0x0d, 0xd5, 0x01, 0x84, 0x00, 0x55, 0x20,
+	0xa1, 0x0d, 0xa8, 0x45, 0x67, 0x2e, 0x9f, 0x43, 0x5b, 0xa0, 0xe8, 0x02, 0xea, 0x8d, 0x8e, 0xc6,
+	0x93, 0xee, 0xd1, 0x44, 0x91, 0xa9, 0xa5, 0x08, 0x3d, 0x1c, 0x1e, 0x0d, 0xba, 0xba, 0xb2, 0x8e,
+	0xee, 0xc2, 0x66, 0x84, 0x0d, 0x7e, 0x73, 0x3c, 0x3a, 0x1a, 0x1c, 0x4d, 0x86, 0xdd, 0x43, 0x25,
+	0x4f, 0xb5, 0xea, 0x83, 0xf1, 0x60, 0x62, 0x4c, 0x86, 0x2f, 0x06, 0xa3, 0x93, 0x89, 0x52, 0x78,
+	0xf4, 0x29, 0x54, 0xd3, 0x6b, 0x06, 0x2a, 0x43, 0xfe, 0x58, 0x1f, 0x4d, 0x46, 0xdc, 0xa7, 0x5f,
+	0x8d, 0x47, 0x47, 0x4c, 0xef, 0x58, 0x91, 0x1e, 0x99, 0x50, 0x14, 0x73, 0x05, 0x6d, 0x42, 0x63,
+	0xaf, 0xdb, 0xfb, 0x66, 0xb4, 0xbf, 0x6f, 0xf4, 0x07, 0xfb, 0xdd, 0x93, 0xc3, 0x89, 0xb2, 0x46,
+	0xcd, 0x46, 0x60, 0xda, 0xac, 0x44, 0x7d, 0x8c, 0x08, 0xc2, 0x47, 0x76, 0x9b, 0x08, 0x4b, 0x6e,
+	0xb3, 0xfb, 0xd7, 0x12, 0xc8, 0xfd, 0xc1, 0xb7, 0x48, 0x03, 0xf9, 0x78, 0x7e, 0x8a, 0xf8, 0x80,
+	0x49, 0x1e, 0xb2, 0x6a, 0x6a, 0x7d, 0xa6, 0xdf, 0x64, 0xf4, 0xac, 0x44, 0x5b, 0x11, 0x63, 0xfa,
+	0x71, 0xaa, 0x36, 0x97, 0x50, 0x51, 0xc7, 0x1f, 0x83, 0x3c, 0x8e, 0x95, 0x8f, 0x57, 0x2a, 0x7f,
+	0x2c, 0xa1, 0x0e, 0xc8, 0x5d, 0xeb, 0x52, 0x70, 0x25, 0x2f, 0x31, 0x55, 0x49, 0x80, 0x78, 0xc7,
+	0x97, 0x0f, 0x30, 0x11, 0x9c, 0xc9, 0x9b, 0x28, 0xe3, 0xec, 0x67, 0xb0, 0x4e, 0x3f, 0x2a, 0xc4,
+	0xa5, 0x53, 0x0f, 0x19, 0x75, 0x23, 0x85, 0x24, 0x0a, 0xfb, 0xd8, 0x15, 0x0a, 0x93, 0xa7, 0x41,
+	0xa4, 0x90, 0x7e, 0x2c, 0xf4, 0xf6, 0xd1, 0xd2, 0x2e, 0x6e, 0xbf, 0xf4, 0x08, 0x50, 0x9b, 0x4b,
+	0xa8, 0x50, 0xfe, 0x53, 0x28, 0x30, 0x20, 0x44, 0x28, 0x99, 0x6a, 0xd1, 0xc7, 0xaf, 0x6e, 0x66,
+	0x30, 0x21, 0xf2, 0x18, 0x0a, 0x7c, 0x81, 0x17, 0x22, 0x99, 0xa5, 0x5f, 0x55, 0x52, 0x18, 0x5b,
+	0x79, 0x1f, 0x4b, 0xe8, 0x19, 0x14, 0xc5, 0x3e, 0x8e, 0xb8, 0xc6, 0xec, 0x0e, 0xaf, 0x6e, 0x65,
+	0x41, 0x6e, 0xa7, 0x23, 0x51, 0x4b, 0xbc, 0x1a, 0x85, 0xa5, 0xcc, 0x06, 0xaf, 0x2a, 0x29, 0x2c,
+	0xb2, 0xf4, 0x04, 0x0a, 0xc3, 0x69, 0x4a, 0x22, 0xb3, 0x57, 0xab, 0x9b, 0x19, 0x2c, 0x36, 0xf3,
+	0x33, 0xa8, 0x67, 0x97, 0x45, 0xa4, 0x0a, 0x87, 0x56, 0x6c, 0x90, 0x6a, 0x25, 0x6e, 0x72, 0x53,
+	0x13, 0xfd, 0x04, 0xca, 0xf1, 0x96, 0x87, 0x9a, 0x51, 0xca, 0xdf, 0x22, 0xf0, 0x4b, 0xa8, 0x67,
+	0x97, 0x0c, 0x61, 0x6b, 0xe5, 0xe6, 0xa1, 0xde, 0xd8, 0x34, 0xa8, 0xfc, 0xc1, 0x2a, 0xf9, 0x83,
+	0xf7, 0x94, 0x7f, 0x4a, 0x53, 0xc1, 0xa6, 0x6c, 0x9c, 0x8a, 0xf4, 0xfe, 0xa1, 0x6e, 0x65, 0x41,
+	0x91, 0xf2, 0xcf, 0xa1, 0x92, 0x9a, 0x2e, 0xe8, 0x6e, 0xe4, 0xf2, 0x52, 0x43, 0x57, 0xb3, 0x03,
+	0x00, 0xed, 0x43, 0x2d, 0x33, 0x3b, 0xd0, 0xbd, 0xb8, 0xba, 0x97, 0xe7, 0x8c, 0xaa, 0xae, 0x22,
+	0x09, 0xf3, 0xbb, 0x50, 0x49, 0x4d, 0x0f, 0x61, 0xfe, 0xe6, 0x3c, 0x49, 0x7f, 0x11, 0x7b, 0xad,
+	0xbf, 0xbd, 0xde, 0x96, 0xbe, 0x7f, 0xbd, 0x2d, 0xfd, 0xf3, 0xf5, 0xb6, 0xf4, 0xc7, 0x37, 0xdb,
+	0x6b, 0xdf, 0xbf, 0xd9, 0x5e, 0xfb, 0xfb, 0x9b, 0xed, 0xb5, 0xd3, 0x02, 0xfb, 0xdb, 0xf3, 0xc9,
+	0x7f, 0x06, 0x00, 0x2a, 0xf4, 0xa5, 0xb3, 0x03, 0x15, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// DEQClient is the client API for DEQ service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type DEQClient interface {
+	// Pub publishes an event on its topic.
+	Pub(ctx context.Context, in *PubRequest, opts ...grpc.CallOption) (*Event, error)
+	// PubBatch publishes a batch of events, possibly on different topics, atomically. Either all of
+	// the events are published or none of them are.
+	PubBatch(ctx context.Context, in *PubBatchRequest, opts ...grpc.CallOption) (*PubBatchResponse, error)
+	// Sub subscribers to events on a topic and channel. All events are stored until deleted, so all
+	// events with a default_state of QUEUED are queued on a new channel, even those published before
+	// before the subscriber connected.
+	Sub(ctx context.Context, in *SubRequest, opts ...grpc.CallOption) (DEQ_SubClient, error)
+	// Ack updates an event's processing state on a channel. See the definition of AckCode for
+	// available functionality.
+	Ack(ctx context.Context, in *AckRequest, opts ...grpc.CallOption) (*AckResponse, error)
+	// Get retrieves an event on a channel without modifying its status or place in the queue.
+	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*Event, error)
+	// List retrieves a list of events on a channel, sorted by event id, without modifying their
+	// status or place in the queue.
+	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error)
+	// Del deletes an event along with its state on every channel.
+	Del(ctx context.Context, in *DelRequest, opts ...grpc.CallOption) (*Empty, error)
+	// DelTopic deletes the events of a topic along with their state on every channel.
+	DelTopic(ctx context.Context, in *DelTopicRequest, opts ...grpc.CallOption) (*DelTopicResponse, error)
+	// Topics returns all topics for which an event has been published.
+	Topics(ctx context.Context, in *TopicsRequest, opts ...grpc.CallOption) (*TopicsResponse, error)
+	// Backup streams a consistent snapshot of the server's data, which can be restored with Restore.
+	// The server continues serving requests while the backup is in progress.
+	Backup(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (DEQ_BackupClient, error)
+	// Restore writes the data of a backup created by Backup to the server. The backup's chunks must be
+	// sent in order.
+	Restore(ctx context.Context, opts ...grpc.CallOption) (DEQ_RestoreClient, error)
+	// Export streams the events of a topic in a portable format, which can be read by Import on any
+	// version of DEQ.
+	Export(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (DEQ_ExportClient, error)
+	// Import publishes events written by Export, along with any exported channel states. The export's
+	// chunks must be sent in order.
+	Import(ctx context.Context, opts ...grpc.CallOption) (DEQ_ImportClient, error)
+	// RegisterSchema registers or updates the protobuf message type of a topic's payloads. Once a
+	// topic has a schema, publishing an event whose payload doesn't match it fails with
+	// INVALID_ARGUMENT. Updates must be backward compatible with the current schema, or
+	// RegisterSchema fails with FAILED_PRECONDITION.
+	RegisterSchema(ctx context.Context, in *RegisterSchemaRequest, opts ...grpc.CallOption) (*Schema, error)
+	// GetSchema returns the schema of a topic.
+	GetSchema(ctx context.Context, in *GetSchemaRequest, opts ...grpc.CallOption) (*Schema, error)
+	// SetTopicConfig sets the persisted configuration of a topic, which is used as the default
+	// requeue limit, backoff, default state and retention of the topic's events.
+	SetTopicConfig(ctx context.Context, in *SetTopicConfigRequest, opts ...grpc.CallOption) (*TopicConfig, error)
+	// GetTopicConfig returns the configuration of a topic. Topics that were never configured have
+	// an empty configuration, which uses the server's defaults.
+	GetTopicConfig(ctx context.Context, in *GetTopicConfigRequest, opts ...grpc.CallOption) (*TopicConfig, error)
+	// Redrive requeues the events of a channel that were dequeued with an error, resetting their
+	// requeue counts, so they are delivered to the channel's subscribers again.
+	Redrive(ctx context.Context, in *RedriveRequest, opts ...grpc.CallOption) (*RedriveResponse, error)
+	// SetSchedule creates or updates a schedule, which publishes an event on each of its ticks.
+	SetSchedule(ctx context.Context, in *SetScheduleRequest, opts ...grpc.CallOption) (*Schedule, error)
+	// ListSchedules lists every schedule.
+	ListSchedules(ctx context.Context, in *ListSchedulesRequest, opts ...grpc.CallOption) (*ListSchedulesResponse, error)
+	// DelSchedule deletes a schedule. Events that it already published are not deleted.
+	DelSchedule(ctx context.Context, in *DelScheduleRequest, opts ...grpc.CallOption) (*Empty, error)
+}
+
+type dEQClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewDEQClient(cc *grpc.ClientConn) DEQClient {
+	return &dEQClient{cc}
+}
+
+func (c *dEQClient) Pub(ctx context.Context, in *PubRequest, opts ...grpc.CallOption) (*Event, error) {
+	out := new(Event)
+	err := c.cc.Invoke(ctx, "/deq.DEQ/Pub", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dEQClient) PubBatch(ctx context.Context, in *PubBatchRequest, opts ...grpc.CallOption) (*PubBatchResponse, error) {
+	out := new(PubBatchResponse)
+	err := c.cc.Invoke(ctx, "/deq.DEQ/PubBatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dEQClient) Sub(ctx context.Context, in *SubRequest, opts ...grpc.CallOption) (DEQ_SubClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_DEQ_serviceDesc.Streams[0], "/deq.DEQ/Sub", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &dEQSubClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type DEQ_SubClient interface {
+	Recv() (*Event, error)
+	grpc.ClientStream
+}
+
+type dEQSubClient struct {
+	grpc.ClientStream
+}
+
+func (x *dEQSubClient) Recv() (*Event, error) {
+	m := new(Event)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *dEQClient) Ack(ctx context.Context, in *AckRequest, opts ...grpc.CallOption) (*AckResponse, error) {
+	out := new(AckResponse)
+	err := c.cc.Invoke(ctx, "/deq.DEQ/Ack", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dEQClient) Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*Event, error) {
+	out := new(Event)
+	err := c.cc.Invoke(ctx, "/deq.DEQ/Get", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dEQClient) List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error) {
+	out := new(ListResponse)
+	err := c.cc.Invoke(ctx, "/deq.DEQ/List", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dEQClient) Del(ctx context.Context, in *DelRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/deq.DEQ/Del", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dEQClient) DelTopic(ctx context.Context, in *DelTopicRequest, opts ...grpc.CallOption) (*DelTopicResponse, error) {
+	out := new(DelTopicResponse)
+	err := c.cc.Invoke(ctx, "/deq.DEQ/DelTopic", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dEQClient) Topics(ctx context.Context, in *TopicsRequest, opts ...grpc.CallOption) (*TopicsResponse, error) {
+	out := new(TopicsResponse)
+	err := c.cc.Invoke(ctx, "/deq.DEQ/Topics", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dEQClient) Backup(ctx context.Context, in *BackupRequest, opts ...grpc.CallOption) (DEQ_BackupClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_DEQ_serviceDesc.Streams[1], "/deq.DEQ/Backup", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &dEQBackupClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type DEQ_BackupClient interface {
+	Recv() (*BackupChunk, error)
+	grpc.ClientStream
+}
+
+type dEQBackupClient struct {
+	grpc.ClientStream
+}
+
+func (x *dEQBackupClient) Recv() (*BackupChunk, error) {
+	m := new(BackupChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *dEQClient) Restore(ctx context.Context, opts ...grpc.CallOption) (DEQ_RestoreClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_DEQ_serviceDesc.Streams[2], "/deq.DEQ/Restore", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &dEQRestoreClient{stream}
+	return x, nil
+}
+
+type DEQ_RestoreClient interface {
+	Send(*RestoreRequest) error
+	CloseAndRecv() (*RestoreResponse, error)
+	grpc.ClientStream
+}
+
+type dEQRestoreClient struct {
+	grpc.ClientStream
+}
+
+func (x *dEQRestoreClient) Send(m *RestoreRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *dEQRestoreClient) CloseAndRecv() (*RestoreResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(RestoreResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *dEQClient) Export(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (DEQ_ExportClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_DEQ_serviceDesc.Streams[3], "/deq.DEQ/Export", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &dEQExportClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type DEQ_ExportClient interface {
+	Recv() (*ExportChunk, error)
+	grpc.ClientStream
+}
+
+type dEQExportClient struct {
+	grpc.ClientStream
+}
+
+func (x *dEQExportClient) Recv() (*ExportChunk, error) {
+	m := new(ExportChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *dEQClient) Import(ctx context.Context, opts ...grpc.CallOption) (DEQ_ImportClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_DEQ_serviceDesc.Streams[4], "/deq.DEQ/Import", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &dEQImportClient{stream}
+	return x, nil
+}
+
+type DEQ_ImportClient interface {
+	Send(*ImportRequest) error
+	CloseAndRecv() (*ImportResponse, error)
+	grpc.ClientStream
+}
+
+type dEQImportClient struct {
+	grpc.ClientStream
+}
+
+func (x *dEQImportClient) Send(m *ImportRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *dEQImportClient) CloseAndRecv() (*ImportResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(ImportResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *dEQClient) RegisterSchema(ctx context.Context, in *RegisterSchemaRequest, opts ...grpc.CallOption) (*Schema, error) {
+	out := new(Schema)
+	err := c.cc.Invoke(ctx, "/deq.DEQ/RegisterSchema", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dEQClient) GetSchema(ctx context.Context, in *GetSchemaRequest, opts ...grpc.CallOption) (*Schema, error) {
+	out := new(Schema)
+	err := c.cc.Invoke(ctx, "/deq.DEQ/GetSchema", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dEQClient) SetTopicConfig(ctx context.Context, in *SetTopicConfigRequest, opts ...grpc.CallOption) (*TopicConfig, error) {
+	out := new(TopicConfig)
+	err := c.cc.Invoke(ctx, "/deq.DEQ/SetTopicConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dEQClient) GetTopicConfig(ctx context.Context, in *GetTopicConfigRequest, opts ...grpc.CallOption) (*TopicConfig, error) {
+	out := new(TopicConfig)
+	err := c.cc.Invoke(ctx, "/deq.DEQ/GetTopicConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dEQClient) Redrive(ctx context.Context, in *RedriveRequest, opts ...grpc.CallOption) (*RedriveResponse, error) {
+	out := new(RedriveResponse)
+	err := c.cc.Invoke(ctx, "/deq.DEQ/Redrive", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dEQClient) SetSchedule(ctx context.Context, in *SetScheduleRequest, opts ...grpc.CallOption) (*Schedule, error) {
+	out := new(Schedule)
+	err := c.cc.Invoke(ctx, "/deq.DEQ/SetSchedule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dEQClient) ListSchedules(ctx context.Context, in *ListSchedulesRequest, opts ...grpc.CallOption) (*ListSchedulesResponse, error) {
+	out := new(ListSchedulesResponse)
+	err := c.cc.Invoke(ctx, "/deq.DEQ/ListSchedules", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dEQClient) DelSchedule(ctx context.Context, in *DelScheduleRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/deq.DEQ/DelSchedule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DEQServer is the server API for DEQ service.
+type DEQServer interface {
+	// Pub publishes an event on its topic.
+	Pub(context.Context, *PubRequest) (*Event, error)
+	// PubBatch publishes a batch of events, possibly on different topics, atomically. Either all of
+	// the events are published or none of them are.
+	PubBatch(context.Context, *PubBatchRequest) (*PubBatchResponse, error)
+	// Sub subscribers to events on a topic and channel. All events are stored until deleted, so all
+	// events with a default_state of QUEUED are queued on a new channel, even those published before
+	// before the subscriber connected.
+	Sub(*SubRequest, DEQ_SubServer) error
+	// Ack updates an event's processing state on a channel. See the definition of AckCode for
+	// available functionality.
+	Ack(context.Context, *AckRequest) (*AckResponse, error)
+	// Get retrieves an event on a channel without modifying its status or place in the queue.
+	Get(context.Context, *GetRequest) (*Event, error)
+	// List retrieves a list of events on a channel, sorted by event id, without modifying their
+	// status or place in the queue.
+	List(context.Context, *ListRequest) (*ListResponse, error)
+	// Del deletes an event along with its state on every channel.
+	Del(context.Context, *DelRequest) (*Empty, error)
+	// DelTopic deletes the events of a topic along with their state on every channel.
+	DelTopic(context.Context, *DelTopicRequest) (*DelTopicResponse, error)
+	// Topics returns all topics for which an event has been published.
+	Topics(context.Context, *TopicsRequest) (*TopicsResponse, error)
+	// Backup streams a consistent snapshot of the server's data, which can be restored with Restore.
+	// The server continues serving requests while the backup is in progress.
+	Backup(*BackupRequest, DEQ_BackupServer) error
+	// Restore writes the data of a backup created by Backup to the server. The backup's chunks must be
+	// sent in order.
+	Restore(DEQ_RestoreServer) error
+	// Export streams the events of a topic in a portable format, which can be read by Import on any
+	// version of DEQ.
+	Export(*ExportRequest, DEQ_ExportServer) error
+	// Import publishes events written by Export, along with any exported channel states. The export's
+	// chunks must be sent in order.
+	Import(DEQ_ImportServer) error
+	// RegisterSchema registers or updates the protobuf message type of a topic's payloads. Once a
+	// topic has a schema, publishing an event whose payload doesn't match it fails with
+	// INVALID_ARGUMENT. Updates must be backward compatible with the current schema, or
+	// RegisterSchema fails with FAILED_PRECONDITION.
+	RegisterSchema(context.Context, *RegisterSchemaRequest) (*Schema, error)
+	// GetSchema returns the schema of a topic.
+	GetSchema(context.Context, *GetSchemaRequest) (*Schema, error)
+	// SetTopicConfig sets the persisted configuration of a topic, which is used as the default
+	// requeue limit, backoff, default state and retention of the topic's events.
+	SetTopicConfig(context.Context, *SetTopicConfigRequest) (*TopicConfig, error)
+	// GetTopicConfig returns the configuration of a topic. Topics that were never configured have
+	// an empty configuration, which uses the server's defaults.
+	GetTopicConfig(context.Context, *GetTopicConfigRequest) (*TopicConfig, error)
+	// Redrive requeues the events of a channel that were dequeued with an error, resetting their
+	// requeue counts, so they are delivered to the channel's subscribers again.
+	Redrive(context.Context, *RedriveRequest) (*RedriveResponse, error)
+	// SetSchedule creates or updates a schedule, which publishes an event on each of its ticks.
+	SetSchedule(context.Context, *SetScheduleRequest) (*Schedule, error)
+	// ListSchedules lists every schedule.
+	ListSchedules(context.Context, *ListSchedulesRequest) (*ListSchedulesResponse, error)
+	// DelSchedule deletes a schedule. Events that it already published are not deleted.
+	DelSchedule(context.Context, *DelScheduleRequest) (*Empty, error)
+}
+
+func RegisterDEQServer(s *grpc.Server, srv DEQServer) {
+	s.RegisterService(&_DEQ_serviceDesc, srv)
+}
+
+func _DEQ_Pub_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PubRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DEQServer).Pub(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/deq.DEQ/Pub",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DEQServer).Pub(ctx, req.(*PubRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DEQ_PubBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PubBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DEQServer).PubBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/deq.DEQ/PubBatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DEQServer).PubBatch(ctx, req.(*PubBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DEQ_Sub_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DEQServer).Sub(m, &dEQSubServer{stream})
+}
+
+type DEQ_SubServer interface {
+	Send(*Event) error
+	grpc.ServerStream
+}
+
+type dEQSubServer struct {
+	grpc.ServerStream
+}
+
+func (x *dEQSubServer) Send(m *Event) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _DEQ_Ack_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DEQServer).Ack(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/deq.DEQ/Ack",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DEQServer).Ack(ctx, req.(*AckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DEQ_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DEQServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/deq.DEQ/Get",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DEQServer).Get(ctx, req.(*GetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DEQ_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DEQServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/deq.DEQ/List",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DEQServer).List(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DEQ_Del_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DEQServer).Del(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/deq.DEQ/Del",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DEQServer).Del(ctx, req.(*DelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DEQ_DelTopic_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DelTopicRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DEQServer).DelTopic(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/deq.DEQ/DelTopic",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DEQServer).DelTopic(ctx, req.(*DelTopicRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DEQ_Topics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TopicsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DEQServer).Topics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/deq.DEQ/Topics",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DEQServer).Topics(ctx, req.(*TopicsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DEQ_Backup_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(BackupRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DEQServer).Backup(m, &dEQBackupServer{stream})
+}
+
+type DEQ_BackupServer interface {
+	Send(*BackupChunk) error
+	grpc.ServerStream
+}
+
+type dEQBackupServer struct {
+	grpc.ServerStream
+}
+
+func (x *dEQBackupServer) Send(m *BackupChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _DEQ_Restore_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(DEQServer).Restore(&dEQRestoreServer{stream})
+}
+
+type DEQ_RestoreServer interface {
+	SendAndClose(*RestoreResponse) error
+	Recv() (*RestoreRequest, error)
+	grpc.ServerStream
+}
+
+type dEQRestoreServer struct {
+	grpc.ServerStream
+}
+
+func (x *dEQRestoreServer) SendAndClose(m *RestoreResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *dEQRestoreServer) Recv() (*RestoreRequest, error) {
+	m := new(RestoreRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _DEQ_Export_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DEQServer).Export(m, &dEQExportServer{stream})
+}
+
+type DEQ_ExportServer interface {
+	Send(*ExportChunk) error
+	grpc.ServerStream
+}
+
+type dEQExportServer struct {
+	grpc.ServerStream
+}
+
+func (x *dEQExportServer) Send(m *ExportChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _DEQ_Import_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(DEQServer).Import(&dEQImportServer{stream})
+}
+
+type DEQ_ImportServer interface {
+	SendAndClose(*ImportResponse) error
+	Recv() (*ImportRequest, error)
+	grpc.ServerStream
+}
+
+type dEQImportServer struct {
+	grpc.ServerStream
+}
+
+func (x *dEQImportServer) SendAndClose(m *ImportResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *dEQImportServer) Recv() (*ImportRequest, error) {
+	m := new(ImportRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _DEQ_RegisterSchema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterSchemaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DEQServer).RegisterSchema(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/deq.DEQ/RegisterSchema",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DEQServer).RegisterSchema(ctx, req.(*RegisterSchemaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DEQ_GetSchema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSchemaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DEQServer).GetSchema(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/deq.DEQ/GetSchema",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DEQServer).GetSchema(ctx, req.(*GetSchemaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DEQ_SetTopicConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetTopicConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DEQServer).SetTopicConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/deq.DEQ/SetTopicConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DEQServer).SetTopicConfig(ctx, req.(*SetTopicConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DEQ_GetTopicConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTopicConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DEQServer).GetTopicConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/deq.DEQ/GetTopicConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DEQServer).GetTopicConfig(ctx, req.(*GetTopicConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DEQ_Redrive_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RedriveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DEQServer).Redrive(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/deq.DEQ/Redrive",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DEQServer).Redrive(ctx, req.(*RedriveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DEQ_SetSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DEQServer).SetSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/deq.DEQ/SetSchedule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DEQServer).SetSchedule(ctx, req.(*SetScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DEQ_ListSchedules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSchedulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DEQServer).ListSchedules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/deq.DEQ/ListSchedules",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DEQServer).ListSchedules(ctx, req.(*ListSchedulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DEQ_DelSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DelScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DEQServer).DelSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/deq.DEQ/DelSchedule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DEQServer).DelSchedule(ctx, req.(*DelScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _DEQ_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "deq.DEQ",
+	HandlerType: (*DEQServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Pub",
+			Handler:    _DEQ_Pub_Handler,
+		},
+		{
+			MethodName: "PubBatch",
+			Handler:    _DEQ_PubBatch_Handler,
+		},
+		{
+			MethodName: "Ack",
+			Handler:    _DEQ_Ack_Handler,
+		},
+		{
+			MethodName: "Get",
+			Handler:    _DEQ_Get_Handler,
+		},
+		{
+			MethodName: "List",
+			Handler:    _DEQ_List_Handler,
+		},
+		{
+			MethodName: "Del",
+			Handler:    _DEQ_Del_Handler,
+		},
+		{
+			MethodName: "DelTopic",
+			Handler:    _DEQ_DelTopic_Handler,
+		},
+		{
+			MethodName: "Topics",
+			Handler:    _DEQ_Topics_Handler,
+		},
+		{
+			MethodName: "RegisterSchema",
+			Handler:    _DEQ_RegisterSchema_Handler,
+		},
+		{
+			MethodName: "GetSchema",
+			Handler:    _DEQ_GetSchema_Handler,
+		},
+		{
+			MethodName: "SetTopicConfig",
+			Handler:    _DEQ_SetTopicConfig_Handler,
+		},
+		{
+			MethodName: "GetTopicConfig",
+			Handler:    _DEQ_GetTopicConfig_Handler,
+		},
+		{
+			MethodName: "Redrive",
+			Handler:    _DEQ_Redrive_Handler,
+		},
+		{
+			MethodName: "SetSchedule",
+			Handler:    _DEQ_SetSchedule_Handler,
+		},
+		{
+			MethodName: "ListSchedules",
+			Handler:    _DEQ_ListSchedules_Handler,
+		},
+		{
+			MethodName: "DelSchedule",
+			Handler:    _DEQ_DelSchedule_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Sub",
+			Handler:       _DEQ_Sub_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Backup",
+			Handler:       _DEQ_Backup_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Restore",
+			Handler:       _DEQ_Restore_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "Export",
+			Handler:       _DEQ_Export_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Import",
+			Handler:       _DEQ_Import_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "deq.proto",
+}
+
+func (m *Event) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Event) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Id) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(len(m.Id)))
+		i += copy(dAtA[i:], m.Id)
+	}
+	if len(m.Topic) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(len(m.Topic)))
+		i += copy(dAtA[i:], m.Topic)
+	}
+	if len(m.Payload) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(len(m.Payload)))
+		i += copy(dAtA[i:], m.Payload)
+	}
+	if m.CreateTime != 0 {
+		dAtA[i] = 0x21
+		i++
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.CreateTime))
+		i += 8
+	}
+	if m.DefaultState != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(m.DefaultState))
+	}
+	if m.State != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(m.State))
+	}
+	if m.RequeueCount != 0 {
+		dAtA[i] = 0x38
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(m.RequeueCount))
+	}
+	if len(m.Metadata) > 0 {
+		for k, _ := range m.Metadata {
+			dAtA[i] = 0x42
+			i++
+			v := m.Metadata[k]
+			mapSize := 1 + len(k) + sovDeq(uint64(len(k))) + 1 + len(v) + sovDeq(uint64(len(v)))
+			i = encodeVarintDeq(dAtA, i, uint64(mapSize))
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintDeq(dAtA, i, uint64(len(k)))
+			i += copy(dAtA[i:], k)
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintDeq(dAtA, i, uint64(len(v)))
+			i += copy(dAtA[i:], v)
+		}
+	}
+	if m.RequeueLimit != 0 {
+		dAtA[i] = 0x48
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(m.RequeueLimit))
+	}
+	if m.DeliverAt != 0 {
+		dAtA[i] = 0x51
+		i++
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.DeliverAt))
+		i += 8
+	}
+	if m.Priority != 0 {
+		dAtA[i] = 0x58
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(m.Priority))
+	}
+	if m.Scheduled {
+		dAtA[i] = 0x60
+		i++
+		if m.Scheduled {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	return i, nil
+}
+
+func (m *PubRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PubRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Event != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(m.Event.Size()))
+		n1, err := m.Event.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n1
+	}
+	if len(m.AwaitChannel) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(len(m.AwaitChannel)))
+		i += copy(dAtA[i:], m.AwaitChannel)
+	}
+	return i, nil
+}
+
+func (m *PubBatchRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PubBatchRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Events) > 0 {
+		for _, msg := range m.Events {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintDeq(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *PubBatchResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PubBatchResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Events) > 0 {
+		for _, msg := range m.Events {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintDeq(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *SubRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SubRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Channel) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(len(m.Channel)))
+		i += copy(dAtA[i:], m.Channel)
+	}
+	if len(m.Topic) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(len(m.Topic)))
+		i += copy(dAtA[i:], m.Topic)
+	}
+	if m.Follow {
+		dAtA[i] = 0x28
+		i++
+		if m.Follow {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	if m.RequeueDelayMilliseconds != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(m.RequeueDelayMilliseconds))
+	}
+	if m.IdleTimeoutMilliseconds != 0 {
+		dAtA[i] = 0x38
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(m.IdleTimeoutMilliseconds))
+	}
+	if len(m.DeadLetterTopic) > 0 {
+		dAtA[i] = 0x42
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(len(m.DeadLetterTopic)))
+		i += copy(dAtA[i:], m.DeadLetterTopic)
+	}
+	return i, nil
+}
+
+func (m *AckRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AckRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Channel) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(len(m.Channel)))
+		i += copy(dAtA[i:], m.Channel)
+	}
+	if len(m.Topic) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(len(m.Topic)))
+		i += copy(dAtA[i:], m.Topic)
+	}
+	if len(m.EventId) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(len(m.EventId)))
+		i += copy(dAtA[i:], m.EventId)
+	}
+	if m.Code != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(m.Code))
+	}
+	if len(m.Error) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(len(m.Error)))
+		i += copy(dAtA[i:], m.Error)
+	}
+	return i, nil
+}
+
+func (m *AckResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AckResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *GetRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.EventId) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(len(m.EventId)))
+		i += copy(dAtA[i:], m.EventId)
+	}
+	if len(m.Topic) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(len(m.Topic)))
+		i += copy(dAtA[i:], m.Topic)
+	}
+	if len(m.Channel) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(len(m.Channel)))
+		i += copy(dAtA[i:], m.Channel)
+	}
+	if m.Await {
+		dAtA[i] = 0x20
+		i++
+		if m.Await {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	return i, nil
+}
+
+func (m *ListRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Topic) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(len(m.Topic)))
+		i += copy(dAtA[i:], m.Topic)
+	}
+	if len(m.Channel) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(len(m.Channel)))
+		i += copy(dAtA[i:], m.Channel)
+	}
+	if len(m.MinId) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(len(m.MinId)))
+		i += copy(dAtA[i:], m.MinId)
+	}
+	if len(m.MaxId) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(len(m.MaxId)))
+		i += copy(dAtA[i:], m.MaxId)
+	}
+	if m.PageSize != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(m.PageSize))
+	}
+	if m.Reversed {
+		dAtA[i] = 0x30
+		i++
+		if m.Reversed {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	return i, nil
+}
+
+func (m *ListResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Events) > 0 {
+		for _, msg := range m.Events {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintDeq(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *DelRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DelRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.EventId) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(len(m.EventId)))
+		i += copy(dAtA[i:], m.EventId)
+	}
+	if len(m.Topic) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(len(m.Topic)))
+		i += copy(dAtA[i:], m.Topic)
+	}
+	return i, nil
+}
+
+func (m *DelTopicRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DelTopicRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Topic) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(len(m.Topic)))
+		i += copy(dAtA[i:], m.Topic)
+	}
+	if m.Before != 0 {
+		dAtA[i] = 0x11
+		i++
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Before))
+		i += 8
+	}
+	return i, nil
+}
+
+func (m *DelTopicResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DelTopicResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.DeletedCount != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(m.DeletedCount))
+	}
+	return i, nil
+}
+
+func (m *BackupRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BackupRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Since != 0 {
+		dAtA[i] = 0x9
+		i++
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Since))
+		i += 8
+	}
+	return i, nil
+}
+
+func (m *BackupChunk) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BackupChunk) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Data) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(len(m.Data)))
+		i += copy(dAtA[i:], m.Data)
+	}
+	if m.Version != 0 {
+		dAtA[i] = 0x11
+		i++
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.Version))
+		i += 8
+	}
+	return i, nil
+}
+
+func (m *RestoreRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RestoreRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Data) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(len(m.Data)))
+		i += copy(dAtA[i:], m.Data)
+	}
+	return i, nil
+}
+
+func (m *RestoreResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RestoreResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *ExportRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ExportRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Topic) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(len(m.Topic)))
+		i += copy(dAtA[i:], m.Topic)
+	}
+	if m.Format != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(m.Format))
+	}
+	if m.ChannelStates {
+		dAtA[i] = 0x18
+		i++
+		if m.ChannelStates {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
+	return i, nil
+}
+
+func (m *ExportChunk) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ExportChunk) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Data) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(len(m.Data)))
+		i += copy(dAtA[i:], m.Data)
+	}
+	return i, nil
+}
+
+func (m *ImportRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ImportRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Format != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(m.Format))
+	}
+	if len(m.Data) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(len(m.Data)))
+		i += copy(dAtA[i:], m.Data)
+	}
+	return i, nil
+}
+
+func (m *ImportResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ImportResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.ImportedCount != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(m.ImportedCount))
+	}
+	return i, nil
+}
+
+func (m *TopicsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TopicsRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *TopicsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TopicsResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Topics) > 0 {
+		for _, s := range m.Topics {
+			dAtA[i] = 0xa
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	return i, nil
+}
+
+func (m *Schema) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Schema) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Topic) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(len(m.Topic)))
+		i += copy(dAtA[i:], m.Topic)
+	}
+	if len(m.MessageType) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(len(m.MessageType)))
+		i += copy(dAtA[i:], m.MessageType)
+	}
+	if len(m.FileDescriptorSet) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(len(m.FileDescriptorSet)))
+		i += copy(dAtA[i:], m.FileDescriptorSet)
+	}
+	if m.Version != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(m.Version))
+	}
+	return i, nil
+}
+
+func (m *RegisterSchemaRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RegisterSchemaRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Topic) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(len(m.Topic)))
+		i += copy(dAtA[i:], m.Topic)
+	}
+	if len(m.MessageType) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(len(m.MessageType)))
+		i += copy(dAtA[i:], m.MessageType)
+	}
+	if len(m.FileDescriptorSet) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(len(m.FileDescriptorSet)))
+		i += copy(dAtA[i:], m.FileDescriptorSet)
+	}
+	return i, nil
+}
+
+func (m *GetSchemaRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetSchemaRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Topic) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(len(m.Topic)))
+		i += copy(dAtA[i:], m.Topic)
+	}
+	return i, nil
+}
+
+func (m *TopicConfig) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TopicConfig) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Topic) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(len(m.Topic)))
+		i += copy(dAtA[i:], m.Topic)
+	}
+	if m.RequeueLimit != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(m.RequeueLimit))
+	}
+	if m.Backoff != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(m.Backoff))
+	}
+	if m.RequeueDelayMilliseconds != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(m.RequeueDelayMilliseconds))
+	}
+	if m.DefaultState != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(m.DefaultState))
+	}
+	if m.RetentionMaxAgeMilliseconds != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(m.RetentionMaxAgeMilliseconds))
+	}
+	if m.RetentionMaxCount != 0 {
+		dAtA[i] = 0x38
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(m.RetentionMaxCount))
+	}
+	if len(m.DeadLetterTopic) > 0 {
+		dAtA[i] = 0x42
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(len(m.DeadLetterTopic)))
+		i += copy(dAtA[i:], m.DeadLetterTopic)
+	}
+	return i, nil
+}
+
+func (m *SetTopicConfigRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SetTopicConfigRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Config != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(m.Config.Size()))
+		n2, err := m.Config.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n2
+	}
+	return i, nil
+}
+
+func (m *GetTopicConfigRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *GetTopicConfigRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Topic) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(len(m.Topic)))
+		i += copy(dAtA[i:], m.Topic)
+	}
+	return i, nil
+}
+
+func (m *RedriveRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RedriveRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Topic) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(len(m.Topic)))
+		i += copy(dAtA[i:], m.Topic)
+	}
+	if len(m.Channel) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(len(m.Channel)))
+		i += copy(dAtA[i:], m.Channel)
+	}
+	if m.MinCreateTime != 0 {
+		dAtA[i] = 0x19
+		i++
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.MinCreateTime))
+		i += 8
+	}
+	if m.MaxCreateTime != 0 {
+		dAtA[i] = 0x21
+		i++
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.MaxCreateTime))
+		i += 8
+	}
+	if len(m.MinId) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(len(m.MinId)))
+		i += copy(dAtA[i:], m.MinId)
+	}
+	if len(m.MaxId) > 0 {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(len(m.MaxId)))
+		i += copy(dAtA[i:], m.MaxId)
+	}
+	if len(m.IndexPrefix) > 0 {
+		dAtA[i] = 0x3a
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(len(m.IndexPrefix)))
+		i += copy(dAtA[i:], m.IndexPrefix)
+	}
+	return i, nil
+}
+
+func (m *RedriveResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RedriveResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.RedrivenCount != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(m.RedrivenCount))
+	}
+	return i, nil
+}
+
+func (m *Schedule) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Schedule) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Name) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
+	}
+	if len(m.Cron) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(len(m.Cron)))
+		i += copy(dAtA[i:], m.Cron)
+	}
+	if m.IntervalMilliseconds != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(m.IntervalMilliseconds))
+	}
+	if len(m.Topic) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(len(m.Topic)))
+		i += copy(dAtA[i:], m.Topic)
+	}
+	if len(m.Payload) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(len(m.Payload)))
+		i += copy(dAtA[i:], m.Payload)
+	}
+	if len(m.Metadata) > 0 {
+		for k, _ := range m.Metadata {
+			dAtA[i] = 0x32
+			i++
+			v := m.Metadata[k]
+			mapSize := 1 + len(k) + sovDeq(uint64(len(k))) + 1 + len(v) + sovDeq(uint64(len(v)))
+			i = encodeVarintDeq(dAtA, i, uint64(mapSize))
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintDeq(dAtA, i, uint64(len(k)))
+			i += copy(dAtA[i:], k)
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintDeq(dAtA, i, uint64(len(v)))
+			i += copy(dAtA[i:], v)
+		}
+	}
+	if m.LastTick != 0 {
+		dAtA[i] = 0x39
+		i++
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.LastTick))
+		i += 8
+	}
+	return i, nil
+}
+
+func (m *SetScheduleRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SetScheduleRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Schedule != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(m.Schedule.Size()))
+		n3, err := m.Schedule.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n3
+	}
+	return i, nil
+}
+
+func (m *ListSchedulesRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListSchedulesRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *ListSchedulesResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListSchedulesResponse) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Schedules) > 0 {
+		for _, msg := range m.Schedules {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintDeq(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *DelScheduleRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DelScheduleRequest) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Name) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
+	}
+	return i, nil
+}
+
+func (m *Empty) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Empty) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	return i, nil
+}
+
+func (m *EventV0) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *EventV0) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Payload != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(m.Payload.Size()))
+		n4, err := m.Payload.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n4
+	}
+	if len(m.Id) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(len(m.Id)))
+		i += copy(dAtA[i:], m.Id)
+	}
+	if len(m.Key) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(len(m.Key)))
+		i += copy(dAtA[i:], m.Key)
+	}
+	return i, nil
+}
+
+func (m *Any) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Any) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.TypeUrl) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(len(m.TypeUrl)))
+		i += copy(dAtA[i:], m.TypeUrl)
+	}
+	if len(m.Value) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintDeq(dAtA, i, uint64(len(m.Value)))
+		i += copy(dAtA[i:], m.Value)
+	}
+	return i, nil
+}
+
+func encodeVarintDeq(dAtA []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return offset + 1
+}
+func (m *Event) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Id)
+	if l > 0 {
+		n += 1 + l + sovDeq(uint64(l))
+	}
+	l = len(m.Topic)
+	if l > 0 {
+		n += 1 + l + sovDeq(uint64(l))
+	}
+	l = len(m.Payload)
+	if l > 0 {
+		n += 1 + l + sovDeq(uint64(l))
+	}
+	if m.CreateTime != 0 {
+		n += 9
+	}
+	if m.DefaultState != 0 {
+		n += 1 + sovDeq(uint64(m.DefaultState))
+	}
+	if m.State != 0 {
+		n += 1 + sovDeq(uint64(m.State))
+	}
+	if m.RequeueCount != 0 {
+		n += 1 + sovDeq(uint64(m.RequeueCount))
+	}
+	if len(m.Metadata) > 0 {
+		for k, v := range m.Metadata {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovDeq(uint64(len(k))) + 1 + len(v) + sovDeq(uint64(len(v)))
+			n += mapEntrySize + 1 + sovDeq(uint64(mapEntrySize))
+		}
+	}
+	if m.RequeueLimit != 0 {
+		n += 1 + sovDeq(uint64(m.RequeueLimit))
+	}
+	if m.DeliverAt != 0 {
+		n += 9
+	}
+	if m.Priority != 0 {
+		n += 1 + sovDeq(uint64(m.Priority))
+	}
+	if m.Scheduled {
+		n += 2
+	}
+	return n
+}
+
+func (m *PubRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Event != nil {
+		l = m.Event.Size()
+		n += 1 + l + sovDeq(uint64(l))
+	}
+	l = len(m.AwaitChannel)
+	if l > 0 {
+		n += 1 + l + sovDeq(uint64(l))
+	}
+	return n
+}
+
+func (m *PubBatchRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Events) > 0 {
+		for _, e := range m.Events {
+			l = e.Size()
+			n += 1 + l + sovDeq(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *PubBatchResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Events) > 0 {
+		for _, e := range m.Events {
+			l = e.Size()
+			n += 1 + l + sovDeq(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *SubRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Channel)
+	if l > 0 {
+		n += 1 + l + sovDeq(uint64(l))
+	}
+	l = len(m.Topic)
+	if l > 0 {
+		n += 1 + l + sovDeq(uint64(l))
+	}
+	if m.Follow {
+		n += 2
+	}
+	if m.RequeueDelayMilliseconds != 0 {
+		n += 1 + sovDeq(uint64(m.RequeueDelayMilliseconds))
+	}
+	if m.IdleTimeoutMilliseconds != 0 {
+		n += 1 + sovDeq(uint64(m.IdleTimeoutMilliseconds))
+	}
+	l = len(m.DeadLetterTopic)
+	if l > 0 {
+		n += 1 + l + sovDeq(uint64(l))
+	}
+	return n
+}
+
+func (m *AckRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Channel)
+	if l > 0 {
+		n += 1 + l + sovDeq(uint64(l))
+	}
+	l = len(m.Topic)
+	if l > 0 {
+		n += 1 + l + sovDeq(uint64(l))
+	}
+	l = len(m.EventId)
+	if l > 0 {
+		n += 1 + l + sovDeq(uint64(l))
+	}
+	if m.Code != 0 {
+		n += 1 + sovDeq(uint64(m.Code))
+	}
+	l = len(m.Error)
+	if l > 0 {
+		n += 1 + l + sovDeq(uint64(l))
+	}
+	return n
+}
+
+func (m *AckResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *GetRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.EventId)
+	if l > 0 {
+		n += 1 + l + sovDeq(uint64(l))
+	}
+	l = len(m.Topic)
+	if l > 0 {
+		n += 1 + l + sovDeq(uint64(l))
+	}
+	l = len(m.Channel)
+	if l > 0 {
+		n += 1 + l + sovDeq(uint64(l))
+	}
+	if m.Await {
+		n += 2
+	}
+	return n
+}
+
+func (m *ListRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Topic)
+	if l > 0 {
+		n += 1 + l + sovDeq(uint64(l))
+	}
+	l = len(m.Channel)
+	if l > 0 {
+		n += 1 + l + sovDeq(uint64(l))
+	}
+	l = len(m.MinId)
+	if l > 0 {
+		n += 1 + l + sovDeq(uint64(l))
+	}
+	l = len(m.MaxId)
+	if l > 0 {
+		n += 1 + l + sovDeq(uint64(l))
+	}
+	if m.PageSize != 0 {
+		n += 1 + sovDeq(uint64(m.PageSize))
+	}
+	if m.Reversed {
+		n += 2
+	}
+	return n
+}
+
+func (m *ListResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Events) > 0 {
+		for _, e := range m.Events {
+			l = e.Size()
+			n += 1 + l + sovDeq(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *DelRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.EventId)
+	if l > 0 {
+		n += 1 + l + sovDeq(uint64(l))
+	}
+	l = len(m.Topic)
+	if l > 0 {
+		n += 1 + l + sovDeq(uint64(l))
+	}
+	return n
+}
+
+func (m *DelTopicRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Topic)
+	if l > 0 {
+		n += 1 + l + sovDeq(uint64(l))
+	}
+	if m.Before != 0 {
+		n += 9
+	}
+	return n
+}
+
+func (m *DelTopicResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.DeletedCount != 0 {
+		n += 1 + sovDeq(uint64(m.DeletedCount))
+	}
+	return n
+}
+
+func (m *BackupRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Since != 0 {
+		n += 9
+	}
+	return n
+}
+
+func (m *BackupChunk) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Data)
+	if l > 0 {
+		n += 1 + l + sovDeq(uint64(l))
+	}
+	if m.Version != 0 {
+		n += 9
+	}
+	return n
+}
+
+func (m *RestoreRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Data)
+	if l > 0 {
+		n += 1 + l + sovDeq(uint64(l))
+	}
+	return n
+}
+
+func (m *RestoreResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *ExportRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Topic)
+	if l > 0 {
+		n += 1 + l + sovDeq(uint64(l))
+	}
+	if m.Format != 0 {
+		n += 1 + sovDeq(uint64(m.Format))
+	}
+	if m.ChannelStates {
+		n += 2
+	}
+	return n
+}
+
+func (m *ExportChunk) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Data)
+	if l > 0 {
+		n += 1 + l + sovDeq(uint64(l))
+	}
+	return n
+}
+
+func (m *ImportRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Format != 0 {
+		n += 1 + sovDeq(uint64(m.Format))
+	}
+	l = len(m.Data)
+	if l > 0 {
+		n += 1 + l + sovDeq(uint64(l))
+	}
+	return n
+}
+
+func (m *ImportResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ImportedCount != 0 {
+		n += 1 + sovDeq(uint64(m.ImportedCount))
+	}
+	return n
+}
+
+func (m *TopicsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *TopicsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Topics) > 0 {
+		for _, s := range m.Topics {
+			l = len(s)
+			n += 1 + l + sovDeq(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *Schema) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Topic)
+	if l > 0 {
+		n += 1 + l + sovDeq(uint64(l))
+	}
+	l = len(m.MessageType)
+	if l > 0 {
+		n += 1 + l + sovDeq(uint64(l))
+	}
+	l = len(m.FileDescriptorSet)
+	if l > 0 {
+		n += 1 + l + sovDeq(uint64(l))
+	}
+	if m.Version != 0 {
+		n += 1 + sovDeq(uint64(m.Version))
+	}
+	return n
+}
+
+func (m *RegisterSchemaRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Topic)
+	if l > 0 {
+		n += 1 + l + sovDeq(uint64(l))
+	}
+	l = len(m.MessageType)
+	if l > 0 {
+		n += 1 + l + sovDeq(uint64(l))
+	}
+	l = len(m.FileDescriptorSet)
+	if l > 0 {
+		n += 1 + l + sovDeq(uint64(l))
+	}
+	return n
+}
+
+func (m *GetSchemaRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Topic)
+	if l > 0 {
+		n += 1 + l + sovDeq(uint64(l))
+	}
+	return n
+}
+
+func (m *TopicConfig) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Topic)
+	if l > 0 {
+		n += 1 + l + sovDeq(uint64(l))
+	}
+	if m.RequeueLimit != 0 {
+		n += 1 + sovDeq(uint64(m.RequeueLimit))
+	}
+	if m.Backoff != 0 {
+		n += 1 + sovDeq(uint64(m.Backoff))
+	}
+	if m.RequeueDelayMilliseconds != 0 {
+		n += 1 + sovDeq(uint64(m.RequeueDelayMilliseconds))
+	}
+	if m.DefaultState != 0 {
+		n += 1 + sovDeq(uint64(m.DefaultState))
+	}
+	if m.RetentionMaxAgeMilliseconds != 0 {
+		n += 1 + sovDeq(uint64(m.RetentionMaxAgeMilliseconds))
+	}
+	if m.RetentionMaxCount != 0 {
+		n += 1 + sovDeq(uint64(m.RetentionMaxCount))
+	}
+	l = len(m.DeadLetterTopic)
+	if l > 0 {
+		n += 1 + l + sovDeq(uint64(l))
+	}
+	return n
+}
+
+func (m *SetTopicConfigRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Config != nil {
+		l = m.Config.Size()
+		n += 1 + l + sovDeq(uint64(l))
+	}
+	return n
+}
+
+func (m *GetTopicConfigRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Topic)
+	if l > 0 {
+		n += 1 + l + sovDeq(uint64(l))
+	}
+	return n
+}
+
+func (m *RedriveRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Topic)
+	if l > 0 {
+		n += 1 + l + sovDeq(uint64(l))
+	}
+	l = len(m.Channel)
+	if l > 0 {
+		n += 1 + l + sovDeq(uint64(l))
+	}
+	if m.MinCreateTime != 0 {
+		n += 9
+	}
+	if m.MaxCreateTime != 0 {
+		n += 9
+	}
+	l = len(m.MinId)
+	if l > 0 {
+		n += 1 + l + sovDeq(uint64(l))
+	}
+	l = len(m.MaxId)
+	if l > 0 {
+		n += 1 + l + sovDeq(uint64(l))
+	}
+	l = len(m.IndexPrefix)
+	if l > 0 {
+		n += 1 + l + sovDeq(uint64(l))
+	}
+	return n
+}
+
+func (m *RedriveResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.RedrivenCount != 0 {
+		n += 1 + sovDeq(uint64(m.RedrivenCount))
+	}
+	return n
+}
+
+func (m *Schedule) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovDeq(uint64(l))
+	}
+	l = len(m.Cron)
+	if l > 0 {
+		n += 1 + l + sovDeq(uint64(l))
+	}
+	if m.IntervalMilliseconds != 0 {
+		n += 1 + sovDeq(uint64(m.IntervalMilliseconds))
+	}
+	l = len(m.Topic)
+	if l > 0 {
+		n += 1 + l + sovDeq(uint64(l))
+	}
+	l = len(m.Payload)
+	if l > 0 {
+		n += 1 + l + sovDeq(uint64(l))
+	}
+	if len(m.Metadata) > 0 {
+		for k, v := range m.Metadata {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovDeq(uint64(len(k))) + 1 + len(v) + sovDeq(uint64(len(v)))
+			n += mapEntrySize + 1 + sovDeq(uint64(mapEntrySize))
+		}
+	}
+	if m.LastTick != 0 {
+		n += 9
+	}
+	return n
+}
+
+func (m *SetScheduleRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Schedule != nil {
+		l = m.Schedule.Size()
+		n += 1 + l + sovDeq(uint64(l))
+	}
+	return n
+}
+
+func (m *ListSchedulesRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *ListSchedulesResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Schedules) > 0 {
+		for _, e := range m.Schedules {
+			l = e.Size()
+			n += 1 + l + sovDeq(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *DelScheduleRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovDeq(uint64(l))
+	}
+	return n
+}
+
+func (m *Empty) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *EventV0) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Payload != nil {
+		l = m.Payload.Size()
+		n += 1 + l + sovDeq(uint64(l))
+	}
+	l = len(m.Id)
+	if l > 0 {
+		n += 1 + l + sovDeq(uint64(l))
+	}
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovDeq(uint64(l))
+	}
+	return n
+}
+
+func (m *Any) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.TypeUrl)
+	if l > 0 {
+		n += 1 + l + sovDeq(uint64(l))
+	}
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovDeq(uint64(l))
+	}
+	return n
+}
+
+func sovDeq(x uint64) (n int) {
+	for {
+		n++
+		x >>= 7
+		if x == 0 {
+			break
+		}
+	}
+	return n
+}
+func sozDeq(x uint64) (n int) {
+	return sovDeq(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *Event) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDeq
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Event: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Event: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeq
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDeq
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Id = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Topic", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeq
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDeq
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Topic = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeq
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthDeq
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Payload = append(m.Payload[:0], dAtA[iNdEx:postIndex]...)
+			if m.Payload == nil {
+				m.Payload = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CreateTime", wireType)
+			}
+			m.CreateTime = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CreateTime = int64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DefaultState", wireType)
+			}
+			m.DefaultState = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeq
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DefaultState |= EventState(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+			}
+			m.State = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeq
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.State |= EventState(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RequeueCount", wireType)
+			}
+			m.RequeueCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeq
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RequeueCount |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeq
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDeq
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Metadata == nil {
+				m.Metadata = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowDeq
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowDeq
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthDeq
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthDeq
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowDeq
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthDeq
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue < 0 {
+						return ErrInvalidLengthDeq
+					}
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipDeq(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthDeq
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Metadata[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RequeueLimit", wireType)
+			}
+			m.RequeueLimit = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeq
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RequeueLimit |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 10:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeliverAt", wireType)
+			}
+			m.DeliverAt = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DeliverAt = int64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Priority", wireType)
+			}
+			m.Priority = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeq
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Priority |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Scheduled", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeq
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Scheduled = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDeq(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PubRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDeq
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PubRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PubRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Event", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeq
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDeq
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Event == nil {
+				m.Event = &Event{}
+			}
+			if err := m.Event.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AwaitChannel", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeq
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDeq
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AwaitChannel = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDeq(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PubBatchRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDeq
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PubBatchRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PubBatchRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Events", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeq
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDeq
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Events = append(m.Events, &Event{})
+			if err := m.Events[len(m.Events)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDeq(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PubBatchResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDeq
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PubBatchResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PubBatchResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Events", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeq
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDeq
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Events = append(m.Events, &Event{})
+			if err := m.Events[len(m.Events)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDeq(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SubRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDeq
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SubRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SubRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Channel", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeq
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDeq
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Channel = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Topic", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeq
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDeq
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Topic = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Follow", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeq
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Follow = bool(v != 0)
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RequeueDelayMilliseconds", wireType)
+			}
+			m.RequeueDelayMilliseconds = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeq
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RequeueDelayMilliseconds |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IdleTimeoutMilliseconds", wireType)
+			}
+			m.IdleTimeoutMilliseconds = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeq
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.IdleTimeoutMilliseconds |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeadLetterTopic", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeq
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDeq
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DeadLetterTopic = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDeq(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AckRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDeq
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AckRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AckRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Channel", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeq
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDeq
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Channel = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Topic", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeq
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDeq
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Topic = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EventId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeq
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDeq
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EventId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
+			}
+			m.Code = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeq
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Code |= AckCode(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeq
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDeq
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Error = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDeq(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AckResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDeq
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AckResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AckResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDeq(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *GetRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDeq
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: GetRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: GetRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EventId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeq
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDeq
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EventId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Topic", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeq
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDeq
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Topic = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Channel", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeq
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDeq
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Channel = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Await", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeq
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Await = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDeq(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDeq
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Topic", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeq
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDeq
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Topic = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Channel", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeq
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDeq
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Channel = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeq
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDeq
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.MinId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeq
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDeq
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.MaxId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PageSize", wireType)
+			}
+			m.PageSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeq
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PageSize |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reversed", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeq
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Reversed = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDeq(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDeq
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Events", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeq
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDeq
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Events = append(m.Events, &Event{})
+			if err := m.Events[len(m.Events)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDeq(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DelRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDeq
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DelRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DelRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EventId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeq
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDeq
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EventId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Topic", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeq
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDeq
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Topic = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDeq(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DelTopicRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDeq
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DelTopicRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DelTopicRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Topic", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeq
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDeq
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Topic = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Before", wireType)
+			}
+			m.Before = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Before = int64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDeq(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DelTopicResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDeq
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DelTopicResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DelTopicResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeletedCount", wireType)
+			}
+			m.DeletedCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeq
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DeletedCount |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDeq(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *BackupRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDeq
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BackupRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BackupRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Since", wireType)
+			}
+			m.Since = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Since = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDeq(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *BackupChunk) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDeq
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BackupChunk: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BackupChunk: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeq
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthDeq
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Version = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDeq(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RestoreRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDeq
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RestoreRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RestoreRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeq
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthDeq
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDeq(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RestoreResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDeq
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RestoreResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RestoreResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDeq(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ExportRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDeq
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ExportRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ExportRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Topic", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeq
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDeq
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Topic = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Format", wireType)
+			}
+			m.Format = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeq
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Format |= ExportFormat(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChannelStates", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeq
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ChannelStates = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDeq(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ExportChunk) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDeq
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ExportChunk: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ExportChunk: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeq
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthDeq
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDeq(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ImportRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDeq
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ImportRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ImportRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Format", wireType)
+			}
+			m.Format = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeq
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Format |= ExportFormat(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeq
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthDeq
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDeq(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ImportResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDeq
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ImportResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ImportResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ImportedCount", wireType)
+			}
+			m.ImportedCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeq
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ImportedCount |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDeq(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TopicsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDeq
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TopicsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TopicsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDeq(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TopicsResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDeq
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TopicsResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TopicsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Topics", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeq
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDeq
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Topics = append(m.Topics, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDeq(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
 }
-func (m *Event) Unmarshal(dAtA []byte) error {
+func (m *Schema) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2182,15 +8489,15 @@ func (m *Event) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Event: wiretype end group for non-group")
+			return fmt.Errorf("proto: Schema: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Event: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Schema: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Topic", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2218,11 +8525,11 @@ func (m *Event) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Id = string(dAtA[iNdEx:postIndex])
+			m.Topic = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Topic", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field MessageType", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2250,11 +8557,11 @@ func (m *Event) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Topic = string(dAtA[iNdEx:postIndex])
+			m.MessageType = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field FileDescriptorSet", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -2281,64 +8588,16 @@ func (m *Event) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Payload = append(m.Payload[:0], dAtA[iNdEx:postIndex]...)
-			if m.Payload == nil {
-				m.Payload = []byte{}
+			m.FileDescriptorSet = append(m.FileDescriptorSet[:0], dAtA[iNdEx:postIndex]...)
+			if m.FileDescriptorSet == nil {
+				m.FileDescriptorSet = []byte{}
 			}
 			iNdEx = postIndex
 		case 4:
-			if wireType != 1 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CreateTime", wireType)
-			}
-			m.CreateTime = 0
-			if (iNdEx + 8) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.CreateTime = int64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
-			iNdEx += 8
-		case 5:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DefaultState", wireType)
-			}
-			m.DefaultState = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowDeq
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.DefaultState |= EventState(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 6:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
-			}
-			m.State = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowDeq
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.State |= EventState(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 7:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RequeueCount", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
 			}
-			m.RequeueCount = 0
+			m.Version = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDeq
@@ -2348,7 +8607,7 @@ func (m *Event) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.RequeueCount |= int32(b&0x7F) << shift
+				m.Version |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -2377,7 +8636,7 @@ func (m *Event) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PubRequest) Unmarshal(dAtA []byte) error {
+func (m *RegisterSchemaRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2400,17 +8659,17 @@ func (m *PubRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PubRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: RegisterSchemaRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PubRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RegisterSchemaRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Event", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Topic", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDeq
@@ -2420,31 +8679,27 @@ func (m *PubRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthDeq
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthDeq
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Event == nil {
-				m.Event = &Event{}
-			}
-			if err := m.Event.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Topic = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AwaitChannel", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field MessageType", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2472,7 +8727,41 @@ func (m *PubRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.AwaitChannel = string(dAtA[iNdEx:postIndex])
+			m.MessageType = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FileDescriptorSet", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeq
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthDeq
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.FileDescriptorSet = append(m.FileDescriptorSet[:0], dAtA[iNdEx:postIndex]...)
+			if m.FileDescriptorSet == nil {
+				m.FileDescriptorSet = []byte{}
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -2498,7 +8787,7 @@ func (m *PubRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SubRequest) Unmarshal(dAtA []byte) error {
+func (m *GetSchemaRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2521,15 +8810,15 @@ func (m *SubRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SubRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetSchemaRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SubRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetSchemaRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Channel", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Topic", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2557,9 +8846,62 @@ func (m *SubRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Channel = string(dAtA[iNdEx:postIndex])
+			m.Topic = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 2:
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDeq(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TopicConfig) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDeq
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TopicConfig: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TopicConfig: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Topic", wireType)
 			}
@@ -2591,11 +8933,11 @@ func (m *SubRequest) Unmarshal(dAtA []byte) error {
 			}
 			m.Topic = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 5:
+		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Follow", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RequeueLimit", wireType)
 			}
-			var v int
+			m.RequeueLimit = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDeq
@@ -2605,13 +8947,31 @@ func (m *SubRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				m.RequeueLimit |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Follow = bool(v != 0)
-		case 6:
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Backoff", wireType)
+			}
+			m.Backoff = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeq
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Backoff |= Backoff(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
 			if wireType != 0 {
 				return fmt.Errorf("proto: wrong wireType = %d for field RequeueDelayMilliseconds", wireType)
 			}
@@ -2630,11 +8990,68 @@ func (m *SubRequest) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DefaultState", wireType)
+			}
+			m.DefaultState = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeq
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DefaultState |= EventState(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RetentionMaxAgeMilliseconds", wireType)
+			}
+			m.RetentionMaxAgeMilliseconds = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeq
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RetentionMaxAgeMilliseconds |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		case 7:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IdleTimeoutMilliseconds", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RetentionMaxCount", wireType)
 			}
-			m.IdleTimeoutMilliseconds = 0
+			m.RetentionMaxCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeq
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RetentionMaxCount |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeadLetterTopic", wireType)
+			}
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDeq
@@ -2644,11 +9061,24 @@ func (m *SubRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.IdleTimeoutMilliseconds |= int32(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDeq
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DeadLetterTopic = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipDeq(dAtA[iNdEx:])
@@ -2673,7 +9103,7 @@ func (m *SubRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AckRequest) Unmarshal(dAtA []byte) error {
+func (m *SetTopicConfigRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2696,49 +9126,17 @@ func (m *AckRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AckRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: SetTopicConfigRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AckRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SetTopicConfigRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Channel", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowDeq
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthDeq
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthDeq
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Channel = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Topic", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Config", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDeq
@@ -2748,75 +9146,28 @@ func (m *AckRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthDeq
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthDeq
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Topic = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EventId", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowDeq
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthDeq
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthDeq
+			if m.Config == nil {
+				m.Config = &TopicConfig{}
 			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+			if err := m.Config.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-			m.EventId = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
-			}
-			m.Code = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowDeq
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Code |= AckCode(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipDeq(dAtA[iNdEx:])
@@ -2841,7 +9192,7 @@ func (m *AckRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AckResponse) Unmarshal(dAtA []byte) error {
+func (m *GetTopicConfigRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2864,12 +9215,44 @@ func (m *AckResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AckResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: GetTopicConfigRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AckResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: GetTopicConfigRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Topic", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeq
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDeq
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Topic = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipDeq(dAtA[iNdEx:])
@@ -2894,7 +9277,7 @@ func (m *AckResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *GetRequest) Unmarshal(dAtA []byte) error {
+func (m *RedriveRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2917,15 +9300,15 @@ func (m *GetRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: GetRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: RedriveRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: GetRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: RedriveRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EventId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Topic", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2953,11 +9336,11 @@ func (m *GetRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.EventId = string(dAtA[iNdEx:postIndex])
+			m.Topic = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Topic", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Channel", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -2985,11 +9368,95 @@ func (m *GetRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Topic = string(dAtA[iNdEx:postIndex])
+			m.Channel = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinCreateTime", wireType)
+			}
+			m.MinCreateTime = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.MinCreateTime = int64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		case 4:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxCreateTime", wireType)
+			}
+			m.MaxCreateTime = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.MaxCreateTime = int64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Channel", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field MinId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeq
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDeq
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.MinId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeq
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDeq
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.MaxId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IndexPrefix", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3014,16 +9481,69 @@ func (m *GetRequest) Unmarshal(dAtA []byte) error {
 			if postIndex < 0 {
 				return ErrInvalidLengthDeq
 			}
-			if postIndex > l {
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.IndexPrefix = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDeq(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RedriveResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDeq
+			}
+			if iNdEx >= l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Channel = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 4:
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RedriveResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RedriveResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Await", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RedrivenCount", wireType)
 			}
-			var v int
+			m.RedrivenCount = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDeq
@@ -3033,12 +9553,11 @@ func (m *GetRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				m.RedrivenCount |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Await = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipDeq(dAtA[iNdEx:])
@@ -3063,7 +9582,7 @@ func (m *GetRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ListRequest) Unmarshal(dAtA []byte) error {
+func (m *Schedule) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3086,15 +9605,15 @@ func (m *ListRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ListRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: Schedule: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ListRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Schedule: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Topic", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3122,11 +9641,11 @@ func (m *ListRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Topic = string(dAtA[iNdEx:postIndex])
+			m.Name = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Channel", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Cron", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3154,13 +9673,13 @@ func (m *ListRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Channel = string(dAtA[iNdEx:postIndex])
+			m.Cron = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MinId", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IntervalMilliseconds", wireType)
 			}
-			var stringLen uint64
+			m.IntervalMilliseconds = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDeq
@@ -3170,27 +9689,14 @@ func (m *ListRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.IntervalMilliseconds |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthDeq
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthDeq
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.MinId = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MaxId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Topic", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3218,13 +9724,13 @@ func (m *ListRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.MaxId = string(dAtA[iNdEx:postIndex])
+			m.Topic = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 5:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PageSize", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
 			}
-			m.PageSize = 0
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDeq
@@ -3234,16 +9740,31 @@ func (m *ListRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.PageSize |= int32(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if byteLen < 0 {
+				return ErrInvalidLengthDeq
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Payload = append(m.Payload[:0], dAtA[iNdEx:postIndex]...)
+			if m.Payload == nil {
+				m.Payload = []byte{}
+			}
+			iNdEx = postIndex
 		case 6:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Reversed", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
 			}
-			var v int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDeq
@@ -3253,12 +9774,129 @@ func (m *ListRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Reversed = bool(v != 0)
+			if msglen < 0 {
+				return ErrInvalidLengthDeq
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Metadata == nil {
+				m.Metadata = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowDeq
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowDeq
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthDeq
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthDeq
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowDeq
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthDeq
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue < 0 {
+						return ErrInvalidLengthDeq
+					}
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipDeq(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthDeq
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Metadata[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 7:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastTick", wireType)
+			}
+			m.LastTick = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LastTick = int64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
 		default:
 			iNdEx = preIndex
 			skippy, err := skipDeq(dAtA[iNdEx:])
@@ -3283,7 +9921,7 @@ func (m *ListRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ListResponse) Unmarshal(dAtA []byte) error {
+func (m *SetScheduleRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3306,15 +9944,15 @@ func (m *ListResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ListResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: SetScheduleRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ListResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SetScheduleRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Events", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Schedule", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3341,8 +9979,10 @@ func (m *ListResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Events = append(m.Events, &Event{})
-			if err := m.Events[len(m.Events)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Schedule == nil {
+				m.Schedule = &Schedule{}
+			}
+			if err := m.Schedule.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -3370,7 +10010,7 @@ func (m *ListResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *DelRequest) Unmarshal(dAtA []byte) error {
+func (m *ListSchedulesRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3393,76 +10033,12 @@ func (m *DelRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: DelRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ListSchedulesRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DelRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ListSchedulesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EventId", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowDeq
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthDeq
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthDeq
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.EventId = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Topic", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowDeq
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthDeq
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthDeq
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Topic = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipDeq(dAtA[iNdEx:])
@@ -3487,7 +10063,7 @@ func (m *DelRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *TopicsRequest) Unmarshal(dAtA []byte) error {
+func (m *ListSchedulesResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3510,12 +10086,46 @@ func (m *TopicsRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: TopicsRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ListSchedulesResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: TopicsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ListSchedulesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Schedules", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDeq
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDeq
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDeq
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Schedules = append(m.Schedules, &Schedule{})
+			if err := m.Schedules[len(m.Schedules)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipDeq(dAtA[iNdEx:])
@@ -3540,7 +10150,7 @@ func (m *TopicsRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *TopicsResponse) Unmarshal(dAtA []byte) error {
+func (m *DelScheduleRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3563,15 +10173,15 @@ func (m *TopicsResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: TopicsResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: DelScheduleRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: TopicsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: DelScheduleRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Topics", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3599,7 +10209,7 @@ func (m *TopicsResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Topics = append(m.Topics, string(dAtA[iNdEx:postIndex]))
+			m.Name = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
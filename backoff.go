@@ -20,6 +20,14 @@ func ExponentialBackoff(d time.Duration) BackoffFunc {
 	}
 }
 
+// ConstantBackoff returns a BackoffFunc that always returns d, regardless of an event's
+// RequeueCount.
+func ConstantBackoff(d time.Duration) BackoffFunc {
+	return func(e Event) time.Duration {
+		return d
+	}
+}
+
 // LinearBackoff returns a BackoffFunc implementing a linear backoff based on an event's
 // RequeueCount, starting at a duration of d when an event's RequeueCount is 0 and increasing by
 // d with each requeue. The maximum backoff is capped at one hour to prevent overflow.
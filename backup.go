@@ -0,0 +1,94 @@
+package deq
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"gitlab.com/katcheCode/deq/internal/storage"
+)
+
+// backupMagic starts the header line of every backup, which is followed by the version of the
+// database the backup was created from.
+const backupMagic = "deq-backup "
+
+// ErrBadBackup is returned when restoring from a backup that is corrupt or wasn't created by
+// Store.Backup.
+var ErrBadBackup = errors.New("bad backup")
+
+// Backup writes a consistent snapshot of the store to w, including all events, indexes and
+// channel state. The store can be used as normal while the backup is in progress.
+//
+// If since is zero, the entire store is backed up. Otherwise only data modified after the version
+// since is backed up. Backup returns the version of the snapshot, which can be passed as since to a
+// later call to Backup to create an incremental backup.
+func (s *Store) Backup(w io.Writer, since uint64) (uint64, error) {
+	_, err := io.WriteString(w, backupMagic+dbCodeVersion+"\n")
+	if err != nil {
+		return 0, err
+	}
+
+	version, err := s.db.Backup(w, since)
+	if err != nil {
+		return 0, err
+	}
+
+	return version, nil
+}
+
+// Restore writes the data of a backup created by Backup to the store.
+//
+// To restore a store to the state of a backup, restore the full backup to a new store, followed by
+// each incremental backup in the order they were created. Restore returns ErrVersionMismatch if the
+// backup was created by a different version of the store.
+func (s *Store) Restore(r io.Reader) error {
+	br := bufio.NewReader(r)
+
+	header, err := br.ReadString('\n')
+	if err == io.EOF {
+		return ErrBadBackup
+	}
+	if err != nil {
+		return fmt.Errorf("read header: %v", err)
+	}
+	if !strings.HasPrefix(header, backupMagic) {
+		return ErrBadBackup
+	}
+	version := strings.TrimSuffix(strings.TrimPrefix(header, backupMagic), "\n")
+	if version != dbCodeVersion {
+		return ErrVersionMismatch
+	}
+
+	err = storage.Load(s.db, br)
+	if err == storage.ErrBadBackup {
+		return ErrBadBackup
+	}
+	if err != nil {
+		return err
+	}
+
+	err = s.loadSchemas()
+	if err != nil {
+		return fmt.Errorf("load restored schemas: %v", err)
+	}
+	err = s.loadTopicConfigs()
+	if err != nil {
+		return fmt.Errorf("load restored topic configs: %v", err)
+	}
+	err = s.loadSchedules()
+	if err != nil {
+		return fmt.Errorf("load restored schedules: %v", err)
+	}
+	s.schedulesChanged()
+
+	// Restored events may need to be delivered on active channels.
+	s.sharedChannelsMu.Lock()
+	for _, shared := range s.sharedChannels {
+		shared.wakeUp()
+	}
+	s.sharedChannelsMu.Unlock()
+
+	return nil
+}
@@ -0,0 +1,140 @@
+package deq
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestBackup(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	dir, err := ioutil.TempDir("", "test-backup")
+	if err != nil {
+		t.Fatalf("create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	// Back up from badger and restore to memory to make sure backups are portable.
+	db, err := Open(Options{
+		Dir: dir,
+	})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer db.Close()
+
+	// Round(0) gets rid of leap-second info, which will be lost in serialization
+	now := time.Now().Round(0)
+
+	for _, id := range []string{"event1", "event2"} {
+		_, err := db.Pub(ctx, Event{
+			ID:         id,
+			Topic:      "topic",
+			CreateTime: now,
+			Indexes:    []string{"index-" + id},
+		})
+		if err != nil {
+			t.Fatalf("pub %s: %v", id, err)
+		}
+	}
+
+	channel := db.Channel("channel", "topic")
+	defer channel.Close()
+
+	err = channel.SetEventState("event1", EventStateDequeuedOK)
+	if err != nil {
+		t.Fatalf("set event state: %v", err)
+	}
+
+	var full bytes.Buffer
+	version, err := db.Backup(&full, 0)
+	if err != nil {
+		t.Fatalf("backup: %v", err)
+	}
+
+	err = db.Del("topic", "event2")
+	if err != nil {
+		t.Fatalf("del: %v", err)
+	}
+	_, err = db.Pub(ctx, Event{
+		ID:         "event3",
+		Topic:      "topic",
+		CreateTime: now,
+	})
+	if err != nil {
+		t.Fatalf("pub event3: %v", err)
+	}
+
+	var incremental bytes.Buffer
+	_, err = db.Backup(&incremental, version)
+	if err != nil {
+		t.Fatalf("incremental backup: %v", err)
+	}
+
+	restored, discard := newTestDB()
+	defer discard()
+
+	err = restored.Restore(&full)
+	if err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+	err = restored.Restore(&incremental)
+	if err != nil {
+		t.Fatalf("restore incremental: %v", err)
+	}
+
+	restoredChannel := restored.Channel("channel", "topic")
+	defer restoredChannel.Close()
+
+	expected := []Event{
+		{
+			ID:           "event1",
+			Topic:        "topic",
+			CreateTime:   now,
+			Indexes:      []string{"index-event1"},
+			DefaultState: EventStateQueued,
+			State:        EventStateDequeuedOK,
+		},
+		{
+			ID:           "event3",
+			Topic:        "topic",
+			CreateTime:   now,
+			DefaultState: EventStateQueued,
+			State:        EventStateQueued,
+		},
+	}
+
+	var actual []Event
+	iter := restoredChannel.NewEventIter(DefaultIterOpts)
+	for iter.Next() {
+		actual = append(actual, iter.Event())
+	}
+	iter.Close()
+	if iter.Err() != nil {
+		t.Fatalf("iterate: %v", iter.Err())
+	}
+
+	if !cmp.Equal(expected, actual) {
+		t.Errorf("\n%s", cmp.Diff(expected, actual))
+	}
+}
+
+func TestRestoreBadBackup(t *testing.T) {
+	t.Parallel()
+
+	db, discard := newTestDB()
+	defer discard()
+
+	err := db.Restore(bytes.NewBufferString("not a backup"))
+	if err != ErrBadBackup {
+		t.Errorf("expected ErrBadBackup, got %v", err)
+	}
+}
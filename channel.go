@@ -6,9 +6,11 @@ import (
 	"sync"
 	"time"
 
-	"github.com/dgraph-io/badger"
 	"gitlab.com/katcheCode/deq/ack"
+	api "gitlab.com/katcheCode/deq/api/v1/deq"
 	"gitlab.com/katcheCode/deq/internal/data"
+	"gitlab.com/katcheCode/deq/internal/storage"
+	"gitlab.com/katcheCode/deq/trace"
 )
 
 // Channel allows multiple listeners to synchronize processing of events.
@@ -22,11 +24,12 @@ type Channel struct {
 	done       chan struct{}
 	errMutex   sync.Mutex
 	err        error
-	db         *badger.DB
+	db         storage.DB
 	store      *Store
 	sharedDone func()
 
-	backoffFunc BackoffFunc
+	backoffFunc     BackoffFunc
+	deadLetterTopic string
 }
 
 // Channel returns the channel for a given name
@@ -35,23 +38,61 @@ func (s *Store) Channel(name, topic string) *Channel {
 
 	// DON'T FORGET TO ADD CHECK FOR FAILED CHANNEL
 
+	config := s.TopicConfig(topic)
+
 	return &Channel{
-		name:        name,
-		topic:       topic,
-		shared:      shared,
-		done:        make(chan struct{}),
-		db:          s.db,
-		backoffFunc: ExponentialBackoff(time.Second),
-		store:       s,
-		sharedDone:  sharedDone,
+		name:            name,
+		topic:           topic,
+		shared:          shared,
+		done:            make(chan struct{}),
+		db:              s.db,
+		backoffFunc:     config.BackoffFunc(time.Second),
+		deadLetterTopic: config.DeadLetterTopic,
+		store:           s,
+		sharedDone:      sharedDone,
 	}
 }
 
+// DeleteChannel deletes the state of every event on a channel, as if the channel had never been
+// used. Afterwards every event of topic is queued on the channel, including events published with a
+// DefaultState other than EventStateQueued, and is sent to any of the channel's subscribers again.
+//
+// Channel state is deleted in small batches, so DeleteChannel may be called while the channel is in
+// use.
+func (s *Store) DeleteChannel(name, topic string) error {
+
+	prefix, err := data.ChannelKey{
+		Channel: name,
+		Topic:   topic,
+	}.Marshal(nil)
+	if err != nil {
+		return err
+	}
+
+	for more := true; more; {
+		more, err = s.delPrefixBatch(context.Background(), prefix)
+		if err != nil {
+			return fmt.Errorf("delete channel state: %v", err)
+		}
+	}
+
+	s.sharedChannelsMu.Lock()
+	shared := s.sharedChannels[channelKey{name, topic}]
+	s.sharedChannelsMu.Unlock()
+
+	if shared != nil {
+		shared.wakeUp()
+	}
+
+	return nil
+}
+
 // BackoffFunc sets the function that determines the requeue delay for each event removed from c's
 // queue.
 //
-// The BackoffFunc for a channel defaults to an exponential backoff starting at one second,
-// equivelant to calling:
+// The BackoffFunc for a channel defaults to the backoff selected by the TopicConfig of c's topic,
+// with a base delay of one second if the TopicConfig has no RequeueDelay. If the topic has no
+// TopicConfig, this is equivelant to calling:
 //   c.BackoffFunc(deq.ExponentialBackoff(time.Second))
 //
 // BackoffFunc is not safe for concurrent use with any of c's methods.
@@ -74,19 +115,27 @@ func (c *Channel) Next(ctx context.Context) (Event, error) {
 			if e == nil {
 				return Event{}, c.Err()
 			}
+			var channel data.ChannelPayload
 			txn := c.db.NewTransaction(false)
-			defer txn.Discard()
-
-			// TODO: don't allow deleted events to get sent out.
-			channel, err := getChannelEvent(txn, data.ChannelKey{
-				Channel: c.name,
-				Topic:   c.topic,
-				ID:      e.ID,
+			_, err := getEventTimePayload(txn, data.EventTimeKey{
+				Topic: c.topic,
+				ID:    e.ID,
 			})
+			if err == nil {
+				channel, err = getChannelEvent(txn, data.ChannelKey{
+					Channel: c.name,
+					Topic:   c.topic,
+					ID:      e.ID,
+				})
+			}
+			txn.Discard()
+			if err == ErrNotFound {
+				// The event was deleted after it was sent to the shared channel.
+				continue
+			}
 			if err != nil {
 				return Event{}, err
 			}
-
 			if channel.EventState != data.EventState_QUEUED {
 				continue
 			}
@@ -109,9 +158,19 @@ func (c *Channel) Next(ctx context.Context) (Event, error) {
 // error.
 //
 // The Event returned by handler is published if non-nil, and ack.Code is processed according to the
-// rules specified in the gitlab.com/katcheCode/deq/ack package. Sub only handles one event at a
-// time. To handle multiple events concurrently subscribe with the same handler on multiple
-// goroutines. For example:
+// rules specified in the gitlab.com/katcheCode/deq/ack package. If ack.Code dequeues the event, the
+// returned Event is published in the same transaction that dequeues the handled event, so either
+// both take effect or neither does. The returned Event inherits each entry of the handled event's
+// Metadata that it doesn't set itself, so metadata such as trace context or a tenant ID follows the
+// event through its handlers.
+//
+// If the handled event carries W3C trace context (see package gitlab.com/katcheCode/deq/trace),
+// Sub starts a span for the handler that continues the event's trace and exports it to
+// Options.TraceExporter once the event is acknowledged. The event passed to handler carries the
+// handler span's trace context, so events published by the handler are part of the same trace.
+//
+// Sub only handles one event at a time. To handle multiple events concurrently subscribe with the
+// same handler on multiple goroutines. For example:
 //
 //   errc := make(chan error, 1)
 //   for i := 0; i < workerCount; i++ {
@@ -126,67 +185,97 @@ func (c *Channel) Next(ctx context.Context) (Event, error) {
 //   ...
 func (c *Channel) Sub(ctx context.Context, handler func(Event) (*Event, ack.Code)) error {
 
-	errc := make(chan error, 1)
-	responses := make(chan *Event, 1)
-	defer close(responses)
-
-	// worker to publish responses event in parallel with processing ack.Code
-	go func() {
-		for response := range responses {
-			_, err := c.store.Pub(ctx, *response)
-			errc <- err
-		}
-	}()
-
 	for {
 		e, err := c.Next(ctx)
 		if err != nil {
 			return err
 		}
 
-		response, code := handler(e)
+		handled, span := c.startHandlerSpan(e)
 
+		response, code := handler(handled)
 		if response != nil {
-			responses <- response
+			response = inheritMetadata(response, handled)
 		}
 
 		switch code {
 		case ack.DequeueOK:
-			err := c.SetEventState(e.ID, EventStateDequeuedOK)
-			if err != nil {
-				errc <- fmt.Errorf("set event state: %v", err)
-			}
+			err = c.setEventState(e.ID, EventStateDequeuedOK, response)
 		case ack.DequeueError:
-			err := c.SetEventState(e.ID, EventStateDequeuedError)
-			if err != nil {
-				errc <- fmt.Errorf("set event state: %v", err)
-			}
+			err = c.setEventState(e.ID, EventStateDequeuedError, response)
 		case ack.RequeueConstant:
-			err := c.RequeueEvent(e, time.Second)
-			if err != nil {
-				errc <- fmt.Errorf("requeue event: %v", err)
-			}
+			err = c.pubAndRequeue(ctx, e, response, time.Second)
 		case ack.RequeueLinear:
-			err := c.RequeueEvent(e, LinearBackoff(time.Second)(e))
-			if err != nil {
-				errc <- fmt.Errorf("requeue event: %v", err)
-			}
+			err = c.pubAndRequeue(ctx, e, response, LinearBackoff(time.Second)(e))
 		case ack.RequeueExponential:
-			err := c.RequeueEvent(e, ExponentialBackoff(time.Second)(e))
-			if err != nil {
-				errc <- fmt.Errorf("requeue event: %v", err)
-			}
+			err = c.pubAndRequeue(ctx, e, response, ExponentialBackoff(time.Second)(e))
 		default:
-			errc <- fmt.Errorf("handler returned unrecognized ack.Code")
+			err = fmt.Errorf("handler returned unrecognized ack.Code")
 		}
-
-		if response != nil {
-			err = <-errc
+		if span != nil {
+			span.SetAttribute("deq.ack_code", api.AckCode(code).String())
 			if err != nil {
-				return fmt.Errorf("publish result: %v", err)
+				span.SetAttribute("error", err.Error())
 			}
+			span.End()
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// startHandlerSpan starts the span of a handler of e if e carries trace context, returning a copy of
+// e whose trace context is the new span's. span is nil if e doesn't carry trace context.
+func (c *Channel) startHandlerSpan(e Event) (handled Event, span *trace.Span) {
+	if _, ok := trace.Extract(e.Metadata); !ok {
+		return e, nil
+	}
+
+	ctx := trace.ContextWithMetadata(context.Background(), e.Metadata)
+	_, span = c.store.tracer.StartSpan(ctx, "deq.Sub "+c.topic)
+	span.SetAttribute("deq.topic", c.topic)
+	span.SetAttribute("deq.channel", c.name)
+	span.SetAttribute("deq.event_id", e.ID)
+
+	e.Metadata = trace.Inject(span.SpanContext, e.Metadata)
+	return e, span
+}
+
+// inheritMetadata returns a copy of response with each entry of e's Metadata that isn't set on
+// response.
+func inheritMetadata(response *Event, e Event) *Event {
+	if len(e.Metadata) == 0 {
+		return response
+	}
+
+	inherited := *response
+	inherited.Metadata = make(map[string]string, len(e.Metadata)+len(response.Metadata))
+	for k, v := range e.Metadata {
+		inherited.Metadata[k] = v
+	}
+	for k, v := range response.Metadata {
+		inherited.Metadata[k] = v
+	}
+
+	return &inherited
+}
+
+// pubAndRequeue publishes response if it is non-nil, then requeues e after delay.
+func (c *Channel) pubAndRequeue(ctx context.Context, e Event, response *Event, delay time.Duration) error {
+	if response != nil {
+		_, err := c.store.Pub(ctx, *response)
+		if err != nil {
+			return fmt.Errorf("publish result: %v", err)
 		}
 	}
+
+	err := c.RequeueEvent(e, delay)
+	if err != nil {
+		return fmt.Errorf("requeue event: %v", err)
+	}
+
+	return nil
 }
 
 // func (c *sharedChannel) enqueue(*Event) {
@@ -245,7 +334,7 @@ func (c *Channel) Get(eventID string) (Event, error) {
 	txn := c.db.NewTransaction(false)
 	defer txn.Discard()
 
-	e, err := getEvent(txn, c.topic, eventID, c.name)
+	e, err := getEvent(txn, c.store.keys, c.topic, eventID, c.name)
 	if err != nil {
 		return Event{}, err
 	}
@@ -288,23 +377,55 @@ func (c *Channel) Await(ctx context.Context, eventID string) (Event, error) {
 
 // SetEventState sets the state of an event for this channel.
 func (c *Channel) SetEventState(id string, state EventState) error {
+	return c.setEventState(id, state, nil)
+}
 
-	// Retry for up to 10 conflicts
-	for i := 0; i < 10; i++ {
-		key := data.ChannelKey{
-			Topic:   c.topic,
-			Channel: c.name,
-			ID:      id,
+// setEventState sets the state of an event for this channel. If response is non-nil, it is
+// published in the same transaction.
+func (c *Channel) setEventState(id string, state EventState, response *Event) error {
+
+	var e Event
+	if response != nil {
+		e = *response
+		err := c.store.prepareEvent(&e)
+		if err != nil {
+			return fmt.Errorf("publish result: %v", err)
+		}
+		err = c.store.validatePayload(&e)
+		if err != nil {
+			return err
 		}
+	}
 
-		txn := c.db.NewTransaction(true)
-		defer txn.Discard()
+	key := data.ChannelKey{
+		Topic:   c.topic,
+		Channel: c.name,
+		ID:      id,
+	}
 
+	var published bool
+	// changed is the event whose state changed, if the store has an observer to notify.
+	var changed *Event
+	var oldState EventState
+	err := c.store.write(context.Background(), func(txn storage.Txn) error {
 		channelEvent, err := getChannelEvent(txn, key)
 		if err != nil {
 			return err
 		}
 
+		changed = nil
+		oldState = protoToEventState(channelEvent.EventState)
+		if c.store.observer != nil && oldState != state {
+			changed, err = getEvent(txn, c.store.keys, c.topic, id, "")
+			if err != nil && err != ErrNotFound {
+				return fmt.Errorf("get event: %v", err)
+			}
+			if changed != nil {
+				changed.State = state
+				changed.RequeueCount = int(channelEvent.RequeueCount)
+			}
+		}
+
 		channelEvent.EventState = state.toProto()
 
 		err = setChannelEvent(txn, key, channelEvent)
@@ -312,21 +433,162 @@ func (c *Channel) SetEventState(id string, state EventState) error {
 			return err
 		}
 
-		err = txn.Commit(nil)
-		if err == badger.ErrConflict {
-			time.Sleep(time.Second / 10)
-			continue
+		published = false
+		if response != nil {
+			existing, err := writeOrMatchEvent(txn, &e, c.store.compression(e.Topic), c.store.keys)
+			if err != nil {
+				return fmt.Errorf("publish result: %v", err)
+			}
+			published = existing == nil
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	c.shared.broadcastEventUpdated(id, state)
+
+	if changed != nil {
+		c.store.observer.EventStateChanged(c.name, *changed, oldState, state)
+	}
+
+	if published {
+		e.State = e.DefaultState
+		c.store.published(&e)
+	}
+
+	return nil
+}
+
+// Rewind requeues every event on c's topic created after since. The state of each event is reset to
+// EventStateQueued and its RequeueCount is reset to zero, and the events are sent to c's
+// subscribers again.
+//
+// Events are updated in small batches, so if Rewind returns an error some of the events may have
+// already been requeued.
+func (c *Channel) Rewind(since time.Time) error {
+
+	prefix, err := data.EventPrefixTopic(c.topic)
+	if err != nil {
+		return err
+	}
+	cursor := prefix
+	if since.After(time.Unix(0, 0)) {
+		cursor, err = data.EventKey{
+			Topic:      c.topic,
+			CreateTime: since,
+			ID:         data.FirstEventID,
+		}.Marshal(nil)
+		if err != nil {
+			return fmt.Errorf("marshal start key: %v", err)
 		}
+	}
+
+	for cursor != nil {
+		var ids []string
+		ids, cursor, err = c.rewindBatch(prefix, cursor, since)
 		if err != nil {
 			return err
 		}
 
-		c.shared.broadcastEventUpdated(id, state)
+		for _, id := range ids {
+			c.shared.broadcastEventUpdated(id, EventStateQueued)
+		}
+	}
+
+	c.shared.wakeUp()
+
+	return nil
+}
+
+// rewindBatch requeues up to 100 events starting at cursor that were created after since, or as
+// many as it reads within maxWriteHold. It returns the IDs of the requeued events and the cursor for
+// the next batch, or nil if there are no more events.
+func (c *Channel) rewindBatch(prefix, cursor []byte, since time.Time) ([]string, []byte, error) {
+
+	var ids []string
+	var next []byte
+	var changes []rewindChange
+
+	err := c.store.write(context.Background(), func(txn storage.Txn) error {
+		var keys []data.EventKey
+		next = nil
+		deadline := time.Now().Add(maxWriteHold)
+		scanned := 0
+
+		it := txn.NewIterator(storage.IteratorOptions{})
+		for it.Seek(cursor); it.ValidForPrefix(prefix); it.Next() {
+			if len(keys) >= 100 || (scanned > 0 && time.Now().After(deadline)) {
+				next = it.Item().KeyCopy(nil)
+				break
+			}
+			scanned++
+
+			var key data.EventKey
+			err := data.UnmarshalTo(it.Item().Key(), &key)
+			if err != nil {
+				it.Close()
+				return fmt.Errorf("parse event key %s: %v", it.Item().Key(), err)
+			}
+			if !key.CreateTime.After(since) {
+				continue
+			}
+			keys = append(keys, key)
+		}
+		it.Close()
+
+		ids = make([]string, len(keys))
+		changes = nil
+		for i, key := range keys {
+			channelKey := data.ChannelKey{
+				Channel: c.name,
+				Topic:   c.topic,
+				ID:      key.ID,
+			}
+
+			if c.store.observer != nil {
+				channelEvent, err := getChannelEvent(txn, channelKey)
+				if err != nil {
+					return fmt.Errorf("get event state: %v", err)
+				}
+				if old := protoToEventState(channelEvent.EventState); old != EventStateQueued {
+					e, err := getEvent(txn, c.store.keys, c.topic, key.ID, "")
+					if err != nil {
+						return fmt.Errorf("get event: %v", err)
+					}
+					e.State = EventStateQueued
+					changes = append(changes, rewindChange{*e, old})
+				}
+			}
+
+			err := setChannelEvent(txn, channelKey, data.ChannelPayload{
+				EventState: data.EventState_QUEUED,
+			})
+			if err != nil {
+				return fmt.Errorf("set event state: %v", err)
+			}
+			ids[i] = key.ID
+		}
 
 		return nil
+	})
+	if err != nil {
+		return nil, nil, err
 	}
 
-	return badger.ErrConflict
+	for _, change := range changes {
+		c.store.observer.EventStateChanged(c.name, change.event, change.old, EventStateQueued)
+	}
+
+	return ids, next, nil
+}
+
+// rewindChange is the state of an event before it was requeued by Rewind or Redrive.
+type rewindChange struct {
+	event Event
+	old   EventState
 }
 
 // // EventStatus is the processing state of an event on a particular channel
@@ -340,7 +602,9 @@ func (c *Channel) SetEventState(id string, state EventState) error {
 // 	EventStatusWillNotProcess
 // )
 
-// RequeueEvent adds the event back into the event queue for this channel
+// RequeueEvent adds the event back into the event queue for this channel. If the event reaches its
+// requeue limit, it is dequeued with EventStateDequeuedError instead, and its dead letter is
+// published if c has a dead letter topic (see DeadLetterTopic).
 func (c *Channel) RequeueEvent(e Event, delay time.Duration) error {
-	return c.shared.RequeueEvent(e, delay)
+	return c.shared.RequeueEvent(e, delay, c.deadLetterTopic)
 }
@@ -2,14 +2,18 @@ package deq
 
 import (
 	"context"
+	"fmt"
 	"io/ioutil"
 	"log"
 	"os"
+	"sort"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"gitlab.com/katcheCode/deq/ack"
+	"gitlab.com/katcheCode/deq/trace"
 )
 
 func TestSub(t *testing.T) {
@@ -225,6 +229,348 @@ func TestSub(t *testing.T) {
 	}
 }
 
+func TestSubResponseAtomic(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	db, discard := newTestDB()
+	defer discard()
+
+	// The response conflicts with this event, so it can't be published.
+	_, err := db.Pub(ctx, Event{
+		ID:      "event1",
+		Topic:   "Response-TopicA",
+		Payload: []byte{1},
+	})
+	if err != nil {
+		t.Fatalf("pub: %v", err)
+	}
+	_, err = db.Pub(ctx, Event{
+		ID:    "event1",
+		Topic: "TopicA",
+	})
+	if err != nil {
+		t.Fatalf("pub: %v", err)
+	}
+
+	channel := db.Channel("channel", "TopicA")
+	defer channel.Close()
+
+	err = channel.Sub(ctx, func(e Event) (*Event, ack.Code) {
+		return &Event{
+			ID:      e.ID,
+			Topic:   "Response-TopicA",
+			Payload: []byte{2},
+		}, ack.DequeueOK
+	})
+	if err == nil || err == ctx.Err() {
+		t.Fatalf("expected publish error from sub, got %v", err)
+	}
+
+	e, err := channel.Get("event1")
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if e.State != EventStateQueued {
+		t.Errorf("expected handled event to remain queued, got state %v", e.State)
+	}
+
+	// Once the response can be published, both the response and the dequeue take effect.
+	err = channel.Sub(ctx, func(e Event) (*Event, ack.Code) {
+		cancel()
+		return &Event{
+			ID:      e.ID,
+			Topic:   "Response-TopicA",
+			Payload: []byte{1},
+		}, ack.DequeueOK
+	})
+	if err != context.Canceled {
+		t.Fatalf("sub: %v", err)
+	}
+
+	e, err = channel.Get("event1")
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if e.State != EventStateDequeuedOK {
+		t.Errorf("expected handled event to be dequeued, got state %v", e.State)
+	}
+}
+
+func TestSubMetadata(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	db, discard := newTestDB()
+	defer discard()
+
+	_, err := db.Pub(ctx, Event{
+		ID:       "event1",
+		Topic:    "TopicA",
+		Metadata: map[string]string{"tenant": "tenant1", "content-type": "text/plain"},
+	})
+	if err != nil {
+		t.Fatalf("pub: %v", err)
+	}
+
+	channel := db.Channel("channel", "TopicA")
+	defer channel.Close()
+
+	var handled map[string]string
+	err = channel.Sub(ctx, func(e Event) (*Event, ack.Code) {
+		cancel()
+		handled = e.Metadata
+		return &Event{
+			ID:       e.ID,
+			Topic:    "Response-TopicA",
+			Metadata: map[string]string{"content-type": "application/json"},
+		}, ack.DequeueOK
+	})
+	if err != context.Canceled {
+		t.Fatalf("sub: %v", err)
+	}
+
+	expected := map[string]string{"tenant": "tenant1", "content-type": "text/plain"}
+	if !cmp.Equal(expected, handled) {
+		t.Errorf("handled event metadata:\n%s", cmp.Diff(expected, handled))
+	}
+
+	responses := db.Channel("channel", "Response-TopicA")
+	defer responses.Close()
+
+	response, err := responses.Get("event1")
+	if err != nil {
+		t.Fatalf("get response: %v", err)
+	}
+	expected = map[string]string{"tenant": "tenant1", "content-type": "application/json"}
+	if !cmp.Equal(expected, response.Metadata) {
+		t.Errorf("response metadata:\n%s", cmp.Diff(expected, response.Metadata))
+	}
+}
+
+type recordingExporter struct {
+	mu    sync.Mutex
+	spans []*trace.Span
+}
+
+func (e *recordingExporter) ExportSpan(s *trace.Span) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.spans = append(e.spans, s)
+}
+
+func TestSubTrace(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	exporter := &recordingExporter{}
+
+	db, err := Open(Options{
+		InMemory:      true,
+		TraceExporter: exporter,
+	})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer db.Close()
+
+	_, publisher := trace.NewTracer(nil).StartSpan(ctx, "publisher")
+	_, err = db.Pub(ctx, Event{
+		ID:       "event1",
+		Topic:    "TopicA",
+		Metadata: trace.Inject(publisher.SpanContext, nil),
+	})
+	if err != nil {
+		t.Fatalf("pub: %v", err)
+	}
+
+	channel := db.Channel("channel", "TopicA")
+	defer channel.Close()
+
+	var handled map[string]string
+	err = channel.Sub(ctx, func(e Event) (*Event, ack.Code) {
+		cancel()
+		handled = e.Metadata
+		return &Event{
+			ID:    e.ID,
+			Topic: "Response-TopicA",
+		}, ack.DequeueOK
+	})
+	if err != context.Canceled {
+		t.Fatalf("sub: %v", err)
+	}
+
+	exporter.mu.Lock()
+	spans := exporter.spans
+	exporter.mu.Unlock()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 exported span, got %d", len(spans))
+	}
+	span := spans[0]
+
+	if span.Parent != publisher.SpanContext {
+		t.Errorf("handler span parent: expected %v, got %v", publisher.SpanContext, span.Parent)
+	}
+	expectedAttributes := map[string]string{
+		"deq.topic":    "TopicA",
+		"deq.channel":  "channel",
+		"deq.event_id": "event1",
+		"deq.ack_code": "DEQUEUE_OK",
+	}
+	if !cmp.Equal(expectedAttributes, span.Attributes()) {
+		t.Errorf("handler span attributes:\n%s", cmp.Diff(expectedAttributes, span.Attributes()))
+	}
+
+	expected := map[string]string{trace.TraceparentKey: span.SpanContext.Traceparent()}
+	if !cmp.Equal(expected, handled) {
+		t.Errorf("handled event metadata:\n%s", cmp.Diff(expected, handled))
+	}
+
+	responses := db.Channel("channel", "Response-TopicA")
+	defer responses.Close()
+
+	response, err := responses.Get("event1")
+	if err != nil {
+		t.Fatalf("get response: %v", err)
+	}
+	if !cmp.Equal(expected, response.Metadata) {
+		t.Errorf("response metadata:\n%s", cmp.Diff(expected, response.Metadata))
+	}
+}
+
+func TestRewind(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	db, discard := newTestDB()
+	defer discard()
+
+	// Round(0) gets rid of leap-second info, which will be lost in serialization
+	createTime := time.Now().Round(0)
+
+	channel := db.Channel("channel", "topic")
+	defer channel.Close()
+
+	// Don't requeue events during the test.
+	channel.BackoffFunc(func(Event) time.Duration { return time.Hour })
+
+	for i := 0; i < 3; i++ {
+		_, err := db.Pub(ctx, Event{
+			ID:         fmt.Sprintf("event%d", i),
+			Topic:      "topic",
+			CreateTime: createTime.Add(time.Duration(i) * time.Second),
+		})
+		if err != nil {
+			t.Fatalf("pub: %v", err)
+		}
+	}
+
+	// Process every event once.
+	for i := 0; i < 3; i++ {
+		e, err := channel.Next(ctx)
+		if err != nil {
+			t.Fatalf("next: %v", err)
+		}
+		err = channel.SetEventState(e.ID, EventStateDequeuedOK)
+		if err != nil {
+			t.Fatalf("set event state: %v", err)
+		}
+	}
+
+	err := channel.Rewind(createTime)
+	if err != nil {
+		t.Fatalf("rewind: %v", err)
+	}
+
+	e, err := channel.Get("event0")
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if e.State != EventStateDequeuedOK {
+		t.Errorf("expected event before rewind time to be unchanged, got state %v", e.State)
+	}
+
+	var actual []string
+	for len(actual) < 2 {
+		e, err := channel.Next(ctx)
+		if err != nil {
+			t.Fatalf("next after rewind: %v", err)
+		}
+		if e.RequeueCount != 0 {
+			t.Errorf("expected requeue count of %s to be reset, got %d", e.ID, e.RequeueCount)
+		}
+		actual = append(actual, e.ID)
+	}
+	sort.Strings(actual)
+
+	expected := []string{"event1", "event2"}
+	if !cmp.Equal(expected, actual) {
+		t.Errorf("rewound events:\n%s", cmp.Diff(expected, actual))
+	}
+}
+
+func TestDeleteChannel(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	db, discard := newTestDB()
+	defer discard()
+
+	channel := db.Channel("channel", "topic")
+	defer channel.Close()
+
+	// Don't requeue events during the test.
+	channel.BackoffFunc(func(Event) time.Duration { return time.Hour })
+
+	_, err := db.Pub(ctx, Event{
+		ID:    "event1",
+		Topic: "topic",
+	})
+	if err != nil {
+		t.Fatalf("pub: %v", err)
+	}
+
+	e, err := channel.Next(ctx)
+	if err != nil {
+		t.Fatalf("next: %v", err)
+	}
+	err = channel.SetEventState(e.ID, EventStateDequeuedOK)
+	if err != nil {
+		t.Fatalf("set event state: %v", err)
+	}
+
+	err = db.DeleteChannel("channel", "topic")
+	if err != nil {
+		t.Fatalf("delete channel: %v", err)
+	}
+
+	e, err = channel.Get("event1")
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if e.State != EventStateQueued {
+		t.Errorf("expected event to be queued after deleting channel, got state %v", e.State)
+	}
+
+	e, err = channel.Next(ctx)
+	if err != nil {
+		t.Fatalf("next after delete channel: %v", err)
+	}
+	if e.ID != "event1" {
+		t.Errorf("expected event1 after delete channel, got %s", e.ID)
+	}
+}
+
 func TestAwait(t *testing.T) {
 	t.Parallel()
 
@@ -500,3 +846,220 @@ func TestDequeue(t *testing.T) {
 		t.Fatalf("recieved dequeued event: %v", e)
 	}
 }
+
+func TestRequeueLimit(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	db, err := Open(Options{
+		InMemory:            true,
+		DefaultRequeueLimit: 3,
+	})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer db.Close()
+
+	err = db.SetTopicConfig("TopicB", TopicConfig{RequeueLimit: 2})
+	if err != nil {
+		t.Fatalf("set topic config: %v", err)
+	}
+
+	for _, tc := range []struct {
+		name     string
+		event    Event
+		expected int
+	}{
+		{"store default", Event{ID: "event1", Topic: "TopicA"}, 3},
+		{"topic default", Event{ID: "event1", Topic: "TopicB"}, 2},
+		{"event", Event{ID: "event2", Topic: "TopicB", RequeueLimit: 1}, 1},
+	} {
+		e, err := db.Pub(ctx, tc.event)
+		if err != nil {
+			t.Fatalf("%s: pub: %v", tc.name, err)
+		}
+
+		channel := db.Channel("channel", e.Topic)
+
+		e, err = channel.Get(e.ID)
+		if err != nil {
+			t.Fatalf("%s: get: %v", tc.name, err)
+		}
+		if e.RequeueLimit != tc.event.RequeueLimit {
+			t.Errorf("%s: expected stored requeue limit %d, got %d", tc.name, tc.event.RequeueLimit, e.RequeueLimit)
+		}
+
+		for i := 0; i <= tc.expected; i++ {
+			err = channel.RequeueEvent(e, 0)
+			if err != nil {
+				t.Fatalf("%s: requeue: %v", tc.name, err)
+			}
+		}
+
+		e, err = channel.Get(e.ID)
+		if err != nil {
+			t.Fatalf("%s: get: %v", tc.name, err)
+		}
+		if e.State != EventStateDequeuedError || e.RequeueCount != tc.expected {
+			t.Errorf("%s: expected %v after %d requeues, got %v after %d", tc.name, EventStateDequeuedError, tc.expected, e.State, e.RequeueCount)
+		}
+
+		channel.Close()
+	}
+}
+
+func TestDeliverAt(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	dir, err := ioutil.TempDir("", "test-deliver-at")
+	if err != nil {
+		t.Fatalf("create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	db, err := Open(Options{Dir: dir})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+
+	deliverAt := time.Now().Add(500 * time.Millisecond)
+	_, err = db.PubBatch(ctx, []Event{
+		{ID: "event1", Topic: "TopicA", DeliverAt: deliverAt},
+		{ID: "event2", Topic: "TopicA"},
+	})
+	if err != nil {
+		t.Fatalf("pub: %v", err)
+	}
+
+	channel := db.Channel("channel", "TopicA")
+
+	e, err := channel.Get("event1")
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if !e.DeliverAt.Equal(deliverAt) || !e.Scheduled(time.Now()) {
+		t.Errorf("expected event1 to be scheduled at %v, got %v", deliverAt, e.DeliverAt)
+	}
+
+	nextCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond)
+	e, err = channel.Next(nextCtx)
+	cancel()
+	if err != nil {
+		t.Fatalf("next: %v", err)
+	}
+	if e.ID != "event2" {
+		t.Fatalf("expected event2 to be delivered first, got %s", e.ID)
+	}
+	err = channel.SetEventState(e.ID, EventStateDequeuedOK)
+	if err != nil {
+		t.Fatalf("set event state: %v", err)
+	}
+
+	nextCtx, cancel = context.WithTimeout(ctx, 100*time.Millisecond)
+	e, err = channel.Next(nextCtx)
+	cancel()
+	if err != context.DeadlineExceeded {
+		t.Fatalf("expected no event before event1 is due, got %s, %v", e.ID, err)
+	}
+	channel.Close()
+	db.Close()
+
+	// Scheduled events are delivered after a restart.
+	db, err = Open(Options{Dir: dir})
+	if err != nil {
+		t.Fatalf("reopen db: %v", err)
+	}
+	defer db.Close()
+
+	channel = db.Channel("channel", "TopicA")
+	defer channel.Close()
+
+	nextCtx, cancel = context.WithTimeout(ctx, 2*time.Second)
+	e, err = channel.Next(nextCtx)
+	cancel()
+	if err != nil {
+		t.Fatalf("next after reopen: %v", err)
+	}
+	if e.ID != "event1" {
+		t.Errorf("expected event1, got %s", e.ID)
+	}
+	if time.Now().Before(deliverAt) {
+		t.Errorf("event1 delivered before %v", deliverAt)
+	}
+}
+
+func TestPriority(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	dir, err := ioutil.TempDir("", "test-priority")
+	if err != nil {
+		t.Fatalf("create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	db, err := Open(Options{Dir: dir})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer db.Close()
+
+	now := time.Now()
+	_, err = db.PubBatch(ctx, []Event{
+		{ID: "low", Topic: "TopicA", CreateTime: now, Priority: -1},
+		{ID: "bulk1", Topic: "TopicA", CreateTime: now.Add(time.Second)},
+		{ID: "bulk2", Topic: "TopicA", CreateTime: now.Add(2 * time.Second)},
+		{ID: "urgent", Topic: "TopicA", CreateTime: now.Add(3 * time.Second), Priority: 5},
+		{ID: "deleted", Topic: "TopicA", CreateTime: now.Add(4 * time.Second), Priority: 10},
+		{ID: "critical", Topic: "TopicA", CreateTime: now.Add(5 * time.Second), Priority: 10},
+	})
+	if err != nil {
+		t.Fatalf("pub: %v", err)
+	}
+	err = db.Del("TopicA", "deleted")
+	if err != nil {
+		t.Fatalf("del: %v", err)
+	}
+
+	channel := db.Channel("channel", "TopicA")
+	defer channel.Close()
+
+	var ids []string
+	for len(ids) < 5 {
+		nextCtx, cancel := context.WithTimeout(ctx, time.Second)
+		e, err := channel.Next(nextCtx)
+		cancel()
+		if err != nil {
+			t.Fatalf("next: %v", err)
+		}
+		ids = append(ids, e.ID)
+	}
+	expected := []string{"critical", "urgent", "bulk1", "bulk2", "low"}
+	if !cmp.Equal(expected, ids) {
+		t.Errorf("delivery order:\n%s", cmp.Diff(expected, ids))
+	}
+
+	// Events received from memory are ordered the same way.
+	var pending pendingEvents
+	for _, e := range []*Event{
+		{ID: "bulk1"},
+		{ID: "low", Priority: -1},
+		{ID: "urgent", Priority: 5},
+		{ID: "bulk2"},
+		{ID: "critical", Priority: 10},
+	} {
+		pending.add(e)
+	}
+	ids = ids[:0]
+	for len(pending) > 0 {
+		ids = append(ids, pending[0].ID)
+		pending.remove()
+	}
+	if !cmp.Equal(expected, ids) {
+		t.Errorf("pending order:\n%s", cmp.Diff(expected, ids))
+	}
+}
@@ -15,13 +15,16 @@ import (
 	"flag"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"math/rand"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"google.golang.org/grpc/credentials"
 
+	store "gitlab.com/katcheCode/deq"
 	"gitlab.com/katcheCode/deq/api/v1/deq"
 	"google.golang.org/grpc"
 )
@@ -35,14 +38,36 @@ func main() {
 		fmt.Println("Available Commands:")
 		fmt.Println("list: print events for a topic.")
 		fmt.Println("topics: print all topics.")
+		fmt.Println("deltopic: delete the events of a topic.")
+		fmt.Println("backup: write a backup of the server's data to -file, or stdout.")
+		fmt.Println("restore: restore a backup from -file, or stdin, to the server.")
+		fmt.Println("export: write the events of a topic to -file, or stdout.")
+		fmt.Println("import: publish events exported by export from -file, or stdin.")
+		fmt.Println("setschema: register the schema of a topic from the file descriptor set in -file, or stdin.")
+		fmt.Println("getschema: print the schema of a topic, writing its file descriptor set to -file if set.")
+		fmt.Println("topic config [SETTING=VALUE...]: print the configuration of a topic, after applying any settings.")
+		fmt.Println("  settings are requeue-limit, backoff (exponential, linear or constant), requeue-delay, default-state")
+		fmt.Println("  (queued, dequeued-ok or dequeued-error), max-age, max-count and dead-letter-topic. durations are Go durations,")
+		fmt.Println("  such as 30s.")
+		fmt.Println("redrive: requeue the events on channel -c that were dequeued with an error, optionally filtered by")
+		fmt.Println("  -after, -before, -min-id, -max-id and -index-prefix.")
+		fmt.Println("schedule list: print all schedules.")
+		fmt.Println("schedule set NAME: create or update a schedule that publishes to -t on each tick of -cron or -every,")
+		fmt.Println("  with the payload read from -file, if set.")
+		fmt.Println("schedule del NAME: delete a schedule.")
+		fmt.Println("reencrypt: re-encrypt the events of the database in -dir with the current key. deqd must not be running.")
+		fmt.Println("  keys are read from DEQ_ENCRYPTION_KEYS and DEQ_ENCRYPTION_KEY_ID, as in deqd.")
 		fmt.Println("")
 		fmt.Println("Available Flags:")
 		flag.PrintDefaults()
 	}
 
-	var host, channel, topic, nameOverride string
-	var follow, insecure bool
+	var host, channel, topic, nameOverride, before, after, file, format, dir, messageType string
+	var minID, maxID, indexPrefix, cronExpr string
+	var every time.Duration
+	var follow, insecure, channelStates bool
 	var timeout int
+	var since uint64
 
 	flag.StringVar(&host, "host", "localhost:3000", "specify deq host and port.")
 	flag.StringVar(&channel, "c", strconv.FormatInt(int64(rand.Int()), 16), "specify channel.")
@@ -51,6 +76,19 @@ func main() {
 	flag.IntVar(&timeout, "timeout", 10000, "timeout of the request in milliseconds.")
 	flag.BoolVar(&insecure, "insecure", false, "disables tls")
 	flag.StringVar(&nameOverride, "tls-name-override", "", "overrides the expected name on the server's TLS certificate.")
+	flag.StringVar(&before, "before", "", "only delete events created before this RFC 3339 timestamp. used by deltopic and redrive.")
+	flag.StringVar(&after, "after", "", "only redrive events created after this RFC 3339 timestamp. used by redrive.")
+	flag.StringVar(&minID, "min-id", "", "only redrive events with ids greater than or equal to this id. used by redrive.")
+	flag.StringVar(&maxID, "max-id", "", "only redrive events with ids less than or equal to this id. used by redrive.")
+	flag.StringVar(&indexPrefix, "index-prefix", "", "only redrive events with an index that starts with this prefix. used by redrive.")
+	flag.StringVar(&file, "file", "", "file to write to or read from. defaults to stdout or stdin. used by backup, restore, export, import, setschema, getschema and schedule set.")
+	flag.Uint64Var(&since, "since", 0, "only back up data modified after this backup version. used by backup.")
+	flag.StringVar(&format, "format", "proto", "format of exported events, either proto or json. used by export and import.")
+	flag.BoolVar(&channelStates, "channel-states", false, "include the state of each event on every channel. used by export.")
+	flag.StringVar(&messageType, "type", "", "full name of the protobuf message type of the topic's payloads. defaults to the topic. used by setschema.")
+	flag.StringVar(&cronExpr, "cron", "", "cron expression of the schedule's ticks in UTC, such as \"*/15 * * * *\". used by schedule set.")
+	flag.DurationVar(&every, "every", 0, "interval between the schedule's ticks, such as 1h. used by schedule set.")
+	flag.StringVar(&dir, "dir", "/var/deqd", "data directory of the database. used by reencrypt.")
 
 	flag.Parse()
 
@@ -109,6 +147,528 @@ func main() {
 			fmt.Printf("id: %v, topic: %s, %s\n", e.Id, e.Topic, e.Payload)
 		}
 
+	case "deltopic":
+		if topic == "" {
+			flag.Usage()
+			os.Exit(1)
+		}
+
+		var beforeTime int64
+		if before != "" {
+			t, err := time.Parse(time.RFC3339, before)
+			if err != nil {
+				fmt.Printf("parse -before: %v\n", err)
+				os.Exit(1)
+			}
+			beforeTime = t.UnixNano()
+		}
+
+		deqc, err := dial(host, nameOverride, insecure)
+		if err != nil {
+			fmt.Printf("dial: %v\n", err)
+			os.Exit(1)
+		}
+
+		resp, err := deqc.DelTopic(ctx, &deq.DelTopicRequest{
+			Topic:  topic,
+			Before: beforeTime,
+		})
+		if err != nil {
+			fmt.Printf("delete topic: %v\n", err)
+			os.Exit(2)
+		}
+
+		fmt.Printf("deleted %d events\n", resp.DeletedCount)
+
+	case "backup":
+		out := os.Stdout
+		if file != "" {
+			f, err := os.Create(file)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "create backup file: %v\n", err)
+				os.Exit(1)
+			}
+			defer f.Close()
+			out = f
+		}
+
+		deqc, err := dial(host, nameOverride, insecure)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "dial: %v\n", err)
+			os.Exit(1)
+		}
+
+		stream, err := deqc.Backup(ctx, &deq.BackupRequest{
+			Since: since,
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "backup: %v\n", err)
+			os.Exit(2)
+		}
+
+		var version uint64
+		for {
+			chunk, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "recieve backup: %v\n", err)
+				os.Exit(2)
+			}
+			_, err = out.Write(chunk.Data)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "write backup: %v\n", err)
+				os.Exit(1)
+			}
+			if chunk.Version != 0 {
+				version = chunk.Version
+			}
+		}
+
+		if file != "" {
+			err = out.Sync()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "write backup: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		// The backup itself may be on stdout, so report the version on stderr.
+		fmt.Fprintf(os.Stderr, "backup version: %d\n", version)
+
+	case "restore":
+		in := os.Stdin
+		if file != "" {
+			f, err := os.Open(file)
+			if err != nil {
+				fmt.Printf("open backup file: %v\n", err)
+				os.Exit(1)
+			}
+			defer f.Close()
+			in = f
+		}
+
+		deqc, err := dial(host, nameOverride, insecure)
+		if err != nil {
+			fmt.Printf("dial: %v\n", err)
+			os.Exit(1)
+		}
+
+		stream, err := deqc.Restore(ctx)
+		if err != nil {
+			fmt.Printf("restore: %v\n", err)
+			os.Exit(2)
+		}
+
+		buf := make([]byte, 64*1024)
+		for {
+			n, err := in.Read(buf)
+			if n > 0 {
+				sendErr := stream.Send(&deq.RestoreRequest{
+					Data: buf[:n],
+				})
+				if sendErr == io.EOF {
+					// The server closed the stream, the error is returned by CloseAndRecv.
+					break
+				}
+				if sendErr != nil {
+					fmt.Printf("send backup: %v\n", sendErr)
+					os.Exit(2)
+				}
+			}
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				fmt.Printf("read backup: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		_, err = stream.CloseAndRecv()
+		if err != nil {
+			fmt.Printf("restore: %v\n", err)
+			os.Exit(2)
+		}
+
+		fmt.Println("restored backup")
+
+	case "export":
+		if topic == "" {
+			flag.Usage()
+			os.Exit(1)
+		}
+		exportFormat, err := parseExportFormat(format)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+
+		out := os.Stdout
+		if file != "" {
+			f, err := os.Create(file)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "create export file: %v\n", err)
+				os.Exit(1)
+			}
+			defer f.Close()
+			out = f
+		}
+
+		deqc, err := dial(host, nameOverride, insecure)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "dial: %v\n", err)
+			os.Exit(1)
+		}
+
+		stream, err := deqc.Export(ctx, &deq.ExportRequest{
+			Topic:         topic,
+			Format:        exportFormat,
+			ChannelStates: channelStates,
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "export: %v\n", err)
+			os.Exit(2)
+		}
+
+		for {
+			chunk, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "recieve export: %v\n", err)
+				os.Exit(2)
+			}
+			_, err = out.Write(chunk.Data)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "write export: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+	case "import":
+		exportFormat, err := parseExportFormat(format)
+		if err != nil {
+			fmt.Printf("%v\n", err)
+			os.Exit(1)
+		}
+
+		in := os.Stdin
+		if file != "" {
+			f, err := os.Open(file)
+			if err != nil {
+				fmt.Printf("open export file: %v\n", err)
+				os.Exit(1)
+			}
+			defer f.Close()
+			in = f
+		}
+
+		deqc, err := dial(host, nameOverride, insecure)
+		if err != nil {
+			fmt.Printf("dial: %v\n", err)
+			os.Exit(1)
+		}
+
+		stream, err := deqc.Import(ctx)
+		if err != nil {
+			fmt.Printf("import: %v\n", err)
+			os.Exit(2)
+		}
+
+		buf := make([]byte, 64*1024)
+		for {
+			n, err := in.Read(buf)
+			if n > 0 {
+				sendErr := stream.Send(&deq.ImportRequest{
+					Format: exportFormat,
+					Data:   buf[:n],
+				})
+				if sendErr == io.EOF {
+					// The server closed the stream, the error is returned by CloseAndRecv.
+					break
+				}
+				if sendErr != nil {
+					fmt.Printf("send export: %v\n", sendErr)
+					os.Exit(2)
+				}
+			}
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				fmt.Printf("read export: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		resp, err := stream.CloseAndRecv()
+		if err != nil {
+			fmt.Printf("import: %v\n", err)
+			os.Exit(2)
+		}
+
+		fmt.Printf("imported %d events\n", resp.ImportedCount)
+
+	case "setschema":
+		if topic == "" {
+			flag.Usage()
+			os.Exit(1)
+		}
+
+		in := os.Stdin
+		if file != "" {
+			f, err := os.Open(file)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "open file descriptor set: %v\n", err)
+				os.Exit(1)
+			}
+			defer f.Close()
+			in = f
+		}
+		fileDescriptorSet, err := ioutil.ReadAll(in)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "read file descriptor set: %v\n", err)
+			os.Exit(1)
+		}
+
+		deqc, err := dial(host, nameOverride, insecure)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "dial: %v\n", err)
+			os.Exit(1)
+		}
+
+		schema, err := deqc.RegisterSchema(ctx, &deq.RegisterSchemaRequest{
+			Topic:             topic,
+			MessageType:       messageType,
+			FileDescriptorSet: fileDescriptorSet,
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "register schema: %v\n", err)
+			os.Exit(2)
+		}
+
+		fmt.Printf("registered %s as version %d of the schema of %s\n", schema.MessageType, schema.Version, schema.Topic)
+
+	case "getschema":
+		if topic == "" {
+			flag.Usage()
+			os.Exit(1)
+		}
+
+		deqc, err := dial(host, nameOverride, insecure)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "dial: %v\n", err)
+			os.Exit(1)
+		}
+
+		schema, err := deqc.GetSchema(ctx, &deq.GetSchemaRequest{
+			Topic: topic,
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "get schema: %v\n", err)
+			os.Exit(2)
+		}
+
+		if file != "" {
+			err = ioutil.WriteFile(file, schema.FileDescriptorSet, 0644)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "write file descriptor set: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		fmt.Printf("topic: %s, message type: %s, version: %d\n", schema.Topic, schema.MessageType, schema.Version)
+
+	case "topic":
+		if topic == "" || flag.Arg(1) != "config" {
+			flag.Usage()
+			os.Exit(1)
+		}
+
+		deqc, err := dial(host, nameOverride, insecure)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "dial: %v\n", err)
+			os.Exit(1)
+		}
+
+		config, err := deqc.GetTopicConfig(ctx, &deq.GetTopicConfigRequest{
+			Topic: topic,
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "get topic config: %v\n", err)
+			os.Exit(2)
+		}
+
+		if flag.NArg() > 2 {
+			for _, setting := range flag.Args()[2:] {
+				err := setTopicConfig(config, setting)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "%v\n", err)
+					os.Exit(1)
+				}
+			}
+
+			config, err = deqc.SetTopicConfig(ctx, &deq.SetTopicConfigRequest{
+				Config: config,
+			})
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "set topic config: %v\n", err)
+				os.Exit(2)
+			}
+		}
+
+		fmt.Printf("topic: %s\n", config.Topic)
+		fmt.Printf("requeue-limit: %d\n", config.RequeueLimit)
+		fmt.Printf("backoff: %s\n", config.Backoff)
+		fmt.Printf("requeue-delay: %v\n", time.Duration(config.RequeueDelayMilliseconds)*time.Millisecond)
+		fmt.Printf("default-state: %s\n", config.DefaultState)
+		fmt.Printf("max-age: %v\n", time.Duration(config.RetentionMaxAgeMilliseconds)*time.Millisecond)
+		fmt.Printf("max-count: %d\n", config.RetentionMaxCount)
+		fmt.Printf("dead-letter-topic: %s\n", config.DeadLetterTopic)
+
+	case "redrive":
+		if topic == "" {
+			flag.Usage()
+			os.Exit(1)
+		}
+
+		var beforeTime, afterTime int64
+		if before != "" {
+			t, err := time.Parse(time.RFC3339, before)
+			if err != nil {
+				fmt.Printf("parse -before: %v\n", err)
+				os.Exit(1)
+			}
+			beforeTime = t.UnixNano()
+		}
+		if after != "" {
+			t, err := time.Parse(time.RFC3339, after)
+			if err != nil {
+				fmt.Printf("parse -after: %v\n", err)
+				os.Exit(1)
+			}
+			afterTime = t.UnixNano()
+		}
+
+		deqc, err := dial(host, nameOverride, insecure)
+		if err != nil {
+			fmt.Printf("dial: %v\n", err)
+			os.Exit(1)
+		}
+
+		resp, err := deqc.Redrive(ctx, &deq.RedriveRequest{
+			Topic:         topic,
+			Channel:       channel,
+			MinCreateTime: afterTime,
+			MaxCreateTime: beforeTime,
+			MinId:         minID,
+			MaxId:         maxID,
+			IndexPrefix:   indexPrefix,
+		})
+		if err != nil {
+			fmt.Printf("redrive: %v\n", err)
+			os.Exit(2)
+		}
+
+		fmt.Printf("redrove %d events\n", resp.RedrivenCount)
+
+	case "schedule":
+		deqc, err := dial(host, nameOverride, insecure)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "dial: %v\n", err)
+			os.Exit(1)
+		}
+
+		switch flag.Arg(1) {
+		case "list":
+			resp, err := deqc.ListSchedules(ctx, &deq.ListSchedulesRequest{})
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "list schedules: %v\n", err)
+				os.Exit(2)
+			}
+			for _, schedule := range resp.Schedules {
+				printSchedule(schedule)
+			}
+
+		case "set":
+			if flag.Arg(2) == "" || topic == "" {
+				flag.Usage()
+				os.Exit(1)
+			}
+
+			var payload []byte
+			if file != "" {
+				payload, err = ioutil.ReadFile(file)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "read payload: %v\n", err)
+					os.Exit(1)
+				}
+			}
+
+			schedule, err := deqc.SetSchedule(ctx, &deq.SetScheduleRequest{
+				Schedule: &deq.Schedule{
+					Name:                 flag.Arg(2),
+					Cron:                 cronExpr,
+					IntervalMilliseconds: int64(every / time.Millisecond),
+					Topic:                topic,
+					Payload:              payload,
+				},
+			})
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "set schedule: %v\n", err)
+				os.Exit(2)
+			}
+			printSchedule(schedule)
+
+		case "del":
+			if flag.Arg(2) == "" {
+				flag.Usage()
+				os.Exit(1)
+			}
+
+			_, err := deqc.DelSchedule(ctx, &deq.DelScheduleRequest{
+				Name: flag.Arg(2),
+			})
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "delete schedule: %v\n", err)
+				os.Exit(2)
+			}
+			fmt.Printf("deleted schedule %s\n", flag.Arg(2))
+
+		default:
+			flag.Usage()
+			os.Exit(1)
+		}
+
+	case "reencrypt":
+		keys, err := store.ParseKeyRing(os.Getenv("DEQ_ENCRYPTION_KEY_ID"), os.Getenv("DEQ_ENCRYPTION_KEYS"))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "parse DEQ_ENCRYPTION_KEYS: %v\n", err)
+			os.Exit(1)
+		}
+
+		db, err := store.Open(store.Options{
+			Dir:         dir,
+			KeyProvider: keys,
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "open database: %v\n", err)
+			os.Exit(1)
+		}
+		defer db.Close()
+
+		// Re-encrypting a large database can take much longer than -timeout.
+		count, err := db.Reencrypt(context.Background())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "reencrypt: %v\n", err)
+			os.Exit(2)
+		}
+
+		fmt.Printf("re-encrypted %d events\n", count)
+
 	case "help", "":
 		flag.Usage()
 	default:
@@ -118,6 +678,80 @@ func main() {
 
 }
 
+// printSchedule prints a schedule on a single line.
+func printSchedule(schedule *deq.Schedule) {
+	every := schedule.Cron
+	if every == "" {
+		every = (time.Duration(schedule.IntervalMilliseconds) * time.Millisecond).String()
+	}
+	fmt.Printf("%s: topic: %s, every: %s, last tick: %v\n", schedule.Name, schedule.Topic, every, time.Unix(0, schedule.LastTick).UTC().Format(time.RFC3339))
+}
+
+// setTopicConfig applies a SETTING=VALUE pair to config.
+func setTopicConfig(config *deq.TopicConfig, setting string) error {
+	i := strings.Index(setting, "=")
+	if i < 0 {
+		return fmt.Errorf("%q is not a SETTING=VALUE pair", setting)
+	}
+	name, value := setting[:i], setting[i+1:]
+
+	switch name {
+	case "requeue-limit":
+		limit, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return fmt.Errorf("parse requeue-limit: %v", err)
+		}
+		config.RequeueLimit = int32(limit)
+	case "backoff":
+		backoff, ok := deq.Backoff_value["BACKOFF_"+strings.ToUpper(value)]
+		if !ok {
+			return fmt.Errorf("unrecognized backoff %q", value)
+		}
+		config.Backoff = deq.Backoff(backoff)
+	case "requeue-delay":
+		delay, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("parse requeue-delay: %v", err)
+		}
+		config.RequeueDelayMilliseconds = int32(delay / time.Millisecond)
+	case "default-state":
+		state, ok := deq.EventState_value[strings.ToUpper(strings.Replace(value, "-", "_", -1))]
+		if !ok {
+			return fmt.Errorf("unrecognized default-state %q", value)
+		}
+		config.DefaultState = deq.EventState(state)
+	case "max-age":
+		age, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("parse max-age: %v", err)
+		}
+		config.RetentionMaxAgeMilliseconds = int64(age / time.Millisecond)
+	case "max-count":
+		count, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("parse max-count: %v", err)
+		}
+		config.RetentionMaxCount = count
+	case "dead-letter-topic":
+		config.DeadLetterTopic = value
+	default:
+		return fmt.Errorf("unrecognized setting %q", name)
+	}
+
+	return nil
+}
+
+func parseExportFormat(format string) (deq.ExportFormat, error) {
+	switch format {
+	case "proto":
+		return deq.ExportFormat_PROTO, nil
+	case "json":
+		return deq.ExportFormat_JSON_LINES, nil
+	default:
+		return 0, fmt.Errorf("unrecognized -format %q, expected proto or json", format)
+	}
+}
+
 func dial(host, nameOverride string, insecure bool) (deq.DEQClient, error) {
 	var opts []grpc.DialOption
 	if insecure {
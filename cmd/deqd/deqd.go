@@ -17,6 +17,7 @@ import (
 	"gitlab.com/katcheCode/deq"
 	pb "gitlab.com/katcheCode/deq/api/v1/deq"
 	eventserver "gitlab.com/katcheCode/deq/internal/handlers"
+	"gitlab.com/katcheCode/deq/internal/metrics"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 )
@@ -34,15 +35,31 @@ var (
 	// dataDir is the database directory
 	dataDir = os.Getenv("DEQ_DATA_DIR")
 
-	// statsAddress is the address that deq publishes stats on
+	// statsAddress is the address that deq publishes stats on, including pprof profiles and Prometheus
+	// metrics at /metrics
 	statsAddress = os.Getenv("DEQ_STATS_ADDRESS")
 
 	// deleteCorrupt specifies if we should allow the database to delete corrupt data.
 	deleteCorrupt = strings.ToLower(os.Getenv("DEQ_DANGEROUS_DELETE_CORRUPT")) == "true"
 
+	// noSync specifies if writes should be acknowledged before they are synced to disk.
+	noSync = strings.ToLower(os.Getenv("DEQ_DANGEROUS_NO_SYNC")) == "true"
+
+	// writeWindow is how long to wait for concurrent writes to group into a single transaction.
+	writeWindow time.Duration
+
 	// requeueLimit specifies the default maximum requeues of a single event.
 	requeueLimit = 40
 
+	// defaultCompression is the compression of payloads on topics that aren't in compression.
+	defaultCompression deq.Compression
+
+	// compression maps topics to the compression of their payloads.
+	compression map[string]deq.Compression
+
+	// keys encrypt payloads at rest, if DEQ_ENCRYPTION_KEYS is set.
+	keys deq.KeyProvider
+
 	// listenInsecure sets the server to listen for HTTP2 requests with TLS disabled.
 	insecure = strings.ToLower(os.Getenv("DEQ_LISTEN_INSECURE")) == "true"
 
@@ -65,7 +82,49 @@ func main() {
 		var err error
 		requeueLimit, err = strconv.Atoi(limit)
 		if err != nil {
-			log.Fatalf("parse DEQ_REQUEUE_LIMIT from environment: %v", err)
+			log.Fatalf("parse DEQ_DEFAULT_REQUEUE_LIMIT from environment: %v", err)
+		}
+	}
+
+	if window, ok := os.LookupEnv("DEQ_WRITE_WINDOW"); ok {
+		var err error
+		writeWindow, err = time.ParseDuration(window)
+		if err != nil {
+			log.Fatalf("parse DEQ_WRITE_WINDOW from environment: %v", err)
+		}
+	}
+
+	if c, ok := os.LookupEnv("DEQ_DEFAULT_COMPRESSION"); ok {
+		var err error
+		defaultCompression, err = parseCompression(c)
+		if err != nil {
+			log.Fatalf("parse DEQ_DEFAULT_COMPRESSION from environment: %v", err)
+		}
+	}
+
+	// DEQ_COMPRESSION is a comma separated list of topic=compression pairs.
+	if pairs, ok := os.LookupEnv("DEQ_COMPRESSION"); ok && pairs != "" {
+		compression = make(map[string]deq.Compression)
+		for _, pair := range strings.Split(pairs, ",") {
+			i := strings.LastIndex(pair, "=")
+			if i < 0 {
+				log.Fatalf("parse DEQ_COMPRESSION from environment: %q is not a topic=compression pair", pair)
+			}
+			c, err := parseCompression(pair[i+1:])
+			if err != nil {
+				log.Fatalf("parse DEQ_COMPRESSION from environment: %v", err)
+			}
+			compression[strings.TrimSpace(pair[:i])] = c
+		}
+	}
+
+	// DEQ_ENCRYPTION_KEYS is a comma separated list of id=key pairs, where each key is base64
+	// encoded. DEQ_ENCRYPTION_KEY_ID is the ID of the key new payloads are encrypted with.
+	if encryptionKeys := os.Getenv("DEQ_ENCRYPTION_KEYS"); encryptionKeys != "" {
+		var err error
+		keys, err = deq.ParseKeyRing(os.Getenv("DEQ_ENCRYPTION_KEY_ID"), encryptionKeys)
+		if err != nil {
+			log.Fatalf("parse DEQ_ENCRYPTION_KEYS from environment: %v", err)
 		}
 	}
 
@@ -89,7 +148,13 @@ func run(dbDir, address, statsAddress, certFile, keyFile string, insecure bool)
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	var serverMetrics *eventserver.Metrics
+
 	if statsAddress != "" {
+		registry := metrics.NewRegistry()
+		serverMetrics = eventserver.NewMetrics(registry)
+		http.Handle("/metrics", registry)
+
 		statsServer := http.Server{
 			Addr:    statsAddress,
 			Handler: http.DefaultServeMux,
@@ -123,16 +188,28 @@ func run(dbDir, address, statsAddress, certFile, keyFile string, insecure bool)
 		DangerousDeleteCorrupt: deleteCorrupt,
 		DefaultRequeueLimit:    requeueLimit,
 		UpgradeIfNeeded:        true,
+		WriteWindow:            writeWindow,
+		DangerousNoSync:        noSync,
+		Compression:            compression,
+		DefaultCompression:     defaultCompression,
+		KeyProvider:            keys,
 	})
 	if err != nil {
 		return fmt.Errorf("open database: %v", err)
 	}
 	defer store.Close()
 
-	server := eventserver.NewServer(store)
+	server := eventserver.NewServer(store, serverMetrics)
 
 	var opts []grpc.ServerOption
 
+	if serverMetrics != nil {
+		opts = append(opts,
+			grpc.UnaryInterceptor(serverMetrics.UnaryServerInterceptor),
+			grpc.StreamInterceptor(serverMetrics.StreamServerInterceptor),
+		)
+	}
+
 	if !insecure {
 		creds, err := credentials.NewServerTLSFromFile(certFile, keyFile)
 		if err != nil {
@@ -173,3 +250,15 @@ func run(dbDir, address, statsAddress, certFile, keyFile string, insecure bool)
 
 	return nil
 }
+
+// parseCompression parses the name of a deq.Compression.
+func parseCompression(name string) (deq.Compression, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "none":
+		return deq.CompressionNone, nil
+	case "gzip":
+		return deq.CompressionGzip, nil
+	default:
+		return 0, fmt.Errorf("unrecognized compression %q", name)
+	}
+}
@@ -0,0 +1,103 @@
+package deq
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io/ioutil"
+
+	"gitlab.com/katcheCode/deq/internal/data"
+)
+
+// Compression is a codec used to compress the payloads of events when they are written to disk.
+//
+// The codec of each event is stored alongside its payload, so events written with different codecs
+// can coexist in a topic, and changing the Compression of a topic only affects new events. Payloads
+// are decompressed transparently when they are read.
+type Compression int
+
+const (
+	// CompressionNone stores payloads uncompressed.
+	CompressionNone Compression = iota
+	// CompressionGzip compresses payloads with gzip.
+	CompressionGzip
+)
+
+func (c Compression) String() string {
+	switch c {
+	case CompressionNone:
+		return "None"
+	case CompressionGzip:
+		return "Gzip"
+	default:
+		return fmt.Sprintf("Compression(%d)", int(c))
+	}
+}
+
+// compression returns the Compression of a topic.
+func (s *Store) compression(topic string) Compression {
+	c, ok := s.compressionByTopic[topic]
+	if !ok {
+		return s.defaultCompression
+	}
+	return c
+}
+
+// compressPayload compresses the payload of e with c. If compressing doesn't make the payload
+// smaller, it is left uncompressed.
+func compressPayload(e *data.EventPayload, c Compression) error {
+	if c == CompressionNone || len(e.Payload) == 0 {
+		e.Codec = data.Codec_CODEC_NONE
+		return nil
+	}
+
+	var buf bytes.Buffer
+	var codec data.Codec
+	switch c {
+	case CompressionGzip:
+		codec = data.Codec_CODEC_GZIP
+		w := gzip.NewWriter(&buf)
+		_, err := w.Write(e.Payload)
+		if err != nil {
+			return fmt.Errorf("gzip payload: %v", err)
+		}
+		err = w.Close()
+		if err != nil {
+			return fmt.Errorf("gzip payload: %v", err)
+		}
+	default:
+		return fmt.Errorf("unrecognized compression %v", c)
+	}
+
+	if buf.Len() >= len(e.Payload) {
+		e.Codec = data.Codec_CODEC_NONE
+		return nil
+	}
+
+	e.Payload = buf.Bytes()
+	e.Codec = codec
+	return nil
+}
+
+// decompressPayload decompresses the payload of e according to its codec.
+func decompressPayload(e *data.EventPayload) error {
+	switch e.Codec {
+	case data.Codec_CODEC_NONE:
+		return nil
+	case data.Codec_CODEC_GZIP:
+		r, err := gzip.NewReader(bytes.NewReader(e.Payload))
+		if err != nil {
+			return fmt.Errorf("gunzip payload: %v", err)
+		}
+		payload, err := ioutil.ReadAll(r)
+		if err != nil {
+			return fmt.Errorf("gunzip payload: %v", err)
+		}
+		e.Payload = payload
+	default:
+		return fmt.Errorf("unrecognized codec %v", e.Codec)
+	}
+
+	e.Codec = data.Codec_CODEC_NONE
+	return nil
+}
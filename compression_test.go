@@ -0,0 +1,139 @@
+package deq
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"gitlab.com/katcheCode/deq/internal/data"
+)
+
+func TestCompression(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	db, err := Open(Options{
+		InMemory: true,
+		Compression: map[string]Compression{
+			"TopicB": CompressionNone,
+		},
+		DefaultCompression: CompressionGzip,
+	})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer db.Close()
+
+	compressible := bytes.Repeat([]byte(`{"key":"value"}`), 100)
+
+	for _, e := range []Event{
+		{ID: "event1", Topic: "TopicA", Payload: compressible, Indexes: []string{"index1"}},
+		// Too small to benefit from compression.
+		{ID: "event2", Topic: "TopicA", Payload: []byte("a"), Indexes: []string{"index2"}},
+		{ID: "event1", Topic: "TopicB", Payload: compressible},
+	} {
+		_, err := db.Pub(ctx, e)
+		if err != nil {
+			t.Fatalf("pub %s/%s: %v", e.Topic, e.ID, err)
+		}
+	}
+
+	// Check how the payloads are stored.
+	txn := db.db.NewTransaction(false)
+	defer txn.Discard()
+	for _, expected := range []struct {
+		topic, id string
+		codec     data.Codec
+	}{
+		{"TopicA", "event1", data.Codec_CODEC_GZIP},
+		{"TopicA", "event2", data.Codec_CODEC_NONE},
+		{"TopicB", "event1", data.Codec_CODEC_NONE},
+	} {
+		eventTime, err := getEventTimePayload(txn, data.EventTimeKey{Topic: expected.topic, ID: expected.id})
+		if err != nil {
+			t.Fatalf("get event time of %s/%s: %v", expected.topic, expected.id, err)
+		}
+		key, err := data.EventKey{
+			Topic:      expected.topic,
+			ID:         expected.id,
+			CreateTime: time.Unix(0, eventTime.CreateTime),
+		}.Marshal(nil)
+		if err != nil {
+			t.Fatalf("marshal key: %v", err)
+		}
+		item, err := txn.Get(key)
+		if err != nil {
+			t.Fatalf("get %s/%s: %v", expected.topic, expected.id, err)
+		}
+		val, err := item.Value()
+		if err != nil {
+			t.Fatalf("get value of %s/%s: %v", expected.topic, expected.id, err)
+		}
+		var payload data.EventPayload
+		err = payload.Unmarshal(val)
+		if err != nil {
+			t.Fatalf("unmarshal %s/%s: %v", expected.topic, expected.id, err)
+		}
+		if payload.Codec != expected.codec {
+			t.Errorf("codec of %s/%s: expected %v, got %v", expected.topic, expected.id, expected.codec, payload.Codec)
+		}
+		if payload.Codec == data.Codec_CODEC_GZIP && len(payload.Payload) >= len(compressible) {
+			t.Errorf("payload of %s/%s is %d bytes compressed", expected.topic, expected.id, len(payload.Payload))
+		}
+	}
+
+	// Check that every way of reading the events decompresses them.
+	channel := db.Channel("channel", "TopicA")
+	defer channel.Close()
+
+	e, err := channel.Get("event1")
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if !cmp.Equal(compressible, e.Payload) {
+		t.Errorf("get: payload:\n%s", cmp.Diff(compressible, e.Payload))
+	}
+
+	var iterated [][]byte
+	iter := channel.NewEventIter(IterOpts{})
+	for iter.Next() {
+		iterated = append(iterated, iter.Event().Payload)
+	}
+	if iter.Err() != nil {
+		t.Fatalf("iterate events: %v", iter.Err())
+	}
+	iter.Close()
+
+	indexIter := channel.NewIndexIter(IterOpts{})
+	for indexIter.Next() {
+		iterated = append(iterated, indexIter.Event().Payload)
+	}
+	if indexIter.Err() != nil {
+		t.Fatalf("iterate indexes: %v", indexIter.Err())
+	}
+	indexIter.Close()
+
+	expected := [][]byte{compressible, []byte("a"), compressible, []byte("a")}
+	if !cmp.Equal(expected, iterated) {
+		t.Errorf("iterate: payloads:\n%s", cmp.Diff(expected, iterated))
+	}
+
+	e, err = channel.Next(ctx)
+	if err != nil {
+		t.Fatalf("next: %v", err)
+	}
+	if !cmp.Equal(compressible, e.Payload) {
+		t.Errorf("next: payload:\n%s", cmp.Diff(compressible, e.Payload))
+	}
+
+	stats, err := db.Stats()
+	if err != nil {
+		t.Fatalf("stats: %v", err)
+	}
+	if stats.Topics["TopicA"].PayloadBytes != int64(len(compressible)+1) {
+		t.Errorf("stats: expected uncompressed payload bytes %d, got %d", len(compressible)+1, stats.Topics["TopicA"].PayloadBytes)
+	}
+}
@@ -6,16 +6,16 @@ import (
 	"log"
 	"time"
 
-	"github.com/dgraph-io/badger"
 	"github.com/gogo/protobuf/proto"
 	"gitlab.com/katcheCode/deq/internal/data"
+	"gitlab.com/katcheCode/deq/internal/storage"
 )
 
 var defaultChannelState = data.ChannelPayload{
 	EventState: data.EventState_QUEUED,
 }
 
-func getEvent(txn *badger.Txn, topic, eventID, channel string) (*Event, error) {
+func getEvent(txn storage.Txn, keys KeyProvider, topic, eventID, channel string) (*Event, error) {
 	eventTime, err := getEventTimePayload(txn, data.EventTimeKey{
 		ID:    eventID,
 		Topic: topic,
@@ -27,7 +27,7 @@ func getEvent(txn *badger.Txn, topic, eventID, channel string) (*Event, error) {
 		return nil, fmt.Errorf("get event time: %v", err)
 	}
 
-	event, err := getEventPayload(txn, data.EventKey{
+	event, err := getEventPayload(txn, keys, data.EventKey{
 		ID:         eventID,
 		Topic:      topic,
 		CreateTime: time.Unix(0, eventTime.CreateTime),
@@ -58,18 +58,18 @@ func getEvent(txn *badger.Txn, topic, eventID, channel string) (*Event, error) {
 		State:        protoToEventState(channelState.EventState),
 		DefaultState: protoToEventState(event.DefaultEventState),
 		Indexes:      event.Indexes,
+		Metadata:     event.Metadata,
+		RequeueLimit: int(event.RequeueLimit),
+		DeliverAt:    data.FromUnixNano(event.DeliverAt),
+		Priority:     int(event.Priority),
 	}, nil
 }
 
-func printKeys(txn *badger.Txn) {
-	it := txn.NewIterator(badger.DefaultIteratorOptions)
+func printKeys(txn storage.Txn) {
+	it := txn.NewIterator(storage.DefaultIteratorOptions)
 	defer it.Close()
 	for it.Rewind(); it.Valid(); it.Next() {
 		item := it.Item()
-		if item.IsDeletedOrExpired() {
-			continue
-		}
-
 		key, err := data.Unmarshal(item.Key())
 		if err != nil {
 			log.Printf("%v %v", item.Key(), err)
@@ -79,7 +79,9 @@ func printKeys(txn *badger.Txn) {
 	}
 }
 
-func writeEvent(txn *badger.Txn, e *Event) error {
+// writeEvent writes e to txn. Its payload is compressed with compression, then encrypted with the
+// current key of keys if keys is non-nil.
+func writeEvent(txn storage.Txn, e *Event, compression Compression, keys KeyProvider) error {
 	key, err := data.EventTimeKey{
 		Topic: e.Topic,
 		ID:    e.ID,
@@ -92,7 +94,7 @@ func writeEvent(txn *badger.Txn, e *Event) error {
 	if err == nil {
 		return ErrAlreadyExists
 	}
-	if err != badger.ErrKeyNotFound {
+	if err != storage.ErrKeyNotFound {
 		return fmt.Errorf("check event doesn't exist: %v", err)
 	}
 
@@ -108,22 +110,27 @@ func writeEvent(txn *badger.Txn, e *Event) error {
 		return err
 	}
 
-	key, err = data.EventKey{
+	eventKey := data.EventKey{
 		Topic:      e.Topic,
 		CreateTime: e.CreateTime,
 		ID:         e.ID,
-	}.Marshal(nil)
+	}
+	key, err = eventKey.Marshal(nil)
 	if err != nil {
 		return fmt.Errorf("marshal event key: %v", err)
 	}
 
-	val, err = proto.Marshal(&data.EventPayload{
+	val, err = marshalEventPayload(&data.EventPayload{
 		Payload:           e.Payload,
 		DefaultEventState: e.DefaultState.toProto(),
 		Indexes:           e.Indexes,
-	})
+		Metadata:          e.Metadata,
+		RequeueLimit:      int32(e.RequeueLimit),
+		DeliverAt:         data.UnixNano(e.DeliverAt),
+		Priority:          int32(e.Priority),
+	}, key, compression, keys)
 	if err != nil {
-		return fmt.Errorf("marshal event time payload: %v", err)
+		return err
 	}
 
 	err = txn.Set(key, val)
@@ -131,6 +138,29 @@ func writeEvent(txn *badger.Txn, e *Event) error {
 		return err
 	}
 
+	err = addTopicEventStats(txn, eventKey, key, int64(len(e.Payload)))
+	if err != nil {
+		return fmt.Errorf("update topic stats: %v", err)
+	}
+
+	// Events with the default priority are delivered in EventKey order, so only the others are
+	// indexed by priority.
+	if e.Priority != 0 {
+		key, err = data.PriorityKey{
+			Topic:      e.Topic,
+			Priority:   int32(e.Priority),
+			CreateTime: e.CreateTime,
+			ID:         e.ID,
+		}.Marshal(nil)
+		if err != nil {
+			return fmt.Errorf("marshal priority key: %v", err)
+		}
+		err = txn.Set(key, nil)
+		if err != nil {
+			return err
+		}
+	}
+
 	for _, index := range e.Indexes {
 		indexKey := data.IndexKey{
 			Topic: e.Topic,
@@ -141,7 +171,7 @@ func writeEvent(txn *badger.Txn, e *Event) error {
 		// id hash is greater.
 		var existing data.IndexPayload
 		err := getIndexPayload(txn, indexKey, &existing)
-		if err != nil && err != badger.ErrKeyNotFound {
+		if err != nil && err != storage.ErrKeyNotFound {
 			return fmt.Errorf("lookup existing index: %v", err)
 		}
 		if err == nil && !shouldUpdateIndex(&existing, e) {
@@ -158,46 +188,292 @@ func writeEvent(txn *badger.Txn, e *Event) error {
 	}
 
 	if e.DefaultState != EventStateUnspecified && e.DefaultState != EventStateQueued {
+		// setChannelEvent may iterate txn, so the channels are listed before any state is saved.
+		channels, err := getChannelNames(txn)
+		if err != nil {
+			return fmt.Errorf("list channels: %v", err)
+		}
+
+		for _, channel := range channels {
+			err = setChannelEvent(txn, data.ChannelKey{
+				Topic:   e.Topic,
+				Channel: channel,
+				ID:      e.ID,
+			}, data.ChannelPayload{
+				EventState:   e.DefaultState.toProto(),
+				RequeueCount: int32(e.RequeueCount),
+			})
+			if err != nil {
+				return fmt.Errorf("set event state on channel %s: %v", channel, err)
+			}
+		}
+	}
 
-		it := txn.NewIterator(badger.DefaultIteratorOptions)
-		defer it.Close()
+	return nil
+}
+
+// deleteEvent deletes an event along with its event time key and its state on every channel. Each
+// index that points to the event is updated to point to the next most recent event with the same
+// index value, or deleted if there are none.
+func deleteEvent(txn storage.Txn, key data.EventKey, payload *data.EventPayload) error {
+	eventTimeKey, err := data.EventTimeKey{
+		ID:    key.ID,
+		Topic: key.Topic,
+	}.Marshal(nil)
+	if err != nil {
+		return fmt.Errorf("marshal event time key: %v", err)
+	}
+	eventKey, err := key.Marshal(nil)
+	if err != nil {
+		return fmt.Errorf("marshal event key: %v", err)
+	}
 
-		prefix := []byte{data.ChannelTag, data.Sep}
-		cursor := prefix
+	err = txn.Delete(eventTimeKey)
+	if err != nil {
+		return fmt.Errorf("delete event time: %v", err)
+	}
+	err = txn.Delete(eventKey)
+	if err != nil {
+		return fmt.Errorf("delete event key: %v", err)
+	}
+	if payload.Priority != 0 {
+		priorityKey, err := data.PriorityKey{
+			Topic:      key.Topic,
+			Priority:   payload.Priority,
+			CreateTime: key.CreateTime,
+			ID:         key.ID,
+		}.Marshal(nil)
+		if err != nil {
+			return fmt.Errorf("marshal priority key: %v", err)
+		}
+		err = txn.Delete(priorityKey)
+		if err != nil {
+			return fmt.Errorf("delete priority key: %v", err)
+		}
+	}
 
-		for it.Seek(cursor); it.ValidForPrefix(prefix); it.Seek(cursor) {
+	size, err := payloadSize(payload)
+	if err != nil {
+		return fmt.Errorf("get payload size: %v", err)
+	}
+	err = updateTopicStats(txn, key.Topic, -1, -size)
+	if err != nil {
+		return fmt.Errorf("update topic stats: %v", err)
+	}
 
-			var key data.ChannelKey
-			err := data.UnmarshalChannelKey(it.Item().Key(), &key)
-			if err != nil {
-				return fmt.Errorf("unmarshal channel key: %v", err)
-			}
+	for _, index := range payload.Indexes {
+		indexKey := data.IndexKey{
+			Topic: key.Topic,
+			Value: index,
+		}
+
+		// Only update indexes that still point to this event.
+		var existing data.IndexPayload
+		err := getIndexPayload(txn, indexKey, &existing)
+		if err == storage.ErrKeyNotFound {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("lookup index %q: %v", index, err)
+		}
+		if existing.EventId != key.ID {
+			continue
+		}
 
-			// Skip to next channel
-			cursor, err = data.ChannelPrefix(key.Channel + "\u0001")
+		next, err := nextIndexOwner(txn, key, index)
+		if err != nil {
+			return fmt.Errorf("find next event for index %q: %v", index, err)
+		}
+		if next != nil {
+			err = writeIndex(txn, indexKey, next)
 			if err != nil {
-				return fmt.Errorf("marshal channel prefix: %v", err)
+				return fmt.Errorf("update index %q: %v", index, err)
 			}
+			continue
+		}
 
-			newKey := data.ChannelKey{
-				Topic:   e.Topic,
-				Channel: key.Channel,
-				ID:      e.ID,
-			}
+		rawkey, err := indexKey.Marshal(nil)
+		if err != nil {
+			return fmt.Errorf("marshal index key: %v", err)
+		}
+		err = txn.Delete(rawkey)
+		if err != nil {
+			return fmt.Errorf("delete index %q: %v", index, err)
+		}
+	}
 
-			err = setChannelEvent(txn, newKey, data.ChannelPayload{
-				EventState:   e.DefaultState.toProto(),
-				RequeueCount: int32(e.RequeueCount),
-			})
-			if err != nil {
-				return fmt.Errorf("set event state on channel %s: %v", key.Channel, err)
+	err = deleteChannelEvents(txn, key.Topic, key.ID)
+	if err != nil {
+		return fmt.Errorf("delete channel events: %v", err)
+	}
+
+	return nil
+}
+
+// nextIndexOwner returns the payload for an index that points to the event that should own the
+// index after the event for deleted is removed, or nil if no other event has the index.
+//
+// Because an index points to the event with the latest create time, only events created at or
+// before deleted need to be checked. The event for deleted must already be deleted in txn.
+func nextIndexOwner(txn storage.Txn, deleted data.EventKey, index string) (*data.IndexPayload, error) {
+
+	prefix, err := data.EventPrefixTopic(deleted.Topic)
+	if err != nil {
+		return nil, err
+	}
+	// Start after every event with the same create time, since they may sort after deleted.
+	start, err := data.EventKey{
+		Topic:      deleted.Topic,
+		CreateTime: deleted.CreateTime,
+		ID:         data.LastEventID,
+	}.Marshal(nil)
+	if err != nil {
+		return nil, fmt.Errorf("marshal start key: %v", err)
+	}
+
+	it := txn.NewIterator(storage.IteratorOptions{
+		Reverse:        true,
+		PrefetchValues: true,
+		PrefetchSize:   20,
+	})
+	defer it.Close()
+
+	var next *data.IndexPayload
+
+	for it.Seek(start); it.ValidForPrefix(prefix); it.Next() {
+		item := it.Item()
+
+		var key data.EventKey
+		err := data.UnmarshalTo(item.Key(), &key)
+		if err != nil {
+			return nil, fmt.Errorf("parse event key %s: %v", item.Key(), err)
+		}
+
+		// Events are sorted by create time, so once we've found an event any earlier events can't
+		// replace it.
+		if next != nil && key.CreateTime.UnixNano() < next.CreateTime {
+			break
+		}
+
+		val, err := item.Value()
+		if err != nil {
+			return nil, err
+		}
+		var payload data.EventPayload
+		err = proto.Unmarshal(val, &payload)
+		if err != nil {
+			return nil, fmt.Errorf("unmarshal event payload: %v", err)
+		}
+
+		if !containsString(payload.Indexes, index) {
+			continue
+		}
+
+		candidate := &Event{
+			ID:         key.ID,
+			CreateTime: key.CreateTime,
+		}
+		if next == nil || shouldUpdateIndex(next, candidate) {
+			next = &data.IndexPayload{
+				EventId:    key.ID,
+				CreateTime: key.CreateTime.UnixNano(),
 			}
 		}
 	}
 
+	return next, nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// deleteChannelEvents deletes the state of an event on every channel it has been saved to.
+func deleteChannelEvents(txn storage.Txn, topic, id string) error {
+
+	channels, err := getChannelNames(txn)
+	if err != nil {
+		return err
+	}
+
+	for _, channel := range channels {
+		err := deleteChannelEvent(txn, data.ChannelKey{
+			Channel: channel,
+			Topic:   topic,
+			ID:      id,
+		})
+		if err != nil {
+			return fmt.Errorf("delete event state on channel %s: %v", channel, err)
+		}
+	}
+
+	return nil
+}
+
+// deleteChannelEvent deletes the saved state of an event on a channel, if there is any.
+func deleteChannelEvent(txn storage.Txn, key data.ChannelKey) error {
+
+	existing, err := getSavedChannelEvent(txn, key)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return nil
+	}
+
+	rawkey, err := key.Marshal(nil)
+	if err != nil {
+		return fmt.Errorf("marshal channel key: %v", err)
+	}
+	err = txn.Delete(rawkey)
+	if err != nil {
+		return err
+	}
+
+	err = updateChannelStats(txn, key, existing, nil)
+	if err != nil {
+		return fmt.Errorf("update channel stats: %v", err)
+	}
+
 	return nil
 }
 
+// getChannelNames returns the name of every channel that has saved the state of at least one event.
+func getChannelNames(txn storage.Txn) ([]string, error) {
+
+	it := txn.NewIterator(storage.IteratorOptions{})
+	defer it.Close()
+
+	var channels []string
+
+	prefix := []byte{data.ChannelTag, data.Sep}
+	cursor := prefix
+
+	for it.Seek(cursor); it.ValidForPrefix(prefix); it.Seek(cursor) {
+
+		var key data.ChannelKey
+		err := data.UnmarshalChannelKey(it.Item().Key(), &key)
+		if err != nil {
+			return nil, fmt.Errorf("unmarshal channel key: %v", err)
+		}
+
+		channels = append(channels, key.Channel)
+
+		// Skip to next channel
+		cursor, err = data.ChannelPrefix(key.Channel + "\u0001")
+		if err != nil {
+			return nil, fmt.Errorf("marshal channel prefix: %v", err)
+		}
+	}
+
+	return channels, nil
+}
+
 func shouldUpdateIndex(existing *data.IndexPayload, candidate *Event) bool {
 	createTime := candidate.CreateTime.UnixNano()
 
@@ -217,7 +493,7 @@ func shouldUpdateIndex(existing *data.IndexPayload, candidate *Event) bool {
 	return existing.EventId < candidate.ID
 }
 
-func getIndexPayload(txn *badger.Txn, key data.IndexKey, dst *data.IndexPayload) error {
+func getIndexPayload(txn storage.Txn, key data.IndexKey, dst *data.IndexPayload) error {
 	rawkey, err := key.Marshal(nil)
 	if err != nil {
 		return fmt.Errorf("marshal index: %v", err)
@@ -237,7 +513,7 @@ func getIndexPayload(txn *badger.Txn, key data.IndexKey, dst *data.IndexPayload)
 	return nil
 }
 
-func writeIndex(txn *badger.Txn, key data.IndexKey, payload *data.IndexPayload) error {
+func writeIndex(txn storage.Txn, key data.IndexKey, payload *data.IndexPayload) error {
 	rawkey, err := key.Marshal(nil)
 	if err != nil {
 		return fmt.Errorf("marshal index: %v", err)
@@ -254,7 +530,15 @@ func writeIndex(txn *badger.Txn, key data.IndexKey, payload *data.IndexPayload)
 	return nil
 }
 
-func setChannelEvent(txn *badger.Txn, key data.ChannelKey, payload data.ChannelPayload) error {
+// setChannelEvent saves the state of an event on a channel and updates the channel's stats. If the
+// event is dequeued, the channel's next queued event is found by iterating txn, so no other
+// iterator may be open on txn.
+func setChannelEvent(txn storage.Txn, key data.ChannelKey, payload data.ChannelPayload) error {
+
+	existing, err := getSavedChannelEvent(txn, key)
+	if err != nil {
+		return err
+	}
 
 	rawkey, err := key.Marshal(nil)
 	if err != nil {
@@ -270,16 +554,53 @@ func setChannelEvent(txn *badger.Txn, key data.ChannelKey, payload data.ChannelP
 		return err
 	}
 
+	if existing != nil && proto.Equal(existing, &payload) {
+		return nil
+	}
+	err = updateChannelStats(txn, key, existing, &payload)
+	if err != nil {
+		return fmt.Errorf("update channel stats: %v", err)
+	}
+
 	return nil
 }
 
-func getEventTimePayload(txn *badger.Txn, key data.EventTimeKey) (payload data.EventTimePayload, err error) {
+// getSavedChannelEvent returns the saved state of an event on a channel, or nil if the event has no
+// saved state.
+func getSavedChannelEvent(txn storage.Txn, key data.ChannelKey) (*data.ChannelPayload, error) {
+
+	rawkey, err := key.Marshal(nil)
+	if err != nil {
+		return nil, fmt.Errorf("marshal channel key: %v", err)
+	}
+
+	item, err := txn.Get(rawkey)
+	if err == storage.ErrKeyNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	val, err := item.Value()
+	if err != nil {
+		return nil, err
+	}
+	var payload data.ChannelPayload
+	err = proto.Unmarshal(val, &payload)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal channel payload: %v", err)
+	}
+
+	return &payload, nil
+}
+
+func getEventTimePayload(txn storage.Txn, key data.EventTimeKey) (payload data.EventTimePayload, err error) {
 	rawKey, err := key.Marshal(nil)
 	if err != nil {
 		return payload, fmt.Errorf("marshal event time key: %v", err)
 	}
 	item, err := txn.Get(rawKey)
-	if err == badger.ErrKeyNotFound {
+	if err == storage.ErrKeyNotFound {
 		return payload, ErrNotFound
 	}
 	if err != nil {
@@ -298,7 +619,31 @@ func getEventTimePayload(txn *badger.Txn, key data.EventTimeKey) (payload data.E
 	return payload, nil
 }
 
-func getEventPayload(txn *badger.Txn, key data.EventKey) (payload data.EventPayload, err error) {
+func getEventPayload(txn storage.Txn, keys KeyProvider, key data.EventKey) (payload data.EventPayload, err error) {
+	rawKey, err := key.Marshal(nil)
+	if err != nil {
+		return payload, fmt.Errorf("marshal event key: %v", err)
+	}
+	item, err := txn.Get(rawKey)
+	if err != nil {
+		return payload, err
+	}
+	val, err := item.Value()
+	if err != nil {
+		return payload, err
+	}
+
+	err = unmarshalEventPayload(val, rawKey, keys, &payload)
+	if err != nil {
+		return data.EventPayload{}, err
+	}
+
+	return payload, nil
+}
+
+// getStoredEventPayload gets the payload of an event as it is stored, without decrypting or
+// decompressing it.
+func getStoredEventPayload(txn storage.Txn, key data.EventKey) (payload data.EventPayload, err error) {
 	rawKey, err := key.Marshal(nil)
 	if err != nil {
 		return payload, fmt.Errorf("marshal event key: %v", err)
@@ -324,7 +669,7 @@ var defaultChannelPayload = data.ChannelPayload{
 	EventState: EventStateQueued.toProto(),
 }
 
-func getChannelEvent(txn *badger.Txn, key data.ChannelKey) (data.ChannelPayload, error) {
+func getChannelEvent(txn storage.Txn, key data.ChannelKey) (data.ChannelPayload, error) {
 
 	rawKey, err := key.Marshal(nil)
 	if err != nil {
@@ -332,7 +677,7 @@ func getChannelEvent(txn *badger.Txn, key data.ChannelKey) (data.ChannelPayload,
 	}
 
 	item, err := txn.Get(rawKey)
-	if err == badger.ErrKeyNotFound {
+	if err == storage.ErrKeyNotFound {
 		return defaultChannelPayload, nil
 	}
 	if err != nil {
@@ -382,8 +727,19 @@ func protoToEventState(e data.EventState) EventState {
 	}
 }
 
-// TODO: just use requeue limit on event itself once implemented?
-func incrementSavedRequeueCount(txn *badger.Txn, channel, topic string, defaultRequeueLimit int, e *Event) (*data.ChannelPayload, error) {
+// incrementSavedRequeueCount increments the requeue count of e on channel, or dequeues e with
+// EventStateDequeuedError if its requeue count has reached requeueLimit. A negative requeueLimit
+// means there is no limit. It returns ErrNotFound if e has been deleted, so deleted events don't get
+// channel state again.
+func incrementSavedRequeueCount(txn storage.Txn, channel, topic string, requeueLimit int, e *Event) (*data.ChannelPayload, error) {
+
+	_, err := getEventTimePayload(txn, data.EventTimeKey{
+		Topic: topic,
+		ID:    e.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
 
 	key := data.ChannelKey{
 		Channel: channel,
@@ -396,7 +752,7 @@ func incrementSavedRequeueCount(txn *badger.Txn, channel, topic string, defaultR
 		return nil, err
 	}
 
-	if defaultRequeueLimit == -1 || int(channelEvent.RequeueCount) < 40 {
+	if requeueLimit < 0 || int(channelEvent.RequeueCount) < requeueLimit {
 		channelEvent.RequeueCount++
 	} else {
 		channelEvent.EventState = data.EventState_DEQUEUED_ERROR
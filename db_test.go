@@ -9,36 +9,25 @@ import (
 	"github.com/dgraph-io/badger"
 	"github.com/google/go-cmp/cmp"
 	"gitlab.com/katcheCode/deq/internal/data"
+	"gitlab.com/katcheCode/deq/internal/storage"
 )
 
 func TestWriteEvent(t *testing.T) {
 
-	dir, err := ioutil.TempDir("", "test-write-event")
-	if err != nil {
-		t.Fatalf("create temp dir: %v", err)
-	}
-	defer os.RemoveAll(dir)
-
-	opts := badger.DefaultOptions
-	opts.Dir = dir
-	opts.ValueDir = dir
-	db, err := badger.Open(opts)
+	db := storage.NewMemory()
 	defer db.Close()
-	if err != nil {
-		t.Fatal("open db: ", err)
-	}
 
 	txn := db.NewTransaction(true)
 	defer txn.Discard()
 
 	// Setup existing channels - currently we have to ack an existing event on the
 	// channels we want
-	err = writeEvent(txn, &Event{
+	err := writeEvent(txn, &Event{
 		Topic:      "topic",
 		ID:         "event0",
 		CreateTime: time.Now(),
 		Payload:    []byte{1},
-	})
+	}, CompressionNone, nil)
 	if err != nil {
 		t.Fatal("write event: ", err)
 	}
@@ -47,7 +36,7 @@ func TestWriteEvent(t *testing.T) {
 		ID:         "event00",
 		CreateTime: time.Now(),
 		Payload:    []byte{1},
-	})
+	}, CompressionNone, nil)
 	if err != nil {
 		t.Fatal("write event: ", err)
 	}
@@ -87,21 +76,21 @@ func TestWriteEvent(t *testing.T) {
 		State: EventStateDequeuedError,
 	}
 
-	err = writeEvent(txn, expected)
+	err = writeEvent(txn, expected, CompressionNone, nil)
 	if err != nil {
 		t.Fatal("write event: ", err)
 	}
 
 	expected.State = EventStateDequeuedOK
 
-	actual, err := getEvent(txn, expected.Topic, expected.ID, "channel")
+	actual, err := getEvent(txn, nil, expected.Topic, expected.ID, "channel")
 	if err != nil {
 		t.Fatalf("get event on channel: %v", err)
 	}
 	if !cmp.Equal(actual, expected) {
 		t.Errorf("\n%s", cmp.Diff(expected, actual))
 	}
-	actual, err = getEvent(txn, expected.Topic, expected.ID, "channel2")
+	actual, err = getEvent(txn, nil, expected.Topic, expected.ID, "channel2")
 	if err != nil {
 		t.Fatalf("get event on channel2: %v", err)
 	}
@@ -111,7 +100,7 @@ func TestWriteEvent(t *testing.T) {
 
 	expected.State = EventStateQueued
 
-	actual, err = getEvent(txn, expected.Topic, expected.ID, "newchannel")
+	actual, err = getEvent(txn, nil, expected.Topic, expected.ID, "newchannel")
 	if err != nil {
 		t.Fatalf("get event on newchannel: %v", err)
 	}
@@ -119,7 +108,7 @@ func TestWriteEvent(t *testing.T) {
 		t.Errorf("\n%s", cmp.Diff(expected, actual))
 	}
 
-	err = txn.Commit(nil)
+	err = txn.Commit()
 	if err != nil {
 		t.Error("commit: ", err)
 	}
@@ -136,11 +125,11 @@ func BenchmarkWriteEvent(b *testing.B) {
 	opts := badger.DefaultOptions
 	opts.Dir = dir
 	opts.ValueDir = dir
-	db, err := badger.Open(opts)
-	defer db.Close()
+	db, err := storage.OpenBadger(opts)
 	if err != nil {
 		b.Fatal("open db: ", err)
 	}
+	defer db.Close()
 
 	txn := db.NewTransaction(true)
 	defer txn.Discard()
@@ -155,12 +144,12 @@ func BenchmarkWriteEvent(b *testing.B) {
 		State: EventStateDequeuedError,
 	}
 
-	err = writeEvent(txn, &expected)
+	err = writeEvent(txn, &expected, CompressionNone, nil)
 	if err != nil {
 		b.Fatal("write event: ", err)
 	}
 
-	err = txn.Commit(nil)
+	err = txn.Commit()
 	if err != nil {
 		b.Error("commit: ", err)
 	}
@@ -0,0 +1,116 @@
+package deq
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"gitlab.com/katcheCode/deq/internal/data"
+	"gitlab.com/katcheCode/deq/internal/storage"
+)
+
+// Metadata keys set on dead letters, in addition to the Metadata of the original event.
+const (
+	// DeadLetterTopicKey is the topic of the original event.
+	DeadLetterTopicKey = "deq.dead_letter.topic"
+	// DeadLetterIDKey is the ID of the original event.
+	DeadLetterIDKey = "deq.dead_letter.id"
+	// DeadLetterChannelKey is the channel the original event reached its requeue limit on.
+	DeadLetterChannelKey = "deq.dead_letter.channel"
+	// DeadLetterRequeueCountKey is the RequeueCount of the original event on the channel, in
+	// decimal.
+	DeadLetterRequeueCountKey = "deq.dead_letter.requeue_count"
+	// DeadLetterErrorKey is the last error recorded for the original event on the channel with
+	// Channel.RecordEventError. It is not set if no error was recorded.
+	DeadLetterErrorKey = "deq.dead_letter.error"
+)
+
+// DeadLetterTopic sets the topic that a copy of each event is published to when the event reaches
+// its requeue limit while being requeued by c, instead of only being dequeued with
+// EventStateDequeuedError. The copy is published in the same transaction that dequeues the event.
+//
+// A dead letter has the ID "<topic>/<channel>/<id>" and the payload of the original event, and its
+// Metadata is the original event's Metadata along with the DeadLetter keys defined in this package.
+// If an event reaches its requeue limit on the same channel more than once, only the first dead
+// letter is published.
+//
+// Unlike events published with Pub, dead letters are not validated against the schema of their
+// topic, so an event is never lost because its dead letter doesn't match. A dead letter topic may
+// hold the payloads of every topic whose events are sent to it.
+//
+// The dead letter topic of a channel defaults to the DeadLetterTopic of the TopicConfig of c's
+// topic. Pass an empty topic to disable dead letters for c.
+//
+// DeadLetterTopic is not safe for concurrent use with any of c's methods.
+func (c *Channel) DeadLetterTopic(topic string) {
+	c.deadLetterTopic = topic
+}
+
+// RecordEventError records the last error that occured handling an event on c. If the event
+// reaches its requeue limit, the error is included in the Metadata of its dead letter.
+func (c *Channel) RecordEventError(id string, cause string) error {
+	key := data.ChannelKey{
+		Topic:   c.topic,
+		Channel: c.name,
+		ID:      id,
+	}
+
+	return c.store.write(context.Background(), func(txn storage.Txn) error {
+		channelEvent, err := getChannelEvent(txn, key)
+		if err != nil {
+			return err
+		}
+		channelEvent.LastError = cause
+		return setChannelEvent(txn, key, channelEvent)
+	})
+}
+
+// writeDeadLetter writes the dead letter of the event with the given topic and id to deadLetterTopic,
+// as it reached its requeue limit on channel with the state channelEvent. The dead letter is
+// returned if it was written, or nil if it already exists. The dead letter isn't validated against
+// the schema of deadLetterTopic.
+func (s *Store) writeDeadLetter(txn storage.Txn, channel, topic, id, deadLetterTopic string, channelEvent *data.ChannelPayload) (*Event, error) {
+	original, err := getEvent(txn, s.keys, topic, id, "")
+	if err != nil {
+		return nil, fmt.Errorf("get event: %v", err)
+	}
+
+	metadata := make(map[string]string, len(original.Metadata)+5)
+	for k, v := range original.Metadata {
+		metadata[k] = v
+	}
+	metadata[DeadLetterTopicKey] = topic
+	metadata[DeadLetterIDKey] = id
+	metadata[DeadLetterChannelKey] = channel
+	metadata[DeadLetterRequeueCountKey] = strconv.Itoa(int(channelEvent.RequeueCount))
+	if channelEvent.LastError != "" {
+		metadata[DeadLetterErrorKey] = channelEvent.LastError
+	}
+
+	deadLetter := Event{
+		ID:         topic + "/" + channel + "/" + id,
+		Topic:      deadLetterTopic,
+		Payload:    original.Payload,
+		Metadata:   metadata,
+		CreateTime: time.Now(),
+	}
+	err = s.prepareEvent(&deadLetter)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := writeOrMatchEvent(txn, &deadLetter, s.compression(deadLetterTopic), s.keys)
+	if err == ErrAlreadyExists {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, nil
+	}
+
+	deadLetter.State = deadLetter.DefaultState
+	return &deadLetter, nil
+}
@@ -0,0 +1,118 @@
+package deq
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDeadLetter(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	dir, err := ioutil.TempDir("", "test-dead-letter")
+	if err != nil {
+		t.Fatalf("create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	db, err := Open(Options{Dir: dir})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer db.Close()
+
+	err = db.SetTopicConfig("TopicA", TopicConfig{DeadLetterTopic: "TopicA"})
+	if err == nil {
+		t.Errorf("set dead letter topic to the configured topic: expected error")
+	}
+	err = db.SetTopicConfig("TopicA", TopicConfig{RequeueLimit: 1, DeadLetterTopic: "DLQ"})
+	if err != nil {
+		t.Fatalf("set topic config: %v", err)
+	}
+
+	e, err := db.Pub(ctx, Event{
+		ID:       "event1",
+		Topic:    "TopicA",
+		Payload:  []byte("payload"),
+		Metadata: map[string]string{"key": "value"},
+	})
+	if err != nil {
+		t.Fatalf("pub: %v", err)
+	}
+
+	channel := db.Channel("channel", "TopicA")
+	defer channel.Close()
+
+	err = channel.RecordEventError(e.ID, "handler failed")
+	if err != nil {
+		t.Fatalf("record event error: %v", err)
+	}
+	// Requeueing past the limit more than once publishes a single dead letter.
+	for i := 0; i < 3; i++ {
+		err = channel.RequeueEvent(e, 0)
+		if err != nil {
+			t.Fatalf("requeue: %v", err)
+		}
+	}
+
+	expected := []Event{
+		{
+			ID:      "TopicA/channel/event1",
+			Topic:   "DLQ",
+			Payload: []byte("payload"),
+			Metadata: map[string]string{
+				"key":                     "value",
+				DeadLetterTopicKey:        "TopicA",
+				DeadLetterIDKey:           "event1",
+				DeadLetterChannelKey:      "channel",
+				DeadLetterRequeueCountKey: "1",
+				DeadLetterErrorKey:        "handler failed",
+			},
+			State:        EventStateQueued,
+			DefaultState: EventStateQueued,
+		},
+	}
+
+	dlq := db.Channel("dlq", "DLQ")
+	defer dlq.Close()
+
+	var actual []Event
+	iter := dlq.NewEventIter(IterOpts{})
+	defer iter.Close()
+	for iter.Next() {
+		e := iter.Event()
+		e.CreateTime = time.Time{}
+		actual = append(actual, e)
+	}
+	if iter.Err() != nil {
+		t.Fatalf("iterate dead letters: %v", iter.Err())
+	}
+	if !cmp.Equal(expected, actual) {
+		t.Errorf("dead letters:\n%s", cmp.Diff(expected, actual))
+	}
+
+	// A channel's dead letter topic overrides the topic's.
+	e, err = db.Pub(ctx, Event{ID: "event2", Topic: "TopicA"})
+	if err != nil {
+		t.Fatalf("pub: %v", err)
+	}
+	channel2 := db.Channel("channel2", "TopicA")
+	defer channel2.Close()
+	channel2.DeadLetterTopic("")
+	for i := 0; i < 2; i++ {
+		err = channel2.RequeueEvent(e, 0)
+		if err != nil {
+			t.Fatalf("requeue without dead letter topic: %v", err)
+		}
+	}
+	_, err = dlq.Get("TopicA/channel2/event2")
+	if err != ErrNotFound {
+		t.Errorf("get dead letter of channel without dead letter topic: expected ErrNotFound, got %v", err)
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"math"
 	"sync"
 	"time"
 	"unicode"
@@ -23,37 +24,110 @@ import (
 	"github.com/dgraph-io/badger"
 	"github.com/dgraph-io/badger/options"
 	"gitlab.com/katcheCode/deq/internal/data"
+	"gitlab.com/katcheCode/deq/internal/storage"
+	"gitlab.com/katcheCode/deq/trace"
 )
 
 // Store is an event store connected to a specific database
 type Store struct {
-	db               *badger.DB
-	in               chan eventPromise
+	db               storage.DB
+	in               chan writeRequest
 	out              chan *Event
 	sharedChannelsMu sync.Mutex
 	sharedChannels   map[channelKey]*sharedChannel
 	// done is used for signaling to our store's go routine
 	done chan error
+	// wg waits on all background goroutines created by the store to be done
+	wg sync.WaitGroup
 
 	defaultRequeueLimit int
+
+	// counters are updated by the store's channels.
+	counters *counters
+	// observer is notified of changes to events if it is non-nil.
+	observer Observer
+	// tracer starts the spans of Channel.Sub handlers.
+	tracer *trace.Tracer
+
+	retention        map[string]RetentionPolicy
+	defaultRetention RetentionPolicy
+
+	compressionByTopic map[string]Compression
+	defaultCompression Compression
+	// keys encrypt the payloads of events if it is non-nil.
+	keys KeyProvider
+
+	// schemas holds the schema registry, which is loaded from disk when the store is opened.
+	schemasMu sync.RWMutex
+	schemas   map[string]*registeredSchema
+
+	// topicConfigs caches the configuration of every topic, which is loaded from disk when the store
+	// is opened.
+	topicConfigsMu sync.RWMutex
+	topicConfigs   map[string]TopicConfig
+	// setTopicConfigMu is held while a topic's configuration is committed and cached, so the cache
+	// is updated in the same order as the configurations are committed.
+	setTopicConfigMu sync.Mutex
+
+	// schedules caches every schedule, which are loaded from disk when the store is opened.
+	schedulesMu sync.RWMutex
+	schedules   map[string]*activeSchedule
+	// schedulesWake signals the scheduler that the schedules changed.
+	schedulesWake chan struct{}
 }
 
 // Options are parameters for opening a store
 type Options struct {
-	// Dir specifies the directory where data will be written. Required.
+	// Dir specifies the directory where data will be written. Required unless InMemory is true.
 	Dir string
+	// InMemory keeps all data in memory instead of writing it to Dir. All data is lost when the store
+	// is closed.
+	InMemory bool
 	// LoadingMode defaults to LoadingModeBalanced
 	LoadingMode LoadingMode
 	// DangerousDeleteCorrupt allows DEQ to delete any corrupt data from an unclean shutdown. If this
 	// option is false, attempting to call Open on a database with corrupt data will fail.
 	DangerousDeleteCorrupt bool
-	// DefaultRequeueLimit is the default RequeueLimit for new events. Defaults to 40. Set to -1 for
-	// no default limit.
+	// DefaultRequeueLimit is the RequeueLimit of events that don't set one, on topics whose
+	// TopicConfig doesn't set one either. Defaults to 40. Set to -1 for no default limit.
 	DefaultRequeueLimit int
 	// UpgradeIfNeeded causes the database to be upgraded if needed when it is opened. If
 	// UpgradeIfNeeded is false and the version of the data on disk doesn't match the version of the
 	// running code, Open returns an ErrVersionMismatch.
 	UpgradeIfNeeded bool
+	// Retention maps topics to the RetentionPolicy for their events. Topics not in Retention use
+	// DefaultRetention.
+	Retention map[string]RetentionPolicy
+	// DefaultRetention is the RetentionPolicy for topics that aren't in Retention. Defaults to
+	// keeping events until they are deleted.
+	DefaultRetention RetentionPolicy
+	// RetentionInterval is how often expired events are removed from the store. Defaults to one
+	// minute.
+	RetentionInterval time.Duration
+	// Compression maps topics to the Compression used for the payloads of their new events. Topics
+	// not in Compression use DefaultCompression.
+	Compression map[string]Compression
+	// DefaultCompression is the Compression for topics that aren't in Compression. Defaults to
+	// CompressionNone.
+	DefaultCompression Compression
+	// KeyProvider provides the keys that the payloads of new events are encrypted with. If it is nil,
+	// payloads are not encrypted, and encrypted payloads can't be read. See KeyProvider for details.
+	KeyProvider KeyProvider
+	// WriteWindow is how long the store waits for concurrent writes to group into a single
+	// transaction, trading latency for fewer syncs to disk. Writes made while a transaction is being
+	// committed are always grouped into the next transaction, so even the default of zero groups
+	// writes under load.
+	WriteWindow time.Duration
+	// DangerousNoSync acknowledges writes before they are synced to disk, which lowers the latency
+	// of writes. If DangerousNoSync is true, acknowledged writes may be lost if the operating system
+	// crashes or the machine loses power.
+	DangerousNoSync bool
+	// Observer is notified of changes to the store's events, if it is non-nil.
+	Observer Observer
+
+	// TraceExporter exports the spans started by Channel.Sub for handled events that carry trace
+	// context. If it is nil, trace context is still propagated but spans aren't exported.
+	TraceExporter trace.Exporter
 }
 
 // LoadingMode specifies how to load data into memory. Generally speaking, lower memory is slower
@@ -88,15 +162,27 @@ func (m LoadingMode) badgerOptions() (options.FileLoadingMode, options.FileLoadi
 	}
 }
 
-type eventPromise struct {
-	event *Event
-	done  chan error
+// openDB opens the storage.DB selected by opts.
+func openDB(opts Options) (storage.DB, error) {
+	if opts.InMemory {
+		return storage.NewMemory(), nil
+	}
+
+	badgerOpts := badger.DefaultOptions
+	badgerOpts.Dir = opts.Dir
+	badgerOpts.ValueDir = opts.Dir
+	badgerOpts.SyncWrites = !opts.DangerousNoSync
+	badgerOpts.TableLoadingMode, badgerOpts.ValueLogLoadingMode = opts.LoadingMode.badgerOptions()
+	badgerOpts.MaxTableSize = 1 << 24
+	badgerOpts.Truncate = opts.DangerousDeleteCorrupt
+
+	return storage.OpenBadger(badgerOpts)
 }
 
 // Open opens a store from disk, or creates a new store if it does not already exist
 func Open(opts Options) (*Store, error) {
 
-	if opts.Dir == "" {
+	if opts.Dir == "" && !opts.InMemory {
 		return nil, errors.New("option Dir is required")
 	}
 
@@ -105,50 +191,103 @@ func Open(opts Options) (*Store, error) {
 		requeueLimit = 40
 	}
 
-	badgerOpts := badger.DefaultOptions
-	badgerOpts.Dir = opts.Dir
-	badgerOpts.ValueDir = opts.Dir
-	badgerOpts.SyncWrites = true
-	badgerOpts.TableLoadingMode, badgerOpts.ValueLogLoadingMode = opts.LoadingMode.badgerOptions()
-	badgerOpts.MaxTableSize = 1 << 24
-	badgerOpts.Truncate = opts.DangerousDeleteCorrupt
+	retentionInterval := opts.RetentionInterval
+	if retentionInterval == 0 {
+		retentionInterval = time.Minute
+	}
 
-	db, err := badger.Open(badgerOpts)
+	db, err := openDB(opts)
 	if err != nil {
 		return nil, err
 	}
 	s := &Store{
 		db:                  db,
-		in:                  make(chan eventPromise, 20),
+		in:                  make(chan writeRequest),
 		out:                 make(chan *Event, 20),
 		sharedChannels:      make(map[channelKey]*sharedChannel),
 		done:                make(chan error),
 		defaultRequeueLimit: requeueLimit,
+		counters:            new(counters),
+		observer:            opts.Observer,
+		tracer:              trace.NewTracer(opts.TraceExporter),
+		retention:           opts.Retention,
+		defaultRetention:    opts.DefaultRetention,
+		compressionByTopic:  opts.Compression,
+		defaultCompression:  opts.DefaultCompression,
+		keys:                opts.KeyProvider,
+		schedulesWake:       make(chan struct{}, 1),
 	}
 
 	txn := db.NewTransaction(true)
+	defer txn.Discard()
 
 	version, err := s.getDBVersion(txn)
 	if err != nil {
+		db.Close()
 		return nil, fmt.Errorf("read current database version: %v", err)
 	}
-	if version != dbCodeVersion {
-		if version == "" {
-			err := txn.Set([]byte(dbVersionKey), []byte(dbCodeVersion))
-			if err != nil {
-				return nil, fmt.Errorf("write version for new db: %v", err)
-			}
-		} else if !opts.UpgradeIfNeeded {
+	if version == "" {
+		err := txn.Set([]byte(dbVersionKey), []byte(dbCodeVersion))
+		if err != nil {
+			db.Close()
+			return nil, fmt.Errorf("write version for new db: %v", err)
+		}
+		err = txn.Commit()
+		if err != nil {
+			db.Close()
+			return nil, fmt.Errorf("commit version for new db: %v", err)
+		}
+	} else if version != dbCodeVersion {
+		if !opts.UpgradeIfNeeded {
+			db.Close()
 			return nil, ErrVersionMismatch
 		}
 
 		err = s.upgradeDB(version)
 		if err != nil {
+			db.Close()
 			return nil, fmt.Errorf("upgrade db: %v", err)
 		}
 	}
 
-	go s.garbageCollect(time.Minute * 5)
+	err = s.loadSchemas()
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("load schemas: %v", err)
+	}
+	err = s.loadTopicConfigs()
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("load topic configs: %v", err)
+	}
+	err = s.loadSchedules()
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("load schedules: %v", err)
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.garbageCollect(time.Minute * 5)
+	}()
+	// Retention can be configured per topic while the store is open, so expired events are swept
+	// even if no retention is configured yet.
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.expireEvents(retentionInterval)
+	}()
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.writeLoop(opts.WriteWindow)
+	}()
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.runSchedules()
+	}()
 	go s.listenOut()
 
 	return s, nil
@@ -163,6 +302,11 @@ func Open(opts Options) (*Store, error) {
 //   defer db.Close()
 func (s *Store) Close() error {
 
+	close(s.done)
+	// Wait for background goroutines before locking the shared channels. The scheduler may still be
+	// publishing an event, which needs the lock, and listenOut needs it to drain s.out.
+	s.wg.Wait()
+
 	s.sharedChannelsMu.Lock()
 	defer s.sharedChannelsMu.Unlock()
 
@@ -170,7 +314,6 @@ func (s *Store) Close() error {
 		log.Printf("[WARN] Store.Close called before closing all channels")
 	}
 
-	close(s.done)
 	close(s.out)
 
 	err := s.db.Close()
@@ -201,58 +344,168 @@ func isValidTopic(topic string) bool {
 }
 
 // Pub publishes an event.
+//
+// If the event's topic has a Schema and the event's payload doesn't match it, Pub returns an
+// *InvalidPayloadError.
 func (s *Store) Pub(ctx context.Context, e Event) (Event, error) {
+	e, _, err := s.Publish(ctx, e)
+	return e, err
+}
 
-	if !isValidTopic(e.Topic) {
-		return Event{}, fmt.Errorf("e.Topic is not valid")
+// Publish is like Pub, but also returns whether e was newly published. It returns false if e has
+// the same ID and payload as an existing event, which is returned in its place.
+func (s *Store) Publish(ctx context.Context, e Event) (Event, bool, error) {
+
+	err := s.prepareEvent(&e)
+	if err != nil {
+		return Event{}, false, err
 	}
-	if e.CreateTime.IsZero() {
-		e.CreateTime = time.Now()
+	err = s.validatePayload(&e)
+	if err != nil {
+		return Event{}, false, err
 	}
-	if e.DefaultState == EventStateUnspecified {
-		e.DefaultState = EventStateQueued
+
+	var existing *Event
+	err = s.write(ctx, func(txn storage.Txn) error {
+		var err error
+		existing, err = writeOrMatchEvent(txn, &e, s.compression(e.Topic), s.keys)
+		return err
+	})
+	if err != nil {
+		return Event{}, false, err
+	}
+	if existing != nil {
+		return *existing, false, nil
 	}
 
-	txn := s.db.NewTransaction(true)
-	defer txn.Discard()
+	e.State = e.DefaultState
+	s.published(&e)
 
-	err := writeEvent(txn, &e)
-	if err == ErrAlreadyExists {
-		// Supress the error if the new and existing events have matching payloads.
-		existing, err := getEvent(txn, e.Topic, e.ID, "")
+	return e, true, nil
+}
+
+// PubBatch publishes a batch of events atomically. Either all of the events are published, or
+// none of them are. The events may have different topics.
+//
+// Like Pub, publishing an event with the same ID and payload as an existing event on the same
+// topic succeeds without publishing a new event, and the existing event is returned in its place.
+// If any event has the same ID and topic as an existing event but a different payload, PubBatch
+// returns ErrAlreadyExists and none of the events are published.
+//
+// The returned events are in the same order as events.
+func (s *Store) PubBatch(ctx context.Context, events []Event) ([]Event, error) {
+	results, _, err := s.PublishBatch(ctx, events)
+	return results, err
+}
+
+// PublishBatch is like PubBatch, but also returns whether each event was newly published, in the
+// same order as events.
+func (s *Store) PublishBatch(ctx context.Context, events []Event) ([]Event, []bool, error) {
+
+	batch := make([]Event, len(events))
+	copy(batch, events)
+	for i := range batch {
+		err := s.prepareEvent(&batch[i])
 		if err != nil {
-			return Event{}, fmt.Errorf("get existing event: %v", err)
+			return nil, nil, fmt.Errorf("event %d: %v", i, err)
 		}
-		if !bytes.Equal(existing.Payload, e.Payload) {
-			return Event{}, ErrAlreadyExists
+		err = s.validatePayload(&batch[i])
+		if err != nil {
+			return nil, nil, err
 		}
-		return *existing, nil
 	}
+
+	var results []Event
+	var written []bool
+	err := s.write(ctx, func(txn storage.Txn) error {
+		results = make([]Event, len(batch))
+		written = make([]bool, len(batch))
+
+		for j := range batch {
+			e := batch[j]
+			existing, err := writeOrMatchEvent(txn, &e, s.compression(e.Topic), s.keys)
+			if err == ErrAlreadyExists {
+				return ErrAlreadyExists
+			}
+			if err != nil {
+				return fmt.Errorf("event %d: %v", j, err)
+			}
+			if existing != nil {
+				results[j] = *existing
+				continue
+			}
+			e.State = e.DefaultState
+			results[j] = e
+			written[j] = true
+		}
+
+		return nil
+	})
 	if err != nil {
-		return Event{}, err
+		return nil, nil, err
+	}
+
+	for j := range results {
+		if written[j] {
+			e := results[j]
+			s.published(&e)
+		}
+	}
+
+	return results, written, nil
+}
+
+// prepareEvent validates e and applies the defaults for a new event, including the defaults of its
+// topic's TopicConfig.
+func (s *Store) prepareEvent(e *Event) error {
+	if !isValidTopic(e.Topic) {
+		return fmt.Errorf("e.Topic is not valid")
+	}
+	if e.CreateTime.IsZero() {
+		e.CreateTime = time.Now()
+	}
+	if e.DefaultState == EventStateUnspecified {
+		e.DefaultState = s.TopicConfig(e.Topic).DefaultState
+	}
+	if e.DefaultState == EventStateUnspecified {
+		e.DefaultState = EventStateQueued
+	}
+	if e.RequeueLimit < -1 {
+		return fmt.Errorf("e.RequeueLimit must be -1 or greater")
+	}
+	if e.Priority < math.MinInt32 || e.Priority > math.MaxInt32 {
+		return fmt.Errorf("e.Priority must be a 32 bit integer")
 	}
+	return nil
+}
 
-	err = txn.Commit(nil)
-	if err == badger.ErrConflict {
-		txn := s.db.NewTransaction(false)
-		defer txn.Discard()
-		existing, err := getEvent(txn, e.Topic, e.ID, "")
+// writeOrMatchEvent writes e to txn. If an event with the same topic and ID already exists and has
+// the same payload as e, the existing event is returned and nothing is written. If the existing
+// event has a different payload, ErrAlreadyExists is returned. The payload of e is compressed with
+// compression and encrypted with keys, as in writeEvent.
+func writeOrMatchEvent(txn storage.Txn, e *Event, compression Compression, keys KeyProvider) (*Event, error) {
+	err := writeEvent(txn, e, compression, keys)
+	if err == ErrAlreadyExists {
+		// Supress the error if the new and existing events have matching payloads.
+		existing, err := getEvent(txn, keys, e.Topic, e.ID, "")
 		if err != nil {
-			return Event{}, fmt.Errorf("get conflicting event: %v", err)
+			return nil, fmt.Errorf("get existing event: %v", err)
 		}
 		if !bytes.Equal(existing.Payload, e.Payload) {
-			return Event{}, ErrAlreadyExists
+			return nil, ErrAlreadyExists
 		}
-		return *existing, nil
+		return existing, nil
 	}
 	if err != nil {
-		return Event{}, err
+		return nil, err
 	}
+	return nil, nil
+}
 
-	e.State = e.DefaultState
-
+// published notifies the store's channels that e has been committed.
+func (s *Store) published(e *Event) {
 	if e.DefaultState == EventStateQueued {
-		s.out <- &e
+		s.out <- e
 	}
 
 	s.sharedChannelsMu.Lock()
@@ -264,58 +517,239 @@ func (s *Store) Pub(ctx context.Context, e Event) (Event, error) {
 		}
 	}
 
-	return e, nil
+	if s.observer != nil {
+		s.observer.EventPublished(*e)
+	}
 }
 
-// Del deletes an event
+// Del deletes an event, along with its state on every channel.
+//
+// Any index that points to the event is updated to point to the most recent remaining event on the
+// same topic with the same index value, or deleted if there are no such events.
 func (s *Store) Del(topic, id string) error {
 
-	txn := s.db.NewTransaction(true)
-	defer txn.Discard()
+	var deleted Event
+	err := s.write(context.Background(), func(txn storage.Txn) error {
+		eventTime, err := getEventTimePayload(txn, data.EventTimeKey{
+			ID:    id,
+			Topic: topic,
+		})
+		if err != nil {
+			return err
+		}
+
+		key := data.EventKey{
+			ID:         id,
+			Topic:      topic,
+			CreateTime: time.Unix(0, eventTime.CreateTime),
+		}
+
+		payload, err := getStoredEventPayload(txn, key)
+		if err != nil {
+			return fmt.Errorf("get event payload: %v", err)
+		}
 
-	// TODO: refactor this, we really don't need the whole event, just
-	// the create time
-	e, err := getEvent(txn, topic, id, "")
+		if s.observer != nil {
+			deleted = s.deletedEvent(key, payload)
+		}
+
+		return deleteEvent(txn, key, &payload)
+	})
 	if err != nil {
 		return err
 	}
 
-	eventTimeKey, err := data.EventTimeKey{
-		ID:    id,
-		Topic: topic,
-	}.Marshal(nil)
-	if err != nil {
-		return fmt.Errorf("marshal event time key: %v", err)
+	if s.observer != nil {
+		s.observer.EventDeleted(deleted)
 	}
 
-	eventKey, err := data.EventKey{
-		ID:         id,
-		Topic:      topic,
-		CreateTime: e.CreateTime,
-	}.Marshal(nil)
+	return nil
+}
+
+// eventFromPayload returns the event stored with key and payload, in its default state.
+func eventFromPayload(key data.EventKey, payload *data.EventPayload) Event {
+	return Event{
+		ID:           key.ID,
+		Topic:        key.Topic,
+		CreateTime:   key.CreateTime,
+		Payload:      payload.Payload,
+		State:        protoToEventState(payload.DefaultEventState),
+		DefaultState: protoToEventState(payload.DefaultEventState),
+		Indexes:      payload.Indexes,
+		Metadata:     payload.Metadata,
+		RequeueLimit: int(payload.RequeueLimit),
+		DeliverAt:    data.FromUnixNano(payload.DeliverAt),
+		Priority:     int(payload.Priority),
+	}
+}
+
+// deletedEvent returns the event being deleted with key and its stored payload, for the store's
+// observer. Deleting an event doesn't need its payload to be decrypted, so if it can't be, for
+// example because its key is no longer available, the event is returned without its payload.
+func (s *Store) deletedEvent(key data.EventKey, payload data.EventPayload) Event {
+	rawKey, err := key.Marshal(nil)
+	if err == nil {
+		err = decodeEventPayload(&payload, rawKey, s.keys)
+	}
 	if err != nil {
-		return fmt.Errorf("marshal event key: %v", err)
+		log.Printf("[WARN] delete event %s %s: decode payload: %v", key.Topic, key.ID, err)
+		payload.Payload = nil
 	}
+	return eventFromPayload(key, &payload)
+}
 
-	// TODO: cleanup channel keys
+// DelTopicOpts are options for Store.DelTopic.
+type DelTopicOpts struct {
+	// Before limits the deletion to events created before Before. If Before is the zero time, every
+	// event of the topic is deleted, along with any remaining index and channel state of the topic.
+	Before time.Time
+}
+
+// DelTopic deletes the events of a topic, along with their indexes and their state on every
+// channel. Once every event of a topic is deleted, it is no longer listed by a TopicIter.
+//
+// Events are deleted in small batches, each in their own transaction, so DelTopic may be called on
+// large topics while the store is in use. If DelTopic returns an error or ctx is done, some of the
+// events may have been deleted already. DelTopic returns the number of events deleted.
+//
+// The schema of the topic, if any, is kept, so new events on the topic must still match it.
+func (s *Store) DelTopic(ctx context.Context, topic string, opts DelTopicOpts) (int, error) {
+
+	if !isValidTopic(topic) {
+		return 0, fmt.Errorf("topic is not valid")
+	}
+
+	cutoff := opts.Before
+	excess := 0
+	if cutoff.IsZero() {
+		excess = math.MaxInt32
+	}
+
+	total := 0
+	for conflicts := 0; ; {
+		if ctx.Err() != nil {
+			return total, ctx.Err()
+		}
 
-	err = txn.Delete(eventTimeKey)
+		deleted, more, err := s.delEventsBatch(ctx, topic, cutoff, excess-total)
+		if err == storage.ErrConflict && conflicts < 10 {
+			conflicts++
+			time.Sleep(time.Millisecond * 20)
+			continue
+		}
+		if err != nil {
+			return total, err
+		}
+		total += deleted
+		if !more {
+			break
+		}
+	}
+
+	if !opts.Before.IsZero() {
+		return total, nil
+	}
+
+	err := s.purgeTopic(ctx, topic)
 	if err != nil {
-		return fmt.Errorf("delete event time: %v", err)
+		return total, fmt.Errorf("purge topic state: %v", err)
 	}
-	err = txn.Delete(eventKey)
+
+	return total, nil
+}
+
+// purgeTopic deletes any index or channel state of topic that isn't attached to an event.
+func (s *Store) purgeTopic(ctx context.Context, topic string) error {
+
+	txn := s.db.NewTransaction(false)
+	channels, err := getChannelNames(txn)
+	txn.Discard()
 	if err != nil {
-		return fmt.Errorf("delete event key: %v", err)
+		return err
 	}
 
-	err = txn.Commit(nil)
+	prefix, err := data.IndexPrefixTopic(topic)
+	if err != nil {
+		return err
+	}
+	priorityPrefix, err := data.PriorityPrefixTopic(topic)
 	if err != nil {
 		return err
 	}
+	prefixes := [][]byte{prefix, priorityPrefix}
+	for _, channel := range channels {
+		prefix, err := data.ChannelKey{
+			Channel: channel,
+			Topic:   topic,
+		}.Marshal(nil)
+		if err != nil {
+			return err
+		}
+		prefixes = append(prefixes, prefix)
+	}
+
+	for _, prefix := range prefixes {
+		for more := true; more; {
+			more, err = s.delPrefixBatch(ctx, prefix)
+			if err != nil {
+				return err
+			}
+		}
+	}
 
 	return nil
 }
 
+// delPrefixBatch deletes up to delBatchSize keys with the given prefix, or as many as it reads
+// within maxWriteHold. It returns whether there may be more keys to delete.
+func (s *Store) delPrefixBatch(ctx context.Context, prefix []byte) (bool, error) {
+
+	var more bool
+	err := s.write(ctx, func(txn storage.Txn) error {
+		var keys [][]byte
+		more = false
+		deadline := time.Now().Add(maxWriteHold)
+
+		it := txn.NewIterator(storage.IteratorOptions{})
+		for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
+			if len(keys) >= delBatchSize || (len(keys) > 0 && time.Now().After(deadline)) {
+				more = true
+				break
+			}
+			keys = append(keys, it.Item().KeyCopy(nil))
+		}
+		it.Close()
+
+		for _, key := range keys {
+			// Deleting the state of an event on a channel also updates the channel's stats.
+			if key[0] == data.ChannelTag {
+				var channelKey data.ChannelKey
+				err := data.UnmarshalChannelKey(key, &channelKey)
+				if err != nil {
+					return fmt.Errorf("parse channel key %s: %v", key, err)
+				}
+				err = deleteChannelEvent(txn, channelKey)
+				if err != nil {
+					return err
+				}
+				continue
+			}
+
+			err := txn.Delete(key)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return more, nil
+}
+
 func (s *Store) listenOut() {
 	for e := range s.out {
 		s.sharedChannelsMu.Lock()
@@ -342,7 +776,10 @@ func (s *Store) garbageCollect(interval time.Duration) {
 		case <-s.done:
 			return
 		case <-ticker.C:
-			s.db.RunValueLogGC(0.7)
+			err := s.db.RunGC()
+			if err != nil {
+				log.Printf("[WARN] garbage collect: %v", err)
+			}
 		}
 	}
 }
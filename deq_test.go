@@ -3,22 +3,18 @@ package deq
 import (
 	"context"
 	"fmt"
-	"io/ioutil"
-	"os"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"gitlab.com/katcheCode/deq/internal/data"
+	"gitlab.com/katcheCode/deq/internal/storage"
 )
 
 func newTestDB() (*Store, func()) {
-	dir, err := ioutil.TempDir("", "test-pub")
-	if err != nil {
-		panic("create temp dir: " + err.Error())
-	}
-
 	db, err := Open(Options{
-		Dir: dir,
+		InMemory: true,
 	})
 	if err != nil {
 		panic("open db: " + err.Error())
@@ -26,7 +22,6 @@ func newTestDB() (*Store, func()) {
 
 	return db, func() {
 		db.Close()
-		os.RemoveAll(dir)
 	}
 }
 
@@ -68,6 +63,262 @@ func TestDel(t *testing.T) {
 	}
 }
 
+func TestDelCascade(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	db, discard := newTestDB()
+	defer discard()
+
+	// Round(0) to discard's leap-second info that's lost in serialization
+	createTime := time.Now().Round(0)
+
+	events := []Event{
+		{
+			ID:         "event1",
+			Topic:      "topic",
+			CreateTime: createTime,
+			Indexes:    []string{"a"},
+		},
+		{
+			ID:         "event2",
+			Topic:      "topic",
+			CreateTime: createTime.Add(time.Second),
+			Indexes:    []string{"a", "b"},
+		},
+	}
+	for _, e := range events {
+		_, err := db.Pub(ctx, e)
+		if err != nil {
+			t.Fatalf("pub: %v", err)
+		}
+	}
+
+	channel := db.Channel("channel", "topic")
+	defer channel.Close()
+
+	for _, e := range events {
+		err := channel.SetEventState(e.ID, EventStateDequeuedOK)
+		if err != nil {
+			t.Fatalf("set event state: %v", err)
+		}
+	}
+
+	err := db.Del("topic", "event2")
+	if err != nil {
+		t.Fatalf("del: %v", err)
+	}
+
+	// Index "a" should point to event1, and index "b" should be removed.
+	var actual []string
+	iter := channel.NewIndexIter(DefaultIterOpts)
+	for iter.Next() {
+		actual = append(actual, iter.Event().ID)
+	}
+	iter.Close()
+	if iter.Err() != nil {
+		t.Fatalf("iterate indexes: %v", iter.Err())
+	}
+	expected := []string{"event1"}
+	if !cmp.Equal(expected, actual) {
+		t.Errorf("indexes:\n%s", cmp.Diff(expected, actual))
+	}
+
+	txn := db.db.NewTransaction(false)
+	defer txn.Discard()
+
+	state, err := getChannelEvent(txn, data.ChannelKey{
+		Channel: "channel",
+		Topic:   "topic",
+		ID:      "event2",
+	})
+	if err != nil {
+		t.Fatalf("get deleted channel event: %v", err)
+	}
+	if !cmp.Equal(state, defaultChannelPayload) {
+		t.Errorf("deleted channel event:\n%s", cmp.Diff(defaultChannelPayload, state))
+	}
+
+	e, err := channel.Get("event1")
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if e.State != EventStateDequeuedOK {
+		t.Errorf("expected remaining event's state to be unchanged, got %v", e.State)
+	}
+
+	err = db.Del("topic", "event1")
+	if err != nil {
+		t.Fatalf("del: %v", err)
+	}
+
+	iter = channel.NewIndexIter(DefaultIterOpts)
+	defer iter.Close()
+	for iter.Next() {
+		t.Errorf("index for deleted event: %v", iter.Event().Indexes)
+	}
+	if iter.Err() != nil {
+		t.Fatalf("iterate indexes: %v", iter.Err())
+	}
+}
+
+func TestDelQueued(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	db, discard := newTestDB()
+	defer discard()
+
+	_, err := db.PubBatch(ctx, []Event{
+		{ID: "event1", Topic: "topic", CreateTime: time.Now()},
+		{ID: "event2", Topic: "topic", CreateTime: time.Now().Add(time.Second)},
+	})
+	if err != nil {
+		t.Fatalf("pub: %v", err)
+	}
+
+	channel := db.Channel("channel", "topic")
+	defer channel.Close()
+	channel.BackoffFunc(func(Event) time.Duration { return 50 * time.Millisecond })
+
+	for _, id := range []string{"event1", "event2"} {
+		nextCtx, cancel := context.WithTimeout(ctx, time.Second)
+		e, err := channel.Next(nextCtx)
+		cancel()
+		if err != nil {
+			t.Fatalf("next: %v", err)
+		}
+		if e.ID != id {
+			t.Fatalf("expected %s, got %s", id, e.ID)
+		}
+	}
+	err = channel.SetEventState("event2", EventStateDequeuedOK)
+	if err != nil {
+		t.Fatalf("set event state: %v", err)
+	}
+
+	// event1's requeue is still pending when it is deleted.
+	err = db.Del("topic", "event1")
+	if err != nil {
+		t.Fatalf("del: %v", err)
+	}
+
+	nextCtx, cancel := context.WithTimeout(ctx, 300*time.Millisecond)
+	e, err := channel.Next(nextCtx)
+	cancel()
+	if err != context.DeadlineExceeded {
+		t.Fatalf("expected no events after delete, got %s, %v", e.ID, err)
+	}
+
+	key, err := data.ChannelKey{
+		Channel: "channel",
+		Topic:   "topic",
+		ID:      "event1",
+	}.Marshal(nil)
+	if err != nil {
+		t.Fatalf("marshal channel key: %v", err)
+	}
+	txn := db.db.NewTransaction(false)
+	defer txn.Discard()
+	_, err = txn.Get(key)
+	if err != storage.ErrKeyNotFound {
+		t.Errorf("expected no channel state for deleted event, got %v", err)
+	}
+}
+
+func TestDelTopic(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	db, discard := newTestDB()
+	defer discard()
+
+	// Round(0) to discard's leap-second info that's lost in serialization
+	createTime := time.Now().Round(0)
+
+	for i := 0; i < 250; i++ {
+		for _, topic := range []string{"TopicA", "TopicB"} {
+			_, err := db.Pub(ctx, Event{
+				ID:         fmt.Sprintf("event%03d", i),
+				Topic:      topic,
+				CreateTime: createTime.Add(time.Duration(i) * time.Second),
+				Indexes:    []string{fmt.Sprintf("index%03d", i)},
+			})
+			if err != nil {
+				t.Fatalf("pub: %v", err)
+			}
+		}
+	}
+
+	channel := db.Channel("channel", "TopicA")
+	defer channel.Close()
+
+	err := channel.SetEventState("event249", EventStateDequeuedOK)
+	if err != nil {
+		t.Fatalf("set event state: %v", err)
+	}
+
+	deleted, err := db.DelTopic(ctx, "TopicA", DelTopicOpts{
+		Before: createTime.Add(200 * time.Second),
+	})
+	if err != nil {
+		t.Fatalf("delete events before cutoff: %v", err)
+	}
+	if deleted != 200 {
+		t.Errorf("expected 200 events deleted before cutoff, got %d", deleted)
+	}
+
+	iter := channel.NewEventIter(DefaultIterOpts)
+	count := 0
+	for iter.Next() {
+		count++
+	}
+	iter.Close()
+	if count != 50 {
+		t.Errorf("expected 50 events after deleting before cutoff, got %d", count)
+	}
+
+	deleted, err = db.DelTopic(ctx, "TopicA", DelTopicOpts{})
+	if err != nil {
+		t.Fatalf("delete topic: %v", err)
+	}
+	if deleted != 50 {
+		t.Errorf("expected 50 events deleted, got %d", deleted)
+	}
+
+	topics := db.NewTopicIter(DefaultIterOpts)
+	defer topics.Close()
+
+	var actual []string
+	for topics.Next() {
+		actual = append(actual, topics.Topic())
+	}
+	expected := []string{"TopicB"}
+	if !cmp.Equal(expected, actual) {
+		t.Errorf("topics:\n%s", cmp.Diff(expected, actual))
+	}
+
+	indexes := channel.NewIndexIter(DefaultIterOpts)
+	defer indexes.Close()
+	for indexes.Next() {
+		t.Errorf("index of deleted topic: %v", indexes.Event().Indexes)
+	}
+
+	txn := db.db.NewTransaction(false)
+	defer txn.Discard()
+
+	channels, err := getChannelNames(txn)
+	if err != nil {
+		t.Fatalf("get channel names: %v", err)
+	}
+	if len(channels) != 0 {
+		t.Errorf("expected channel state to be deleted, found channels %v", channels)
+	}
+}
+
 func TestPub(t *testing.T) {
 	t.Parallel()
 
@@ -177,16 +428,22 @@ func TestPubDuplicate(t *testing.T) {
 	defer channel.Close()
 
 	// Publish the event
-	_, err := db.Pub(ctx, expected)
+	_, published, err := db.Publish(ctx, expected)
 	if err != nil {
 		t.Fatalf("pub: %v", err)
 	}
+	if !published {
+		t.Errorf("pub: expected event to be published")
+	}
 
 	// Publish and verify event with same id and payload
-	_, err = db.Pub(ctx, expected)
+	_, published, err = db.Publish(ctx, expected)
 	if err != nil {
 		t.Fatalf("identifical duplicate pub: %v", err)
 	}
+	if published {
+		t.Errorf("identical duplicate pub: expected existing event to be returned")
+	}
 
 	// Publish and verify event with same id and different payload
 	expected.Payload = []byte{1}
@@ -214,3 +471,237 @@ func TestPubDuplicate(t *testing.T) {
 		t.Errorf("get:\n%s", cmp.Diff(expected, event))
 	}
 }
+
+func TestPubBatch(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	db, discard := newTestDB()
+	defer discard()
+
+	// Round(0) to discard's leap-second info that's lost in serialization
+	createTime := time.Now().Round(0)
+
+	existing := Event{
+		ID:           "event1",
+		Topic:        "TopicA",
+		Payload:      []byte{1},
+		CreateTime:   createTime,
+		DefaultState: EventStateQueued,
+		State:        EventStateQueued,
+	}
+	_, err := db.Pub(ctx, existing)
+	if err != nil {
+		t.Fatalf("pub: %v", err)
+	}
+
+	// A modified duplicate should prevent the whole batch from being published.
+	_, err = db.PubBatch(ctx, []Event{
+		{
+			ID:         "event2",
+			Topic:      "TopicA",
+			CreateTime: createTime,
+		},
+		{
+			ID:         "event1",
+			Topic:      "TopicA",
+			Payload:    []byte{2},
+			CreateTime: createTime,
+		},
+	})
+	if err != ErrAlreadyExists {
+		t.Fatalf("pub batch with modified duplicate: %v", err)
+	}
+
+	channelA := db.Channel("channel", "TopicA")
+	defer channelA.Close()
+
+	_, err = channelA.Get("event2")
+	if err != ErrNotFound {
+		t.Fatalf("get event from failed batch: %v", err)
+	}
+
+	expected := []Event{
+		existing,
+		{
+			ID:           "event2",
+			Topic:        "TopicA",
+			CreateTime:   createTime,
+			DefaultState: EventStateQueued,
+			State:        EventStateQueued,
+		},
+		{
+			ID:           "event1",
+			Topic:        "TopicB",
+			CreateTime:   createTime,
+			DefaultState: EventStateQueued,
+			State:        EventStateQueued,
+		},
+	}
+
+	actual, published, err := db.PublishBatch(ctx, []Event{
+		{
+			ID:         "event1",
+			Topic:      "TopicA",
+			Payload:    []byte{1},
+			CreateTime: createTime,
+		},
+		{
+			ID:         "event2",
+			Topic:      "TopicA",
+			CreateTime: createTime,
+		},
+		{
+			ID:         "event1",
+			Topic:      "TopicB",
+			CreateTime: createTime,
+		},
+	})
+	if err != nil {
+		t.Fatalf("pub batch: %v", err)
+	}
+	if !cmp.Equal(expected, actual) {
+		t.Errorf("pub batch:\n%s", cmp.Diff(expected, actual))
+	}
+	// Only the existing event isn't newly published.
+	if !cmp.Equal([]bool{false, true, true}, published) {
+		t.Errorf("pub batch published:\n%s", cmp.Diff([]bool{false, true, true}, published))
+	}
+
+	channelB := db.Channel("channel", "TopicB")
+	defer channelB.Close()
+
+	for _, e := range expected[1:] {
+		channel := channelA
+		if e.Topic == "TopicB" {
+			channel = channelB
+		}
+		event, err := channel.Get(e.ID)
+		if err != nil {
+			t.Fatalf("get %s %s: %v", e.Topic, e.ID, err)
+		}
+		if !cmp.Equal(e, event) {
+			t.Errorf("get %s %s:\n%s", e.Topic, e.ID, cmp.Diff(e, event))
+		}
+	}
+}
+
+func TestPubCanceled(t *testing.T) {
+	t.Parallel()
+
+	db, err := Open(Options{
+		InMemory: true,
+	})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = db.Pub(ctx, Event{
+		ID:    "event1",
+		Topic: "topic",
+	})
+	if err != context.Canceled {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+
+	channel := db.Channel("channel", "topic")
+	defer channel.Close()
+
+	_, err = channel.Get("event1")
+	if err != ErrNotFound {
+		t.Errorf("expected canceled event not to be published, got %v", err)
+	}
+}
+
+func TestPubGrouped(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	// Use a long window so the concurrent writes are committed in the same transaction.
+	db, err := Open(Options{
+		InMemory:    true,
+		WriteWindow: 50 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer db.Close()
+
+	before, err := db.Stats()
+	if err != nil {
+		t.Fatalf("stats: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+
+	for i := 0; i < 10; i++ {
+		i := i
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, err := db.Pub(ctx, Event{
+				ID:    fmt.Sprintf("event%d", i),
+				Topic: "topic",
+			})
+			if err != nil {
+				errs <- fmt.Errorf("pub event%d: %v", i, err)
+			}
+		}()
+		// Only one of these should succeed, without failing the other writes in the transaction.
+		go func() {
+			defer wg.Done()
+			_, err := db.Pub(ctx, Event{
+				ID:      "duplicate",
+				Topic:   "topic",
+				Payload: []byte{byte(i)},
+			})
+			if err != nil && err != ErrAlreadyExists {
+				errs <- fmt.Errorf("pub duplicate %d: %v", i, err)
+			}
+			if err == nil {
+				errs <- nil
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	published := 0
+	for err := range errs {
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		published++
+	}
+	if published != 1 {
+		t.Errorf("expected exactly one duplicate to be published, got %d", published)
+	}
+
+	// The failed duplicates are dropped from the transaction instead of splitting it up, so the
+	// writes should be committed together. Allow for a goroutine that missed the window.
+	after, err := db.Stats()
+	if err != nil {
+		t.Fatalf("stats: %v", err)
+	}
+	if commits := after.Commits - before.Commits; commits < 1 || commits > 2 {
+		t.Errorf("expected the writes to be grouped into one or two commits, got %d", commits)
+	}
+
+	channel := db.Channel("channel", "topic")
+	defer channel.Close()
+
+	for i := 0; i < 10; i++ {
+		_, err := channel.Get(fmt.Sprintf("event%d", i))
+		if err != nil {
+			t.Errorf("get event%d: %v", i, err)
+		}
+	}
+}
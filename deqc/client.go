@@ -57,6 +57,7 @@ import (
 	"github.com/gogo/protobuf/proto"
 	"gitlab.com/katcheCode/deq"
 	api "gitlab.com/katcheCode/deq/api/v1/deq"
+	"gitlab.com/katcheCode/deq/internal/data"
 	"google.golang.org/grpc"
 )
 
@@ -67,6 +68,23 @@ type Event struct {
 	CreateTime   time.Time
 	State        EventState
 	RequeueCount int
+	// Metadata holds arbitrary key-value pairs describing the event, such as trace context, a tenant
+	// ID or the identity of its producer.
+	Metadata map[string]string
+	// RequeueLimit is the number of times the event is requeued on a channel before it is dequeued
+	// with EventStateDequeuedError. Defaults to the requeue limit of the event's topic, or the
+	// server's default requeue limit. Set to -1 for no limit.
+	RequeueLimit int
+	// DeliverAt is the time the event is first sent to subscribers. Until then, the event is
+	// scheduled. If it is the zero time, the event is sent immediately.
+	DeliverAt time.Time
+	// Scheduled is true if DeliverAt was after the time the server retrieved the event, so the event
+	// hadn't been sent to subscribers yet. It is output only, and isn't compared by Equal.
+	Scheduled bool
+	// Priority is the priority of the event on its channels. Queued events with a higher Priority are
+	// sent to subscribers first, except for up to 20 events the server has already buffered for the
+	// channel. Defaults to zero, and may be negative.
+	Priority int
 }
 
 // EventState is the queue state of an event
@@ -95,7 +113,23 @@ func (e Event) Equal(other Event) bool {
 		proto.Equal(e.Msg, other.Msg) &&
 		e.CreateTime == other.CreateTime &&
 		e.State == other.State &&
-		e.RequeueCount == other.RequeueCount
+		e.RequeueCount == other.RequeueCount &&
+		e.RequeueLimit == other.RequeueLimit &&
+		e.DeliverAt.Equal(other.DeliverAt) &&
+		e.Priority == other.Priority &&
+		equalMetadata(e.Metadata, other.Metadata)
+}
+
+func equalMetadata(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if other, ok := b[k]; !ok || other != v {
+			return false
+		}
+	}
+	return true
 }
 
 // Message is a message payload that is sent by deq
@@ -121,6 +155,45 @@ func (c *Client) Pub(ctx context.Context, e deq.Event) (deq.Event, error) {
 		return deq.Event{}, fmt.Errorf("e.ID is required")
 	}
 
+	event, err := c.client.Pub(ctx, &api.PubRequest{
+		Event: eventToAPI(e),
+	})
+	if err != nil {
+		return deq.Event{}, err
+	}
+
+	return apiToEvent(event), nil
+}
+
+// PubBatch publishes a batch of events atomically. Either all of the events are published, or none
+// of them are. See deq.Store.PubBatch for details.
+func (c *Client) PubBatch(ctx context.Context, events []deq.Event) ([]deq.Event, error) {
+
+	in := make([]*api.Event, len(events))
+	for i, e := range events {
+		if e.ID == "" {
+			return nil, fmt.Errorf("events[%d].ID is required", i)
+		}
+		in[i] = eventToAPI(e)
+	}
+
+	resp, err := c.client.PubBatch(ctx, &api.PubBatchRequest{
+		Events: in,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]deq.Event, len(resp.Events))
+	for i, e := range resp.Events {
+		results[i] = apiToEvent(e)
+	}
+
+	return results, nil
+}
+
+func eventToAPI(e deq.Event) *api.Event {
+
 	defaultState := api.EventState_UNSPECIFIED_STATE
 	switch e.DefaultState {
 	case deq.EventStateDequeuedOK:
@@ -136,18 +209,20 @@ func (c *Client) Pub(ctx context.Context, e deq.Event) (deq.Event, error) {
 		createTime = e.CreateTime.UnixNano()
 	}
 
-	event, err := c.client.Pub(ctx, &api.PubRequest{
-		Event: &api.Event{
-			Id:           e.ID,
-			Topic:        e.Topic,
-			CreateTime:   createTime,
-			Payload:      e.Payload,
-			DefaultState: defaultState,
-		},
-	})
-	if err != nil {
-		return deq.Event{}, err
+	return &api.Event{
+		Id:           e.ID,
+		Topic:        e.Topic,
+		CreateTime:   createTime,
+		Payload:      e.Payload,
+		DefaultState: defaultState,
+		Metadata:     e.Metadata,
+		RequeueLimit: int32(e.RequeueLimit),
+		DeliverAt:    data.UnixNano(e.DeliverAt),
+		Priority:     int32(e.Priority),
 	}
+}
+
+func apiToEvent(event *api.Event) deq.Event {
 
 	state := deq.EventStateUnspecified
 	switch event.State {
@@ -176,5 +251,9 @@ func (c *Client) Pub(ctx context.Context, e deq.Event) (deq.Event, error) {
 		Payload:      event.Payload,
 		DefaultState: dState,
 		State:        state,
-	}, nil
+		Metadata:     event.Metadata,
+		RequeueLimit: int(event.RequeueLimit),
+		DeliverAt:    data.FromUnixNano(event.DeliverAt),
+		Priority:     int(event.Priority),
+	}
 }
@@ -7,6 +7,8 @@ import (
 
 	"github.com/gogo/protobuf/proto"
 	api "gitlab.com/katcheCode/deq/api/v1/deq"
+	"gitlab.com/katcheCode/deq/internal/data"
+	"gitlab.com/katcheCode/deq/trace"
 	"google.golang.org/grpc"
 )
 
@@ -31,6 +33,10 @@ func NewPublisher(conn *grpc.ClientConn, opts PublisherOpts) *Publisher {
 }
 
 // Pub publishes a new event.
+//
+// If ctx carries a span context (see package gitlab.com/katcheCode/deq/trace) and e.Metadata doesn't
+// have a traceparent, the span context is recorded in the published event's Metadata, so that
+// subscribers continue the caller's trace.
 func (p *Publisher) Pub(ctx context.Context, e Event) (Event, error) {
 
 	if e.ID == "" {
@@ -49,10 +55,14 @@ func (p *Publisher) Pub(ctx context.Context, e Event) (Event, error) {
 
 	event, err := p.client.Pub(ctx, &api.PubRequest{
 		Event: &api.Event{
-			Id:         e.ID,
-			Topic:      proto.MessageName(e.Msg),
-			CreateTime: createTime,
-			Payload:    payload,
+			Id:           e.ID,
+			Topic:        proto.MessageName(e.Msg),
+			CreateTime:   createTime,
+			Payload:      payload,
+			Metadata:     injectTraceContext(ctx, e.Metadata),
+			RequeueLimit: int32(e.RequeueLimit),
+			DeliverAt:    data.UnixNano(e.DeliverAt),
+			Priority:     int32(e.Priority),
 		},
 		AwaitChannel: p.opts.AwaitChannel,
 	})
@@ -63,6 +73,69 @@ func (p *Publisher) Pub(ctx context.Context, e Event) (Event, error) {
 	return protoToEvent(event, e.Msg, nil), nil
 }
 
+// PubBatch publishes a batch of events atomically. Either all of the events are published, or none
+// of them are. The events may have different message types, and therefore different topics.
+//
+// The returned events are in the same order as events. The span context of ctx is recorded on each
+// event, as in Pub.
+func (p *Publisher) PubBatch(ctx context.Context, events []Event) ([]Event, error) {
+
+	in := make([]*api.Event, len(events))
+	for i, e := range events {
+		if e.ID == "" {
+			return nil, fmt.Errorf("events[%d].ID is required", i)
+		}
+
+		payload, err := proto.Marshal(e.Msg)
+		if err != nil {
+			return nil, fmt.Errorf("marshal payload of events[%d]: %v", i, err)
+		}
+
+		var createTime int64
+		if !e.CreateTime.IsZero() {
+			createTime = e.CreateTime.UnixNano()
+		}
+
+		in[i] = &api.Event{
+			Id:           e.ID,
+			Topic:        proto.MessageName(e.Msg),
+			CreateTime:   createTime,
+			Payload:      payload,
+			Metadata:     injectTraceContext(ctx, e.Metadata),
+			RequeueLimit: int32(e.RequeueLimit),
+			DeliverAt:    data.UnixNano(e.DeliverAt),
+			Priority:     int32(e.Priority),
+		}
+	}
+
+	resp, err := p.client.PubBatch(ctx, &api.PubBatchRequest{
+		Events: in,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]Event, len(resp.Events))
+	for i, event := range resp.Events {
+		results[i] = protoToEvent(event, events[i].Msg, nil)
+	}
+
+	return results, nil
+}
+
+// injectTraceContext returns metadata with the span context of ctx recorded in it, unless metadata
+// already has a traceparent.
+func injectTraceContext(ctx context.Context, metadata map[string]string) map[string]string {
+	if _, ok := metadata[trace.TraceparentKey]; ok {
+		return metadata
+	}
+	sc, ok := trace.FromContext(ctx)
+	if !ok {
+		return metadata
+	}
+	return trace.Inject(sc, metadata)
+}
+
 func protoToEvent(event *api.Event, msg Message, sub *Subscriber) Event {
 
 	var state EventState
@@ -82,5 +155,10 @@ func protoToEvent(event *api.Event, msg Message, sub *Subscriber) Event {
 		CreateTime:   time.Unix(0, event.CreateTime),
 		State:        state,
 		RequeueCount: int(event.RequeueCount),
+		Metadata:     event.Metadata,
+		RequeueLimit: int(event.RequeueLimit),
+		DeliverAt:    data.FromUnixNano(event.DeliverAt),
+		Scheduled:    event.Scheduled,
+		Priority:     int(event.Priority),
 	}
 }
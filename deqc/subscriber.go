@@ -12,6 +12,7 @@ import (
 	"github.com/gogo/protobuf/proto"
 	"gitlab.com/katcheCode/deq/ack"
 	api "gitlab.com/katcheCode/deq/api/v1/deq"
+	"gitlab.com/katcheCode/deq/trace"
 	"google.golang.org/grpc"
 )
 
@@ -19,6 +20,7 @@ import (
 type Subscriber struct {
 	client api.DEQClient
 	opts   SubscriberOpts
+	tracer *trace.Tracer
 }
 
 // SubscriberOpts are options for a Subscriber
@@ -27,6 +29,12 @@ type SubscriberOpts struct {
 	Channel      string
 	IdleTimeout  time.Duration
 	RequeueDelay time.Duration
+	// TraceExporter exports the spans started by Sub for handled events that carry trace context. If
+	// it is nil, trace context is still propagated but spans aren't exported.
+	TraceExporter trace.Exporter
+	// DeadLetterTopic is the topic that events are published to when they reach their requeue limit
+	// on Channel. Defaults to the dead letter topic configured for the subscribed topic.
+	DeadLetterTopic string
 	// Follow       bool
 	// MinID   string
 	// MaxID   string
@@ -38,7 +46,7 @@ func NewSubscriber(conn *grpc.ClientConn, opts SubscriberOpts) *Subscriber {
 	if opts.Channel == "" {
 		panic("opts.Channel is required")
 	}
-	return &Subscriber{api.NewDEQClient(conn), opts}
+	return &Subscriber{api.NewDEQClient(conn), opts, trace.NewTracer(opts.TraceExporter)}
 }
 
 // Handler is a handler for DEQ events.
@@ -63,6 +71,12 @@ func (f HandlerFunc) HandleEvent(e Event) ack.Code {
 //
 // m is used to determine the subscribed type url. All messages passed to handler are guarenteed to be of the same concrete type as m
 // Because github.com/gogo/protobuf is used to lookup the typeURL and concrete type of m, m must be a registered type with the same instance of gogo/proto as this binary (ie. no vendoring or github.com/golang/protobuf)
+//
+// If an event carries W3C trace context (see package gitlab.com/katcheCode/deq/trace), Sub starts a
+// span for its handler that continues the event's trace, and exports it to
+// SubscriberOpts.TraceExporter once the event is acknowledged. The Metadata of the event passed to
+// handler carries the handler span's trace context. To publish events that are part of the same
+// trace, pass trace.ContextWithMetadata(ctx, e.Metadata) to Publisher.Pub.
 func (sub *Subscriber) Sub(ctx context.Context, m Message, handler HandlerFunc) error {
 
 	msgName := proto.MessageName(m)
@@ -73,6 +87,7 @@ func (sub *Subscriber) Sub(ctx context.Context, m Message, handler HandlerFunc)
 		IdleTimeoutMilliseconds:  int32(sub.opts.IdleTimeout / time.Millisecond),
 		Follow:                   sub.opts.IdleTimeout <= 0,
 		RequeueDelayMilliseconds: int32(sub.opts.RequeueDelay / time.Millisecond),
+		DeadLetterTopic:          sub.opts.DeadLetterTopic,
 		// MinId:   c.opts.MinID,
 		// MaxId:   c.opts.MaxID,
 		Topic: msgName,
@@ -115,7 +130,12 @@ func (sub *Subscriber) Sub(ctx context.Context, m Message, handler HandlerFunc)
 				return
 			}
 
-			code := handler.HandleEvent(protoToEvent(event, msg, sub))
+			e, span := sub.startHandlerSpan(protoToEvent(event, msg, sub))
+			if span != nil {
+				defer span.End()
+			}
+
+			code := handler.HandleEvent(e)
 
 			_, err = sub.client.Ack(ctx, &api.AckRequest{
 				Channel: sub.opts.Channel,
@@ -123,6 +143,12 @@ func (sub *Subscriber) Sub(ctx context.Context, m Message, handler HandlerFunc)
 				EventId: event.Id,
 				Code:    api.AckCode(code),
 			})
+			if span != nil {
+				span.SetAttribute("deq.ack_code", api.AckCode(code).String())
+				if err != nil {
+					span.SetAttribute("error", err.Error())
+				}
+			}
 			if err != nil {
 				// TODO: How to expose error?
 				log.Printf("deq: event %s handled: ack: %v", event.Id, err)
@@ -132,6 +158,23 @@ func (sub *Subscriber) Sub(ctx context.Context, m Message, handler HandlerFunc)
 	}
 }
 
+// startHandlerSpan starts the span of a handler of e if e carries trace context, returning a copy of
+// e whose trace context is the new span's. span is nil if e doesn't carry trace context.
+func (sub *Subscriber) startHandlerSpan(e Event) (handled Event, span *trace.Span) {
+	if _, ok := trace.Extract(e.Metadata); !ok {
+		return e, nil
+	}
+
+	ctx := trace.ContextWithMetadata(context.Background(), e.Metadata)
+	_, span = sub.tracer.StartSpan(ctx, "deqc.Sub "+e.Topic())
+	span.SetAttribute("deq.topic", e.Topic())
+	span.SetAttribute("deq.channel", sub.opts.Channel)
+	span.SetAttribute("deq.event_id", e.ID)
+
+	e.Metadata = trace.Inject(span.SpanContext, e.Metadata)
+	return e, span
+}
+
 // Get returns an event for a given id and message type (topic).
 //
 // The message topic is inferred from the type of `result`. The event payload is also deserialized
@@ -0,0 +1,356 @@
+package deq
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"gitlab.com/katcheCode/deq/internal/data"
+	"gitlab.com/katcheCode/deq/internal/storage"
+)
+
+// KeyProvider provides the keys used to encrypt the payloads of events at rest. Keys must be 16, 24
+// or 32 bytes long, to select AES-128, AES-192 or AES-256.
+//
+// Each payload is tagged with the ID of the key it was encrypted with, so the current key can be
+// rotated while events encrypted with previous keys remain readable. A key must remain available
+// from Key until every event encrypted with it is deleted or re-encrypted with Store.Reencrypt.
+//
+// Index values are part of the keys that IndexIter iterates over in order, so they are not
+// encrypted. Topics whose index values are sensitive should index on a hash of the value instead.
+//
+// All methods of KeyProvider must be safe for concurrent use.
+type KeyProvider interface {
+	// CurrentKey returns the ID and value of the key new payloads are encrypted with. id must not be
+	// empty.
+	CurrentKey() (id string, key []byte, err error)
+	// Key returns the key with the given ID.
+	Key(id string) ([]byte, error)
+}
+
+// KeyRing is a KeyProvider with a fixed set of keys.
+type KeyRing struct {
+	// Current is the ID of the key new payloads are encrypted with.
+	Current string
+	// Keys maps key IDs to keys.
+	Keys map[string][]byte
+}
+
+// CurrentKey implements KeyProvider.
+func (r *KeyRing) CurrentKey() (string, []byte, error) {
+	key, err := r.Key(r.Current)
+	if err != nil {
+		return "", nil, err
+	}
+	return r.Current, key, nil
+}
+
+// Key implements KeyProvider.
+func (r *KeyRing) Key(id string) ([]byte, error) {
+	key, ok := r.Keys[id]
+	if !ok || id == "" {
+		return nil, fmt.Errorf("key %q not found", id)
+	}
+	return key, nil
+}
+
+// ParseKeyRing parses a KeyRing from a comma separated list of id=key pairs, where each key is
+// base64 encoded. current is the ID of the current key, which must be one of the listed keys. For
+// example:
+//
+//   keys, err := deq.ParseKeyRing("2019-02", "2019-01=<base64 key>,2019-02=<base64 key>")
+func ParseKeyRing(current, keys string) (*KeyRing, error) {
+	r := &KeyRing{
+		Current: current,
+		Keys:    make(map[string][]byte),
+	}
+
+	for _, pair := range strings.Split(keys, ",") {
+		i := strings.Index(pair, "=")
+		if i < 0 {
+			return nil, fmt.Errorf("%q is not an id=key pair", pair)
+		}
+		id := strings.TrimSpace(pair[:i])
+		if id == "" {
+			return nil, errors.New("key id is empty")
+		}
+		key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(pair[i+1:]))
+		if err != nil {
+			return nil, fmt.Errorf("decode key %q: %v", id, err)
+		}
+		if _, err := aes.NewCipher(key); err != nil {
+			return nil, fmt.Errorf("key %q: %v", id, err)
+		}
+		r.Keys[id] = key
+	}
+
+	if _, ok := r.Keys[current]; !ok {
+		return nil, fmt.Errorf("current key %q is not listed", current)
+	}
+
+	return r, nil
+}
+
+// encryptPayload encrypts the payload of e with the current key of keys, using the event's raw
+// key as additional data so that the payload can't be moved to another event.
+func encryptPayload(e *data.EventPayload, keys KeyProvider, eventKey []byte) error {
+	id, key, err := keys.CurrentKey()
+	if err != nil {
+		return fmt.Errorf("get current key: %v", err)
+	}
+	if id == "" {
+		return errors.New("current key has an empty id")
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return fmt.Errorf("key %q: %v", id, err)
+	}
+
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(e.Payload)+aead.Overhead())
+	_, err = io.ReadFull(rand.Reader, nonce)
+	if err != nil {
+		return fmt.Errorf("generate nonce: %v", err)
+	}
+
+	e.Payload = aead.Seal(nonce, nonce, e.Payload, eventKey)
+	e.KeyId = id
+	return nil
+}
+
+// decryptPayload decrypts the payload of e if it is encrypted.
+func decryptPayload(e *data.EventPayload, keys KeyProvider, eventKey []byte) error {
+	if e.KeyId == "" {
+		return nil
+	}
+	if keys == nil {
+		return fmt.Errorf("payload is encrypted with key %q, but the store has no KeyProvider", e.KeyId)
+	}
+
+	key, err := keys.Key(e.KeyId)
+	if err != nil {
+		return fmt.Errorf("get key: %v", err)
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return fmt.Errorf("key %q: %v", e.KeyId, err)
+	}
+
+	if len(e.Payload) < aead.NonceSize() {
+		return errors.New("encrypted payload is too short")
+	}
+	nonce, ciphertext := e.Payload[:aead.NonceSize()], e.Payload[aead.NonceSize():]
+	payload, err := aead.Open(nil, nonce, ciphertext, eventKey)
+	if err != nil {
+		return fmt.Errorf("decrypt with key %q: %v", e.KeyId, err)
+	}
+
+	e.Payload = payload
+	e.KeyId = ""
+	return nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// marshalEventPayload marshals an event payload to be stored on disk at eventKey. The payload is
+// compressed with compression, then encrypted if keys is non-nil. Its original size is recorded so
+// it can be known without decrypting the payload.
+func marshalEventPayload(payload *data.EventPayload, eventKey []byte, compression Compression, keys KeyProvider) ([]byte, error) {
+	payload.PayloadSize = int64(len(payload.Payload))
+	err := compressPayload(payload, compression)
+	if err != nil {
+		return nil, fmt.Errorf("compress event payload: %v", err)
+	}
+	if keys != nil {
+		err = encryptPayload(payload, keys, eventKey)
+		if err != nil {
+			return nil, fmt.Errorf("encrypt event payload: %v", err)
+		}
+	}
+	val, err := proto.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("marshal event payload: %v", err)
+	}
+	return val, nil
+}
+
+// unmarshalEventPayload unmarshals an event payload stored on disk at eventKey, decrypting and
+// decompressing its payload.
+func unmarshalEventPayload(val, eventKey []byte, keys KeyProvider, payload *data.EventPayload) error {
+	err := proto.Unmarshal(val, payload)
+	if err != nil {
+		return fmt.Errorf("unmarshal event payload: %v", err)
+	}
+	return decodeEventPayload(payload, eventKey, keys)
+}
+
+// decodeEventPayload decrypts and decompresses the payload of an event payload stored at eventKey.
+func decodeEventPayload(payload *data.EventPayload, eventKey []byte, keys KeyProvider) error {
+	err := decryptPayload(payload, keys, eventKey)
+	if err != nil {
+		return fmt.Errorf("decrypt event payload: %v", err)
+	}
+	err = decompressPayload(payload)
+	if err != nil {
+		return fmt.Errorf("decompress event payload: %v", err)
+	}
+	return nil
+}
+
+// payloadSize returns the size of the payload of a stored event payload before it was compressed
+// and encrypted. It never needs a key, so the stats of an event can be updated even after the key
+// it was encrypted with is gone.
+func payloadSize(payload *data.EventPayload) (int64, error) {
+	// Events written before the size was recorded are never encrypted.
+	if payload.PayloadSize != 0 || payload.KeyId != "" {
+		return payload.PayloadSize, nil
+	}
+	if payload.Codec == data.Codec_CODEC_NONE {
+		return int64(len(payload.Payload)), nil
+	}
+	decompressed := data.EventPayload{
+		Payload: payload.Payload,
+		Codec:   payload.Codec,
+	}
+	err := decompressPayload(&decompressed)
+	if err != nil {
+		return 0, err
+	}
+	return int64(len(decompressed.Payload)), nil
+}
+
+// reencryptBatchSize is the maximum number of events re-encrypted in a single transaction.
+const reencryptBatchSize = 100
+
+// Reencrypt re-encrypts the payload of every event that isn't encrypted with the current key of
+// the store's KeyProvider, including events that aren't encrypted at all. Once Reencrypt returns,
+// keys other than the current key are no longer needed. Payloads keep the compression they were
+// written with.
+//
+// Events are re-encrypted in small batches, each in their own transaction, so Reencrypt may be
+// called while the store is in use. Reencrypt returns the number of events re-encrypted.
+func (s *Store) Reencrypt(ctx context.Context) (int, error) {
+	if s.keys == nil {
+		return 0, errors.New("the store has no KeyProvider")
+	}
+
+	current, _, err := s.keys.CurrentKey()
+	if err != nil {
+		return 0, fmt.Errorf("get current key: %v", err)
+	}
+
+	total := 0
+	cursor := data.EventPrefix
+	for {
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+
+		count, next, err := s.reencryptBatch(ctx, cursor, current)
+		total += count
+		if err != nil {
+			return total, err
+		}
+		if next == nil {
+			return total, nil
+		}
+		cursor = next
+	}
+}
+
+// reencryptBatch re-encrypts up to reencryptBatchSize events not encrypted with the key current,
+// starting at cursor, or as many as it finds within maxWriteHold. It returns the cursor of the next
+// batch, or nil if there are no events left.
+func (s *Store) reencryptBatch(ctx context.Context, cursor []byte, current string) (int, []byte, error) {
+	var count int
+	var next []byte
+	err := s.write(ctx, func(txn storage.Txn) error {
+		count, next = 0, nil
+
+		type pending struct {
+			key     []byte
+			payload data.EventPayload
+		}
+		var batch []pending
+		deadline := time.Now().Add(maxWriteHold)
+		scanned := 0
+
+		it := txn.NewIterator(storage.DefaultIteratorOptions)
+		for it.Seek(cursor); it.ValidForPrefix(data.EventPrefix); it.Next() {
+			// Events that are already re-encrypted are skipped, so the deadline also applies to
+			// batches that are still empty.
+			if len(batch) >= reencryptBatchSize || (scanned > 0 && time.Now().After(deadline)) {
+				next = it.Item().KeyCopy(nil)
+				break
+			}
+			scanned++
+
+			item := it.Item()
+			val, err := item.Value()
+			if err != nil {
+				it.Close()
+				return err
+			}
+			p := pending{key: item.KeyCopy(nil)}
+			err = proto.Unmarshal(val, &p.payload)
+			if err != nil {
+				it.Close()
+				return fmt.Errorf("unmarshal event payload %s: %v", p.key, err)
+			}
+			if p.payload.KeyId == current {
+				continue
+			}
+			err = decryptPayload(&p.payload, s.keys, p.key)
+			if err != nil {
+				it.Close()
+				return fmt.Errorf("decrypt event payload %s: %v", p.key, err)
+			}
+			// Record the size of payloads written before it was, since it can't be computed once
+			// they are encrypted.
+			p.payload.PayloadSize, err = payloadSize(&p.payload)
+			if err != nil {
+				it.Close()
+				return fmt.Errorf("get size of event payload %s: %v", p.key, err)
+			}
+			batch = append(batch, p)
+		}
+		it.Close()
+
+		for i := range batch {
+			err := encryptPayload(&batch[i].payload, s.keys, batch[i].key)
+			if err != nil {
+				return fmt.Errorf("encrypt event payload %s: %v", batch[i].key, err)
+			}
+			val, err := proto.Marshal(&batch[i].payload)
+			if err != nil {
+				return fmt.Errorf("marshal event payload: %v", err)
+			}
+			err = txn.Set(batch[i].key, val)
+			if err != nil {
+				return err
+			}
+		}
+		count = len(batch)
+
+		return nil
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return count, next, nil
+}
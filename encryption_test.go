@@ -0,0 +1,234 @@
+package deq
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"gitlab.com/katcheCode/deq/internal/data"
+)
+
+func TestEncryption(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	dir, err := ioutil.TempDir("", "test-encryption")
+	if err != nil {
+		t.Fatalf("create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	key1 := bytes.Repeat([]byte{1}, 32)
+	key2 := bytes.Repeat([]byte{2}, 16)
+
+	payload := bytes.Repeat([]byte("secret "), 20)
+
+	// Write an event with the first key.
+	db, err := Open(Options{
+		Dir:         dir,
+		KeyProvider: &KeyRing{Current: "key1", Keys: map[string][]byte{"key1": key1}},
+	})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	_, err = db.Pub(ctx, Event{ID: "event1", Topic: "TopicA", Payload: payload})
+	if err != nil {
+		t.Fatalf("pub event1: %v", err)
+	}
+	db.Close()
+
+	// Rotate to the second key, keeping the first so event1 stays readable.
+	db, err = Open(Options{
+		Dir:                dir,
+		DefaultCompression: CompressionGzip,
+		KeyProvider: &KeyRing{Current: "key2", Keys: map[string][]byte{
+			"key1": key1,
+			"key2": key2,
+		}},
+	})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	_, err = db.Pub(ctx, Event{ID: "event2", Topic: "TopicA", Payload: payload})
+	if err != nil {
+		t.Fatalf("pub event2: %v", err)
+	}
+
+	expectKeyIDs(t, db, map[string]string{"event1": "key1", "event2": "key2"}, payload)
+	expectPayloads(t, db, payload)
+
+	count, err := db.Reencrypt(ctx)
+	if err != nil {
+		t.Fatalf("reencrypt: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("reencrypt: expected 1 event re-encrypted, got %d", count)
+	}
+
+	expectKeyIDs(t, db, map[string]string{"event1": "key2", "event2": "key2"}, payload)
+	stats, err := db.Stats()
+	if err != nil {
+		t.Fatalf("get stats: %v", err)
+	}
+	if stats.PayloadBytes != int64(2*len(payload)) {
+		t.Errorf("stats: expected %d payload bytes, got %d", 2*len(payload), stats.PayloadBytes)
+	}
+	db.Close()
+
+	// Once every event is re-encrypted, the first key is no longer needed.
+	db, err = Open(Options{
+		Dir:         dir,
+		KeyProvider: &KeyRing{Current: "key2", Keys: map[string][]byte{"key2": key2}},
+	})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	expectPayloads(t, db, payload)
+	db.Close()
+
+	// Without the keys, payloads can't be read.
+	db, err = Open(Options{
+		Dir: dir,
+	})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer db.Close()
+
+	channel := db.Channel("channel", "TopicA")
+	defer channel.Close()
+	_, err = channel.Get("event1")
+	if err == nil {
+		t.Errorf("get without KeyProvider: expected error")
+	}
+
+	// Events can still be deleted, and their stats updated, without the keys.
+	err = db.Del("TopicA", "event1")
+	if err != nil {
+		t.Fatalf("del without KeyProvider: %v", err)
+	}
+	_, err = db.DelTopic(ctx, "TopicA", DelTopicOpts{})
+	if err != nil {
+		t.Fatalf("del topic without KeyProvider: %v", err)
+	}
+	stats, err = db.Stats()
+	if err != nil {
+		t.Fatalf("get stats: %v", err)
+	}
+	if stats.Events != 0 || stats.PayloadBytes != 0 {
+		t.Errorf("stats after delete: expected no events or payload bytes, got %d events and %d bytes", stats.Events, stats.PayloadBytes)
+	}
+}
+
+// expectKeyIDs checks which key each event on TopicA is stored with, and that the plaintext isn't
+// stored.
+func expectKeyIDs(t *testing.T, db *Store, expected map[string]string, plaintext []byte) {
+	t.Helper()
+
+	txn := db.db.NewTransaction(false)
+	defer txn.Discard()
+
+	for id, keyID := range expected {
+		eventTime, err := getEventTimePayload(txn, data.EventTimeKey{Topic: "TopicA", ID: id})
+		if err != nil {
+			t.Fatalf("get event time of %s: %v", id, err)
+		}
+		key, err := data.EventKey{
+			Topic:      "TopicA",
+			ID:         id,
+			CreateTime: time.Unix(0, eventTime.CreateTime),
+		}.Marshal(nil)
+		if err != nil {
+			t.Fatalf("marshal key: %v", err)
+		}
+		item, err := txn.Get(key)
+		if err != nil {
+			t.Fatalf("get %s: %v", id, err)
+		}
+		val, err := item.Value()
+		if err != nil {
+			t.Fatalf("get value of %s: %v", id, err)
+		}
+		if bytes.Contains(val, []byte("secret")) {
+			t.Errorf("%s is stored in plaintext", id)
+		}
+		var payload data.EventPayload
+		err = payload.Unmarshal(val)
+		if err != nil {
+			t.Fatalf("unmarshal %s: %v", id, err)
+		}
+		if payload.KeyId != keyID {
+			t.Errorf("key of %s: expected %q, got %q", id, keyID, payload.KeyId)
+		}
+	}
+}
+
+// expectPayloads checks that every way of reading the events on TopicA decrypts them.
+func expectPayloads(t *testing.T, db *Store, expected []byte) {
+	t.Helper()
+
+	channel := db.Channel("channel", "TopicA")
+	defer channel.Close()
+
+	for _, id := range []string{"event1", "event2"} {
+		e, err := channel.Get(id)
+		if err != nil {
+			t.Fatalf("get %s: %v", id, err)
+		}
+		if !cmp.Equal(expected, e.Payload) {
+			t.Errorf("get %s: payload:\n%s", id, cmp.Diff(expected, e.Payload))
+		}
+	}
+
+	iter := channel.NewEventIter(DefaultIterOpts)
+	defer iter.Close()
+	for iter.Next() {
+		if !cmp.Equal(expected, iter.Event().Payload) {
+			t.Errorf("iterate %s: payload:\n%s", iter.Event().ID, cmp.Diff(expected, iter.Event().Payload))
+		}
+	}
+	if iter.Err() != nil {
+		t.Fatalf("iterate: %v", iter.Err())
+	}
+}
+
+func TestParseKeyRing(t *testing.T) {
+	t.Parallel()
+
+	key1 := bytes.Repeat([]byte{1}, 32)
+	key2 := bytes.Repeat([]byte{2}, 16)
+	encoded := "key1=" + base64.StdEncoding.EncodeToString(key1) + ", key2=" + base64.StdEncoding.EncodeToString(key2)
+
+	keys, err := ParseKeyRing("key2", encoded)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	expected := &KeyRing{
+		Current: "key2",
+		Keys:    map[string][]byte{"key1": key1, "key2": key2},
+	}
+	if !cmp.Equal(expected, keys) {
+		t.Errorf("parse:\n%s", cmp.Diff(expected, keys))
+	}
+
+	for _, invalid := range []struct {
+		current, keys string
+	}{
+		{"key3", encoded},
+		{"key1", "key1"},
+		{"key1", "key1=not base64"},
+		{"key1", "key1=" + base64.StdEncoding.EncodeToString([]byte("too short"))},
+		{"", "=" + base64.StdEncoding.EncodeToString(key1)},
+	} {
+		_, err := ParseKeyRing(invalid.current, invalid.keys)
+		if err == nil {
+			t.Errorf("parse %q with current key %q: expected error", invalid.keys, invalid.current)
+		}
+	}
+}
@@ -18,6 +18,9 @@ type Event struct {
 	// event. Events can be iterated lexicographically by index using an IndexIter. Identical indexes
 	// are sorted by event ID. Indexes cannot contain the null character.
 	Indexes []string
+	// Metadata holds arbitrary key-value pairs describing the event, such as trace context, a tenant
+	// ID, the content type of Payload or the identity of its producer.
+	Metadata map[string]string
 	// CreateTime is the time the event was created.
 	// Defaults to time.Now()
 	CreateTime time.Time
@@ -30,6 +33,27 @@ type Event struct {
 	// RequeueCount is the number of attempts to send the event to the channel it is recieved on.
 	// Output only.
 	RequeueCount int
+	// RequeueLimit is the number of times the event is requeued on a channel before it is dequeued
+	// with EventStateDequeuedError. Defaults to the RequeueLimit of the TopicConfig of the event's
+	// topic, or the store's DefaultRequeueLimit if the topic has none. Set to -1 for no limit.
+	RequeueLimit int
+	// DeliverAt is the time the event is first sent to subscribers. Until then, the event is stored
+	// and can be retrieved with Get or an EventIter, but it is scheduled and isn't sent to the
+	// subscribers of any channel. If DeliverAt is the zero time or has passed, the event is sent
+	// immediately.
+	DeliverAt time.Time
+	// Priority is the priority of the event on its channels. Queued events with a higher Priority are
+	// sent to subscribers before queued events with a lower Priority, and events with the same
+	// Priority are sent in CreateTime order. Defaults to zero, and may be negative.
+	//
+	// Each channel buffers up to 20 events for its subscribers, which are sent in the order they
+	// were buffered, so an event may wait behind up to 20 events with a lower Priority.
+	Priority int
+}
+
+// Scheduled returns true if e has a DeliverAt time after now, and isn't sent to subscribers yet.
+func (e Event) Scheduled(now time.Time) bool {
+	return e.DeliverAt.After(now)
 }
 
 // EventState is the state of an event on a specific channel.
@@ -0,0 +1,513 @@
+package deq
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"gitlab.com/katcheCode/deq/internal/data"
+	"gitlab.com/katcheCode/deq/internal/storage"
+)
+
+// ExportFormat is the encoding of events written by Store.Export and read by Store.Import.
+//
+// Both formats are independent of the format of the database, so events exported by one version of
+// DEQ can be imported by any later version.
+type ExportFormat int
+
+const (
+	// ExportFormatProto encodes each event as a protocol buffer prefixed by its uvarint encoded
+	// length.
+	ExportFormatProto ExportFormat = iota
+	// ExportFormatJSON encodes each event as a JSON object on its own line, also known as JSON Lines.
+	// Create times are formatted as RFC 3339 timestamps and payloads are base64 encoded.
+	ExportFormatJSON
+)
+
+// importBatchSize is the maximum number of events imported in a single transaction.
+const importBatchSize = 100
+
+// maxExportEventSize is the maximum encoded size of an exported event.
+const maxExportEventSize = 1 << 30
+
+// ErrBadExport is returned when importing events that weren't exported in the expected format.
+var ErrBadExport = errors.New("bad export")
+
+// ExportOpts are options for Store.Export.
+type ExportOpts struct {
+	// Format is the format events are written in. Defaults to ExportFormatProto.
+	Format ExportFormat
+	// ChannelStates includes the state of each event on every channel it has been sent to.
+	ChannelStates bool
+}
+
+// Export writes the events of topic to w, sorted by create time. Export reads from a consistent
+// snapshot of the store, and returns the number of events written.
+func (s *Store) Export(ctx context.Context, w io.Writer, topic string, opts ExportOpts) (int, error) {
+
+	txn := s.db.NewTransaction(false)
+	defer txn.Discard()
+
+	var channels []string
+	if opts.ChannelStates {
+		var err error
+		channels, err = getChannelNames(txn)
+		if err != nil {
+			return 0, fmt.Errorf("get channels: %v", err)
+		}
+	}
+
+	prefix, err := data.EventPrefixTopic(topic)
+	if err != nil {
+		return 0, err
+	}
+
+	enc := newExportEncoder(w, opts.Format)
+
+	count := 0
+	it := txn.NewIterator(storage.DefaultIteratorOptions)
+	defer it.Close()
+	for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
+		if ctx.Err() != nil {
+			return count, ctx.Err()
+		}
+
+		item := it.Item()
+
+		var key data.EventKey
+		err := data.UnmarshalTo(item.Key(), &key)
+		if err != nil {
+			return count, fmt.Errorf("parse event key %s: %v", item.Key(), err)
+		}
+		val, err := item.Value()
+		if err != nil {
+			return count, err
+		}
+		var payload data.EventPayload
+		err = unmarshalEventPayload(val, item.Key(), s.keys, &payload)
+		if err != nil {
+			return count, err
+		}
+
+		e := data.ExportEvent{
+			Id:           key.ID,
+			Topic:        key.Topic,
+			CreateTime:   key.CreateTime.UnixNano(),
+			Payload:      payload.Payload,
+			Indexes:      payload.Indexes,
+			DefaultState: payload.DefaultEventState,
+			Metadata:     payload.Metadata,
+			RequeueLimit: payload.RequeueLimit,
+			DeliverAt:    payload.DeliverAt,
+			Priority:     payload.Priority,
+		}
+
+		for _, channel := range channels {
+			state, ok, err := getExportChannelState(txn, data.ChannelKey{
+				Channel: channel,
+				Topic:   key.Topic,
+				ID:      key.ID,
+			})
+			if err != nil {
+				return count, fmt.Errorf("get state of event %s on channel %s: %v", key.ID, channel, err)
+			}
+			if ok {
+				e.Channels = append(e.Channels, state)
+			}
+		}
+
+		err = enc.Encode(&e)
+		if err != nil {
+			return count, err
+		}
+		count++
+	}
+
+	err = enc.Flush()
+	if err != nil {
+		return count, err
+	}
+
+	return count, nil
+}
+
+// getExportChannelState returns the state of an event on a channel, or false if the event hasn't
+// been sent to the channel.
+func getExportChannelState(txn storage.Txn, key data.ChannelKey) (*data.ExportChannelState, bool, error) {
+	rawKey, err := key.Marshal(nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("marshal channel key: %v", err)
+	}
+	item, err := txn.Get(rawKey)
+	if err == storage.ErrKeyNotFound {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	val, err := item.Value()
+	if err != nil {
+		return nil, false, err
+	}
+	var payload data.ChannelPayload
+	err = proto.Unmarshal(val, &payload)
+	if err != nil {
+		return nil, false, fmt.Errorf("unmarshal channel payload: %v", err)
+	}
+
+	return &data.ExportChannelState{
+		Channel:      key.Channel,
+		State:        payload.EventState,
+		RequeueCount: payload.RequeueCount,
+	}, true, nil
+}
+
+// ImportOpts are options for Store.Import.
+type ImportOpts struct {
+	// Format is the format of the imported events. Defaults to ExportFormatProto.
+	Format ExportFormat
+}
+
+// Import publishes events exported by Export, along with their state on any exported channels.
+// Import returns the number of events read.
+//
+// Events are imported in batches, so a failed Import may have imported only some of the events.
+// Events that already exist with the same payload are skipped, so a failed Import can be retried.
+// If an event already exists with a different payload, Import returns ErrAlreadyExists. If an
+// event's payload doesn't match the schema of its topic, Import returns an *InvalidPayloadError.
+func (s *Store) Import(ctx context.Context, r io.Reader, opts ImportOpts) (int, error) {
+
+	dec := newExportDecoder(r, opts.Format)
+
+	count := 0
+	batch := make([]data.ExportEvent, 0, importBatchSize)
+	for {
+		if ctx.Err() != nil {
+			return count, ctx.Err()
+		}
+
+		var e data.ExportEvent
+		err := dec.Decode(&e)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return count, err
+		}
+
+		batch = append(batch, e)
+		if len(batch) == importBatchSize {
+			err = s.importBatch(ctx, batch)
+			if err != nil {
+				return count, err
+			}
+			count += len(batch)
+			batch = batch[:0]
+		}
+	}
+
+	if len(batch) > 0 {
+		err := s.importBatch(ctx, batch)
+		if err != nil {
+			return count, err
+		}
+		count += len(batch)
+	}
+
+	return count, nil
+}
+
+// importBatch publishes a batch of exported events and writes their channel states in a single
+// transaction.
+func (s *Store) importBatch(ctx context.Context, batch []data.ExportEvent) error {
+
+	events := make([]Event, len(batch))
+	for i, exported := range batch {
+		// proto3 decodes unrecognized enum values without an error, so the states of a corrupt export
+		// must be checked before they are converted or stored.
+		if !isExportState(exported.DefaultState) {
+			return ErrBadExport
+		}
+		for _, channel := range exported.Channels {
+			if !isExportState(channel.State) {
+				return ErrBadExport
+			}
+		}
+
+		events[i] = Event{
+			ID:           exported.Id,
+			Topic:        exported.Topic,
+			CreateTime:   time.Unix(0, exported.CreateTime),
+			Payload:      exported.Payload,
+			Indexes:      exported.Indexes,
+			DefaultState: protoToEventState(exported.DefaultState),
+			Metadata:     exported.Metadata,
+			RequeueLimit: int(exported.RequeueLimit),
+			DeliverAt:    data.FromUnixNano(exported.DeliverAt),
+			Priority:     int(exported.Priority),
+		}
+		err := s.prepareEvent(&events[i])
+		if err != nil {
+			return fmt.Errorf("event %s: %v", exported.Id, err)
+		}
+		err = s.validatePayload(&events[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	var written []bool
+	err := s.write(ctx, func(txn storage.Txn) error {
+		written = make([]bool, len(events))
+
+		for j := range events {
+			existing, err := writeOrMatchEvent(txn, &events[j], s.compression(events[j].Topic), s.keys)
+			if err == ErrAlreadyExists {
+				return ErrAlreadyExists
+			}
+			if err != nil {
+				return fmt.Errorf("event %s: %v", events[j].ID, err)
+			}
+			written[j] = existing == nil
+
+			for _, channel := range batch[j].Channels {
+				err := setChannelEvent(txn, data.ChannelKey{
+					Channel: channel.Channel,
+					Topic:   events[j].Topic,
+					ID:      events[j].ID,
+				}, data.ChannelPayload{
+					EventState:   channel.State,
+					RequeueCount: channel.RequeueCount,
+				})
+				if err != nil {
+					return fmt.Errorf("set state of event %s on channel %s: %v", events[j].ID, channel.Channel, err)
+				}
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for j := range events {
+		if written[j] {
+			e := events[j]
+			e.State = e.DefaultState
+			s.published(&e)
+		}
+	}
+
+	return nil
+}
+
+type exportEncoder struct {
+	w      *bufio.Writer
+	format ExportFormat
+	buf    []byte
+}
+
+func newExportEncoder(w io.Writer, format ExportFormat) *exportEncoder {
+	return &exportEncoder{
+		w:      bufio.NewWriter(w),
+		format: format,
+	}
+}
+
+func (enc *exportEncoder) Encode(e *data.ExportEvent) error {
+	switch enc.format {
+	case ExportFormatProto:
+		size := e.Size()
+		if cap(enc.buf) < binary.MaxVarintLen64+size {
+			enc.buf = make([]byte, binary.MaxVarintLen64+size)
+		}
+		n := binary.PutUvarint(enc.buf, uint64(size))
+		m, err := e.MarshalTo(enc.buf[n:])
+		if err != nil {
+			return fmt.Errorf("marshal event %s: %v", e.Id, err)
+		}
+		_, err = enc.w.Write(enc.buf[:n+m])
+		return err
+	case ExportFormatJSON:
+		buf, err := json.Marshal(exportToJSON(e))
+		if err != nil {
+			return fmt.Errorf("marshal event %s: %v", e.Id, err)
+		}
+		_, err = enc.w.Write(append(buf, '\n'))
+		return err
+	default:
+		return fmt.Errorf("unrecognized ExportFormat %d", enc.format)
+	}
+}
+
+func (enc *exportEncoder) Flush() error {
+	return enc.w.Flush()
+}
+
+type exportDecoder struct {
+	r      *bufio.Reader
+	json   *json.Decoder
+	format ExportFormat
+}
+
+func newExportDecoder(r io.Reader, format ExportFormat) *exportDecoder {
+	dec := &exportDecoder{
+		r:      bufio.NewReader(r),
+		format: format,
+	}
+	if format == ExportFormatJSON {
+		dec.json = json.NewDecoder(dec.r)
+	}
+	return dec
+}
+
+// Decode reads the next event into e. It returns io.EOF once all events have been read.
+func (dec *exportDecoder) Decode(e *data.ExportEvent) error {
+	switch dec.format {
+	case ExportFormatProto:
+		size, err := binary.ReadUvarint(dec.r)
+		if err == io.EOF {
+			return io.EOF
+		}
+		if err != nil {
+			return err
+		}
+		if size > maxExportEventSize {
+			return ErrBadExport
+		}
+		buf := make([]byte, size)
+		_, err = io.ReadFull(dec.r, buf)
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return ErrBadExport
+		}
+		if err != nil {
+			return err
+		}
+		err = proto.Unmarshal(buf, e)
+		if err != nil {
+			return ErrBadExport
+		}
+		return nil
+	case ExportFormatJSON:
+		var j exportedEventJSON
+		err := dec.json.Decode(&j)
+		if err == io.EOF {
+			return io.EOF
+		}
+		if err != nil {
+			return ErrBadExport
+		}
+		return jsonToExport(&j, e)
+	default:
+		return fmt.Errorf("unrecognized ExportFormat %d", dec.format)
+	}
+}
+
+// exportedEventJSON is the JSON representation of an exported event.
+type exportedEventJSON struct {
+	ID           string                     `json:"id"`
+	Topic        string                     `json:"topic"`
+	CreateTime   time.Time                  `json:"create_time"`
+	Payload      []byte                     `json:"payload,omitempty"`
+	Indexes      []string                   `json:"indexes,omitempty"`
+	DefaultState string                     `json:"default_state,omitempty"`
+	Metadata     map[string]string          `json:"metadata,omitempty"`
+	RequeueLimit int32                      `json:"requeue_limit,omitempty"`
+	DeliverAt    *time.Time                 `json:"deliver_at,omitempty"`
+	Priority     int32                      `json:"priority,omitempty"`
+	Channels     []exportedChannelStateJSON `json:"channels,omitempty"`
+}
+
+type exportedChannelStateJSON struct {
+	Channel      string `json:"channel"`
+	State        string `json:"state,omitempty"`
+	RequeueCount int32  `json:"requeue_count,omitempty"`
+}
+
+func exportToJSON(e *data.ExportEvent) *exportedEventJSON {
+	j := &exportedEventJSON{
+		ID:           e.Id,
+		Topic:        e.Topic,
+		CreateTime:   time.Unix(0, e.CreateTime).UTC(),
+		Payload:      e.Payload,
+		Indexes:      e.Indexes,
+		DefaultState: e.DefaultState.String(),
+		Metadata:     e.Metadata,
+		RequeueLimit: e.RequeueLimit,
+		Priority:     e.Priority,
+	}
+	if e.DeliverAt != 0 {
+		deliverAt := time.Unix(0, e.DeliverAt).UTC()
+		j.DeliverAt = &deliverAt
+	}
+	for _, channel := range e.Channels {
+		j.Channels = append(j.Channels, exportedChannelStateJSON{
+			Channel:      channel.Channel,
+			State:        channel.State.String(),
+			RequeueCount: channel.RequeueCount,
+		})
+	}
+	return j
+}
+
+func jsonToExport(j *exportedEventJSON, e *data.ExportEvent) error {
+	defaultState, err := parseExportState(j.DefaultState)
+	if err != nil {
+		return fmt.Errorf("event %s: %v", j.ID, err)
+	}
+	*e = data.ExportEvent{
+		Id:           j.ID,
+		Topic:        j.Topic,
+		CreateTime:   j.CreateTime.UnixNano(),
+		Payload:      j.Payload,
+		Indexes:      j.Indexes,
+		DefaultState: defaultState,
+		Metadata:     j.Metadata,
+		RequeueLimit: j.RequeueLimit,
+		Priority:     j.Priority,
+	}
+	if j.DeliverAt != nil {
+		e.DeliverAt = j.DeliverAt.UnixNano()
+	}
+	for _, channel := range j.Channels {
+		state, err := parseExportState(channel.State)
+		if err != nil {
+			return fmt.Errorf("event %s on channel %s: %v", j.ID, channel.Channel, err)
+		}
+		if state == data.EventState_UNSPECIFIED_STATE {
+			state = data.EventState_QUEUED
+		}
+		e.Channels = append(e.Channels, &data.ExportChannelState{
+			Channel:      channel.Channel,
+			State:        state,
+			RequeueCount: channel.RequeueCount,
+		})
+	}
+	return nil
+}
+
+// parseExportState parses the name of a data.EventState. An empty name is parsed as
+// data.EventState_UNSPECIFIED_STATE.
+// isExportState returns true if state is a recognized EventState.
+func isExportState(state data.EventState) bool {
+	_, ok := data.EventState_name[int32(state)]
+	return ok
+}
+
+func parseExportState(name string) (data.EventState, error) {
+	if name == "" {
+		return data.EventState_UNSPECIFIED_STATE, nil
+	}
+	state, ok := data.EventState_value[name]
+	if !ok {
+		return 0, fmt.Errorf("unrecognized state %q", name)
+	}
+	return data.EventState(state), nil
+}
@@ -0,0 +1,199 @@
+package deq
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"gitlab.com/katcheCode/deq/internal/data"
+)
+
+func TestExportImport(t *testing.T) {
+	t.Parallel()
+
+	for _, format := range []ExportFormat{ExportFormatProto, ExportFormatJSON} {
+		format := format
+		t.Run(fmt.Sprintf("format %d", format), func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+
+			db, discard := newTestDB()
+			defer discard()
+
+			// Round(0) gets rid of leap-second info, which will be lost in serialization
+			now := time.Now().Round(0)
+
+			_, err := db.PubBatch(ctx, []Event{
+				{
+					ID:         "event1",
+					Topic:      "topic",
+					CreateTime: now,
+					Payload:    []byte("payload1"),
+					Indexes:    []string{"index1"},
+					Metadata:   map[string]string{"tenant": "tenant1"},
+				},
+				{
+					ID:           "event2",
+					Topic:        "topic",
+					CreateTime:   now.Add(time.Second),
+					DefaultState: EventStateDequeuedOK,
+				},
+				{
+					ID:    "event3",
+					Topic: "other-topic",
+				},
+			})
+			if err != nil {
+				t.Fatalf("pub: %v", err)
+			}
+
+			channel := db.Channel("channel", "topic")
+			defer channel.Close()
+
+			err = channel.SetEventState("event1", EventStateDequeuedError)
+			if err != nil {
+				t.Fatalf("set event state: %v", err)
+			}
+
+			var buf bytes.Buffer
+			count, err := db.Export(ctx, &buf, "topic", ExportOpts{
+				Format:        format,
+				ChannelStates: true,
+			})
+			if err != nil {
+				t.Fatalf("export: %v", err)
+			}
+			if count != 2 {
+				t.Errorf("expected 2 events exported, got %d", count)
+			}
+
+			imported, discardImported := newTestDB()
+			defer discardImported()
+
+			count, err = imported.Import(ctx, &buf, ImportOpts{
+				Format: format,
+			})
+			if err != nil {
+				t.Fatalf("import: %v", err)
+			}
+			if count != 2 {
+				t.Errorf("expected 2 events imported, got %d", count)
+			}
+
+			expected := []Event{
+				{
+					ID:           "event1",
+					Topic:        "topic",
+					CreateTime:   now,
+					Payload:      []byte("payload1"),
+					Indexes:      []string{"index1"},
+					Metadata:     map[string]string{"tenant": "tenant1"},
+					DefaultState: EventStateQueued,
+					State:        EventStateDequeuedError,
+				},
+				{
+					ID:           "event2",
+					Topic:        "topic",
+					CreateTime:   now.Add(time.Second),
+					DefaultState: EventStateDequeuedOK,
+					State:        EventStateDequeuedOK,
+				},
+			}
+
+			importedChannel := imported.Channel("channel", "topic")
+			defer importedChannel.Close()
+
+			var actual []Event
+			iter := importedChannel.NewEventIter(DefaultIterOpts)
+			for iter.Next() {
+				actual = append(actual, iter.Event())
+			}
+			iter.Close()
+
+			if !cmp.Equal(expected, actual) {
+				t.Errorf("\n%s", cmp.Diff(expected, actual))
+			}
+		})
+	}
+}
+
+func TestImportFixture(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	db, discard := newTestDB()
+	defer discard()
+
+	f, err := os.Open(path.Join("testdata", "export", "events.jsonl"))
+	if err != nil {
+		t.Fatalf("open fixture: %v", err)
+	}
+	defer f.Close()
+
+	_, err = db.Import(ctx, f, ImportOpts{
+		Format: ExportFormatJSON,
+	})
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+
+	channel := db.Channel("channel1", "fixtures")
+	defer channel.Close()
+
+	expected := Event{
+		ID:           "event1",
+		Topic:        "fixtures",
+		CreateTime:   time.Date(2019, 1, 2, 3, 4, 5, 0, time.UTC),
+		Payload:      []byte("hello"),
+		Indexes:      []string{"index1"},
+		DefaultState: EventStateQueued,
+		State:        EventStateDequeuedOK,
+	}
+
+	actual, err := channel.Get("event1")
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if !cmp.Equal(expected, actual) {
+		t.Errorf("\n%s", cmp.Diff(expected, actual))
+	}
+}
+
+func TestImportBadState(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	db, discard := newTestDB()
+	defer discard()
+
+	for _, e := range []data.ExportEvent{
+		{Id: "event1", Topic: "topic", CreateTime: 1, DefaultState: 42},
+		{Id: "event2", Topic: "topic", CreateTime: 1, Channels: []*data.ExportChannelState{
+			{Channel: "channel", State: 42},
+		}},
+	} {
+		var buf bytes.Buffer
+		enc := newExportEncoder(&buf, ExportFormatProto)
+		err := enc.Encode(&e)
+		if err != nil {
+			t.Fatalf("encode %s: %v", e.Id, err)
+		}
+		err = enc.Flush()
+		if err != nil {
+			t.Fatalf("flush %s: %v", e.Id, err)
+		}
+
+		_, err = db.Import(ctx, &buf, ImportOpts{Format: ExportFormatProto})
+		if err != ErrBadExport {
+			t.Errorf("import %s: expected ErrBadExport, got %v", e.Id, err)
+		}
+	}
+}
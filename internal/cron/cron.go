@@ -0,0 +1,200 @@
+// Package cron parses cron expressions and computes the times they match.
+//
+// An expression has five fields separated by spaces:
+//
+//   minute (0-59) hour (0-23) day-of-month (1-31) month (1-12) day-of-week (0-6, Sunday is 0 or 7)
+//
+// Each field is *, a value, a range such as 1-5, or a comma separated list of values and ranges.
+// Any field may be followed by /step to only match every step-th value, such as */15. If both
+// day-of-month and day-of-week are restricted, a day matches if either matches.
+//
+// An expression may also be one of the descriptors @yearly (or @annually), @monthly, @weekly,
+// @daily (or @midnight) and @hourly.
+//
+// Times are matched in UTC.
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule is a parsed cron expression.
+type Schedule struct {
+	minute, hour, dom, month, dow uint64
+	// domStar and dowStar are true if day-of-month and day-of-week are unrestricted.
+	domStar, dowStar bool
+}
+
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// field is the range of values of a field of an expression.
+type field struct {
+	name     string
+	min, max int
+}
+
+var (
+	minuteField = field{"minute", 0, 59}
+	hourField   = field{"hour", 0, 23}
+	domField    = field{"day-of-month", 1, 31}
+	monthField  = field{"month", 1, 12}
+	dowField    = field{"day-of-week", 0, 7}
+)
+
+// Parse parses a cron expression.
+func Parse(expr string) (*Schedule, error) {
+	if strings.HasPrefix(expr, "@") {
+		expanded, ok := descriptors[expr]
+		if !ok {
+			return nil, fmt.Errorf("unrecognized descriptor %q", expr)
+		}
+		expr = expanded
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("expected 5 fields, got %d", len(fields))
+	}
+
+	var s Schedule
+	var err error
+	s.minute, err = parseField(fields[0], minuteField)
+	if err != nil {
+		return nil, err
+	}
+	s.hour, err = parseField(fields[1], hourField)
+	if err != nil {
+		return nil, err
+	}
+	s.dom, err = parseField(fields[2], domField)
+	if err != nil {
+		return nil, err
+	}
+	s.month, err = parseField(fields[3], monthField)
+	if err != nil {
+		return nil, err
+	}
+	s.dow, err = parseField(fields[4], dowField)
+	if err != nil {
+		return nil, err
+	}
+	// Sunday is both 0 and 7.
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+	s.domStar = fields[2] == "*"
+	s.dowStar = fields[4] == "*"
+
+	return &s, nil
+}
+
+// parseField returns the bitset of the values of f matched by expr.
+func parseField(expr string, f field) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(expr, ",") {
+		rangeExpr, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			rangeExpr = part[:i]
+			step, err = strconv.Atoi(part[i+1:])
+			if err != nil || step <= 0 {
+				return 0, fmt.Errorf("%s: invalid step in %q", f.name, part)
+			}
+		}
+
+		var low, high int
+		switch {
+		case rangeExpr == "*":
+			low, high = f.min, f.max
+		case strings.Contains(rangeExpr, "-"):
+			i := strings.Index(rangeExpr, "-")
+			var err error
+			low, err = parseValue(rangeExpr[:i], f)
+			if err != nil {
+				return 0, err
+			}
+			high, err = parseValue(rangeExpr[i+1:], f)
+			if err != nil {
+				return 0, err
+			}
+			if high < low {
+				return 0, fmt.Errorf("%s: invalid range %q", f.name, rangeExpr)
+			}
+		default:
+			var err error
+			low, err = parseValue(rangeExpr, f)
+			if err != nil {
+				return 0, err
+			}
+			high = low
+			if step > 1 {
+				high = f.max
+			}
+		}
+
+		for v := low; v <= high; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func parseValue(expr string, f field) (int, error) {
+	v, err := strconv.Atoi(expr)
+	if err != nil {
+		return 0, fmt.Errorf("%s: invalid value %q", f.name, expr)
+	}
+	if v < f.min || v > f.max {
+		return 0, fmt.Errorf("%s: %d is not between %d and %d", f.name, v, f.min, f.max)
+	}
+	return v, nil
+}
+
+// Next returns the first time after t that matches s, or the zero time if s matches no time within
+// five years of t.
+func (s *Schedule) Next(t time.Time) time.Time {
+	t = t.UTC().Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !s.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = t.Truncate(time.Hour).Add(time.Hour)
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+
+	return time.Time{}
+}
+
+func (s *Schedule) matchesDay(t time.Time) bool {
+	dom := s.dom&(1<<uint(t.Day())) != 0
+	dow := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return dom && dow
+	}
+	return dom || dow
+}
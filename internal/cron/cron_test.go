@@ -0,0 +1,69 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	for _, expr := range []string{
+		"* * * * *",
+		"*/15 0-6,18 1 1-12/2 1-5",
+		"5/10 * * * 7",
+		"@hourly",
+	} {
+		_, err := Parse(expr)
+		if err != nil {
+			t.Errorf("parse %q: %v", expr, err)
+		}
+	}
+
+	for _, expr := range []string{
+		"60 * * * *",
+		"* * * *",
+		"* * 0 * *",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"* * * * mon",
+		"@fortnightly",
+	} {
+		_, err := Parse(expr)
+		if err == nil {
+			t.Errorf("parse %q: expected error", expr)
+		}
+	}
+}
+
+func TestNext(t *testing.T) {
+	t.Parallel()
+
+	// A Thursday.
+	start := time.Date(2020, time.January, 2, 10, 30, 15, 0, time.UTC)
+
+	for _, tc := range []struct {
+		expr     string
+		expected time.Time
+	}{
+		{"* * * * *", time.Date(2020, time.January, 2, 10, 31, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2020, time.January, 2, 10, 45, 0, 0, time.UTC)},
+		{"30 10 * * *", time.Date(2020, time.January, 3, 10, 30, 0, 0, time.UTC)},
+		{"0 9-17/4 * * *", time.Date(2020, time.January, 2, 13, 0, 0, 0, time.UTC)},
+		{"0 0 * * 0", time.Date(2020, time.January, 5, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2020, time.January, 5, 0, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2020, time.February, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2020, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		// Day-of-month or day-of-week when both are restricted.
+		{"0 0 15 * 6", time.Date(2020, time.January, 4, 0, 0, 0, 0, time.UTC)},
+		{"0 0 30 2 *", time.Time{}},
+	} {
+		s, err := Parse(tc.expr)
+		if err != nil {
+			t.Fatalf("parse %q: %v", tc.expr, err)
+		}
+		if next := s.Next(start); !next.Equal(tc.expected) {
+			t.Errorf("%q: expected next %v, got %v", tc.expr, tc.expected, next)
+		}
+	}
+}
@@ -22,6 +22,64 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
 
+// Backoff identifies how the requeue delay of an event grows with its requeue count.
+type Backoff int32
+
+const (
+	Backoff_BACKOFF_UNSPECIFIED Backoff = 0
+	Backoff_BACKOFF_EXPONENTIAL Backoff = 1
+	Backoff_BACKOFF_LINEAR      Backoff = 2
+	Backoff_BACKOFF_CONSTANT    Backoff = 3
+)
+
+var Backoff_name = map[int32]string{
+	0: "BACKOFF_UNSPECIFIED",
+	1: "BACKOFF_EXPONENTIAL",
+	2: "BACKOFF_LINEAR",
+	3: "BACKOFF_CONSTANT",
+}
+
+var Backoff_value = map[string]int32{
+	"BACKOFF_UNSPECIFIED": 0,
+	"BACKOFF_EXPONENTIAL": 1,
+	"BACKOFF_LINEAR":      2,
+	"BACKOFF_CONSTANT":    3,
+}
+
+func (x Backoff) String() string {
+	return proto.EnumName(Backoff_name, int32(x))
+}
+
+func (Backoff) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_871986018790d2fd, []int{0}
+}
+
+// Codec identifies the codec an event's payload is compressed with.
+type Codec int32
+
+const (
+	Codec_CODEC_NONE Codec = 0
+	Codec_CODEC_GZIP Codec = 1
+)
+
+var Codec_name = map[int32]string{
+	0: "CODEC_NONE",
+	1: "CODEC_GZIP",
+}
+
+var Codec_value = map[string]int32{
+	"CODEC_NONE": 0,
+	"CODEC_GZIP": 1,
+}
+
+func (x Codec) String() string {
+	return proto.EnumName(Codec_name, int32(x))
+}
+
+func (Codec) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_871986018790d2fd, []int{1}
+}
+
 type EventState int32
 
 const (
@@ -50,12 +108,14 @@ func (x EventState) String() string {
 }
 
 func (EventState) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_871986018790d2fd, []int{0}
+	return fileDescriptor_871986018790d2fd, []int{2}
 }
 
 type ChannelPayload struct {
 	EventState   EventState `protobuf:"varint,1,opt,name=event_state,json=eventState,proto3,enum=EventState" json:"event_state,omitempty"`
 	RequeueCount int32      `protobuf:"varint,2,opt,name=requeue_count,json=requeueCount,proto3" json:"requeue_count,omitempty"`
+	// last_error describes the last error that occured handling the event on the channel.
+	LastError string `protobuf:"bytes,3,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
 }
 
 func (m *ChannelPayload) Reset()         { *m = ChannelPayload{} }
@@ -105,6 +165,13 @@ func (m *ChannelPayload) GetRequeueCount() int32 {
 	return 0
 }
 
+func (m *ChannelPayload) GetLastError() string {
+	if m != nil {
+		return m.LastError
+	}
+	return ""
+}
+
 type EventTimePayload struct {
 	CreateTime int64 `protobuf:"fixed64,1,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
 }
@@ -202,9 +269,27 @@ func (m *IndexPayload) GetCreateTime() int64 {
 }
 
 type EventPayload struct {
-	Payload           []byte     `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
-	DefaultEventState EventState `protobuf:"varint,2,opt,name=default_event_state,json=defaultEventState,proto3,enum=EventState" json:"default_event_state,omitempty"`
-	Indexes           []string   `protobuf:"bytes,3,rep,name=indexes,proto3" json:"indexes,omitempty"`
+	Payload           []byte            `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	DefaultEventState EventState        `protobuf:"varint,2,opt,name=default_event_state,json=defaultEventState,proto3,enum=EventState" json:"default_event_state,omitempty"`
+	Indexes           []string          `protobuf:"bytes,3,rep,name=indexes,proto3" json:"indexes,omitempty"`
+	Metadata          map[string]string `protobuf:"bytes,4,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// codec is the codec payload is compressed with.
+	Codec Codec `protobuf:"varint,5,opt,name=codec,proto3,enum=Codec" json:"codec,omitempty"`
+	// key_id is the ID of the key payload is encrypted with, or empty if payload isn't encrypted.
+	KeyId string `protobuf:"bytes,6,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	// requeue_limit is the requeue limit of the event, or zero to use the defaults of its topic and
+	// store.
+	RequeueLimit int32 `protobuf:"varint,7,opt,name=requeue_limit,json=requeueLimit,proto3" json:"requeue_limit,omitempty"`
+	// deliver_at is the time the event is first delivered to channels, in nanoseconds since the unix
+	// epoch, or zero to deliver it immediately.
+	DeliverAt int64 `protobuf:"fixed64,8,opt,name=deliver_at,json=deliverAt,proto3" json:"deliver_at,omitempty"`
+	// priority is the priority of the event on its channels. Queued events with a higher priority
+	// are delivered first.
+	Priority int32 `protobuf:"varint,9,opt,name=priority,proto3" json:"priority,omitempty"`
+	// payload_size is the size of payload before it was compressed and encrypted, so the stats of an
+	// event can be updated without decrypting it. It is zero for events written before it was
+	// recorded, whose payloads aren't encrypted.
+	PayloadSize int64 `protobuf:"varint,10,opt,name=payload_size,json=payloadSize,proto3" json:"payload_size,omitempty"`
 }
 
 func (m *EventPayload) Reset()         { *m = EventPayload{} }
@@ -261,255 +346,3162 @@ func (m *EventPayload) GetIndexes() []string {
 	return nil
 }
 
-func init() {
-	proto.RegisterEnum("EventState", EventState_name, EventState_value)
-	proto.RegisterType((*ChannelPayload)(nil), "ChannelPayload")
-	proto.RegisterType((*EventTimePayload)(nil), "EventTimePayload")
-	proto.RegisterType((*IndexPayload)(nil), "IndexPayload")
-	proto.RegisterType((*EventPayload)(nil), "EventPayload")
+func (m *EventPayload) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
 }
 
-func init() { proto.RegisterFile("data.proto", fileDescriptor_871986018790d2fd) }
-
-var fileDescriptor_871986018790d2fd = []byte{
-	// 332 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x64, 0x91, 0xc1, 0x4e, 0xf2, 0x40,
-	0x14, 0x85, 0x3b, 0x25, 0x3f, 0xfc, 0xde, 0x56, 0x2c, 0x63, 0x4c, 0xea, 0xa6, 0x12, 0xdc, 0x10,
-	0x63, 0x58, 0xc8, 0xd2, 0x95, 0xc2, 0x98, 0x54, 0x13, 0xc1, 0xa1, 0xac, 0x9b, 0x91, 0x5e, 0x63,
-	0x13, 0x68, 0x11, 0xa6, 0x46, 0x57, 0xbe, 0x82, 0x8f, 0xe5, 0x92, 0xa5, 0x4b, 0x03, 0x2f, 0x62,
-	0x66, 0x4a, 0x91, 0x84, 0xdd, 0x9c, 0x2f, 0xe7, 0x9e, 0x7b, 0x72, 0x07, 0x20, 0x12, 0x52, 0xb4,
-	0xa6, 0xb3, 0x54, 0xa6, 0x8d, 0x11, 0x54, 0x3b, 0xcf, 0x22, 0x49, 0x70, 0xdc, 0x17, 0xef, 0xe3,
-	0x54, 0x44, 0xf4, 0x1c, 0x2c, 0x7c, 0xc5, 0x44, 0x86, 0x73, 0x29, 0x24, 0xba, 0xa4, 0x4e, 0x9a,
-	0xd5, 0x0b, 0xab, 0xc5, 0x14, 0x1b, 0x28, 0xc4, 0x01, 0x37, 0x6f, 0x7a, 0x0a, 0xfb, 0x33, 0x7c,
-	0xc9, 0x30, 0xc3, 0x70, 0x94, 0x66, 0x89, 0x74, 0xcd, 0x3a, 0x69, 0xfe, 0xe3, 0xf6, 0x1a, 0x76,
-	0x14, 0x6b, 0xb4, 0xc1, 0xd1, 0xe3, 0x41, 0x3c, 0xc1, 0x62, 0xcd, 0x09, 0x58, 0xa3, 0x19, 0x0a,
-	0x89, 0xa1, 0x8c, 0x27, 0xf9, 0x1a, 0x87, 0x43, 0x8e, 0x94, 0xaf, 0x71, 0x0b, 0xb6, 0x9f, 0x44,
-	0xf8, 0x56, 0x0c, 0x1c, 0xc3, 0xff, 0xbc, 0x57, 0x1c, 0x69, 0xf7, 0x1e, 0xaf, 0x68, 0xed, 0xef,
-	0x64, 0x99, 0x3b, 0x59, 0x1f, 0x60, 0xeb, 0x02, 0x45, 0x96, 0x0b, 0x95, 0x69, 0xfe, 0xd4, 0x51,
-	0x36, 0x2f, 0x24, 0xbd, 0x84, 0xc3, 0x08, 0x9f, 0x44, 0x36, 0x96, 0xe1, 0xf6, 0x15, 0xcc, 0xdd,
-	0x2b, 0xd4, 0xd6, 0xbe, 0x3f, 0xa4, 0x62, 0x63, 0x55, 0x19, 0xe7, 0x6e, 0xa9, 0x5e, 0x52, 0x0d,
-	0xd7, 0xf2, 0x2c, 0x00, 0xd8, 0xf2, 0x1d, 0x41, 0x6d, 0x78, 0x3f, 0xe8, 0xb3, 0x8e, 0x7f, 0xe3,
-	0xb3, 0x6e, 0x38, 0x08, 0xae, 0x02, 0xe6, 0x18, 0x14, 0xa0, 0xfc, 0x30, 0x64, 0x43, 0xd6, 0x75,
-	0x08, 0x3d, 0x00, 0xab, 0xcb, 0x72, 0x15, 0xf6, 0xee, 0x1c, 0x93, 0x52, 0xa8, 0x6e, 0x00, 0xe3,
-	0xbc, 0xc7, 0x9d, 0xd2, 0xb5, 0xfb, 0xb5, 0xf4, 0xc8, 0x62, 0xe9, 0x91, 0x9f, 0xa5, 0x47, 0x3e,
-	0x57, 0x9e, 0xb1, 0x58, 0x79, 0xc6, 0xf7, 0xca, 0x33, 0x1e, 0xcb, 0xfa, 0x77, 0xdb, 0xbf, 0x01,
-	0x00, 0x00, 0xff, 0xff, 0x91, 0xca, 0x74, 0xea, 0xeb, 0x01, 0x00, 0x00,
+func (m *EventPayload) GetCodec() Codec {
+	if m != nil {
+		return m.Codec
+	}
+	return Codec_CODEC_NONE
 }
 
-func (m *ChannelPayload) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *EventPayload) GetKeyId() string {
+	if m != nil {
+		return m.KeyId
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *ChannelPayload) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.EventState != 0 {
-		dAtA[i] = 0x8
-		i++
-		i = encodeVarintData(dAtA, i, uint64(m.EventState))
+func (m *EventPayload) GetRequeueLimit() int32 {
+	if m != nil {
+		return m.RequeueLimit
 	}
-	if m.RequeueCount != 0 {
-		dAtA[i] = 0x10
-		i++
-		i = encodeVarintData(dAtA, i, uint64(m.RequeueCount))
+	return 0
+}
+
+func (m *EventPayload) GetDeliverAt() int64 {
+	if m != nil {
+		return m.DeliverAt
 	}
-	return i, nil
+	return 0
 }
 
-func (m *EventTimePayload) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *EventPayload) GetPriority() int32 {
+	if m != nil {
+		return m.Priority
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *EventTimePayload) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if m.CreateTime != 0 {
-		dAtA[i] = 0x9
-		i++
-		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.CreateTime))
-		i += 8
+func (m *EventPayload) GetPayloadSize() int64 {
+	if m != nil {
+		return m.PayloadSize
 	}
-	return i, nil
+	return 0
 }
 
-func (m *IndexPayload) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+// TopicStatsPayload holds the counters for the events of a topic.
+type TopicStatsPayload struct {
+	EventCount   int64 `protobuf:"varint,1,opt,name=event_count,json=eventCount,proto3" json:"event_count,omitempty"`
+	PayloadBytes int64 `protobuf:"varint,2,opt,name=payload_bytes,json=payloadBytes,proto3" json:"payload_bytes,omitempty"`
+	// last_create_time is the latest create time of the topic's events, in nanoseconds since the unix
+	// epoch, or zero if it isn't known.
+	LastCreateTime int64 `protobuf:"fixed64,3,opt,name=last_create_time,json=lastCreateTime,proto3" json:"last_create_time,omitempty"`
+	// backdated_key is the lowest EventKey of the events published with a create time that isn't
+	// after last_create_time, which channels may have already passed when looking for their oldest
+	// queued event. backdated_seq changes each time such an event is published.
+	BackdatedKey []byte `protobuf:"bytes,4,opt,name=backdated_key,json=backdatedKey,proto3" json:"backdated_key,omitempty"`
+	BackdatedSeq int64  `protobuf:"varint,5,opt,name=backdated_seq,json=backdatedSeq,proto3" json:"backdated_seq,omitempty"`
+}
+
+func (m *TopicStatsPayload) Reset()         { *m = TopicStatsPayload{} }
+func (m *TopicStatsPayload) String() string { return proto.CompactTextString(m) }
+func (*TopicStatsPayload) ProtoMessage()    {}
+func (*TopicStatsPayload) Descriptor() ([]byte, []int) {
+	return fileDescriptor_871986018790d2fd, []int{4}
+}
+func (m *TopicStatsPayload) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TopicStatsPayload) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TopicStatsPayload.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return dAtA[:n], nil
+}
+func (m *TopicStatsPayload) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TopicStatsPayload.Merge(m, src)
+}
+func (m *TopicStatsPayload) XXX_Size() int {
+	return m.Size()
+}
+func (m *TopicStatsPayload) XXX_DiscardUnknown() {
+	xxx_messageInfo_TopicStatsPayload.DiscardUnknown(m)
 }
 
-func (m *IndexPayload) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.EventId) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintData(dAtA, i, uint64(len(m.EventId)))
-		i += copy(dAtA[i:], m.EventId)
+var xxx_messageInfo_TopicStatsPayload proto.InternalMessageInfo
+
+func (m *TopicStatsPayload) GetEventCount() int64 {
+	if m != nil {
+		return m.EventCount
 	}
-	if m.CreateTime != 0 {
-		dAtA[i] = 0x11
-		i++
-		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.CreateTime))
-		i += 8
+	return 0
+}
+
+func (m *TopicStatsPayload) GetPayloadBytes() int64 {
+	if m != nil {
+		return m.PayloadBytes
 	}
-	return i, nil
+	return 0
 }
 
-func (m *EventPayload) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalTo(dAtA)
-	if err != nil {
-		return nil, err
+func (m *TopicStatsPayload) GetLastCreateTime() int64 {
+	if m != nil {
+		return m.LastCreateTime
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *EventPayload) MarshalTo(dAtA []byte) (int, error) {
-	var i int
-	_ = i
-	var l int
-	_ = l
-	if len(m.Payload) > 0 {
-		dAtA[i] = 0xa
-		i++
-		i = encodeVarintData(dAtA, i, uint64(len(m.Payload)))
-		i += copy(dAtA[i:], m.Payload)
+func (m *TopicStatsPayload) GetBackdatedKey() []byte {
+	if m != nil {
+		return m.BackdatedKey
 	}
-	if m.DefaultEventState != 0 {
-		dAtA[i] = 0x10
-		i++
-		i = encodeVarintData(dAtA, i, uint64(m.DefaultEventState))
+	return nil
+}
+
+func (m *TopicStatsPayload) GetBackdatedSeq() int64 {
+	if m != nil {
+		return m.BackdatedSeq
 	}
-	if len(m.Indexes) > 0 {
-		for _, s := range m.Indexes {
-			dAtA[i] = 0x1a
-			i++
-			l = len(s)
-			for l >= 1<<7 {
-				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
-				l >>= 7
-				i++
-			}
-			dAtA[i] = uint8(l)
-			i++
-			i += copy(dAtA[i:], s)
+	return 0
+}
+
+// ChannelStatsPayload holds the counters for the events of a topic with saved state on a channel.
+// Events without saved state are queued, and aren't counted.
+type ChannelStatsPayload struct {
+	QueuedCount        int64 `protobuf:"varint,1,opt,name=queued_count,json=queuedCount,proto3" json:"queued_count,omitempty"`
+	DequeuedOkCount    int64 `protobuf:"varint,2,opt,name=dequeued_ok_count,json=dequeuedOkCount,proto3" json:"dequeued_ok_count,omitempty"`
+	DequeuedErrorCount int64 `protobuf:"varint,3,opt,name=dequeued_error_count,json=dequeuedErrorCount,proto3" json:"dequeued_error_count,omitempty"`
+	// requeue_histogram[0] counts events that have never been requeued, and requeue_histogram[i]
+	// counts events that have been requeued at least 2^(i-1) and fewer than 2^i times.
+	RequeueHistogram []int64 `protobuf:"varint,4,rep,packed,name=requeue_histogram,json=requeueHistogram,proto3" json:"requeue_histogram,omitempty"`
+	// queued_cursor is an EventKey at or before the oldest queued event on the channel, apart from
+	// events backdated since backdated_seq of the topic's stats. If empty, it is the start of the
+	// topic.
+	QueuedCursor []byte `protobuf:"bytes,5,opt,name=queued_cursor,json=queuedCursor,proto3" json:"queued_cursor,omitempty"`
+	BackdatedSeq int64  `protobuf:"varint,6,opt,name=backdated_seq,json=backdatedSeq,proto3" json:"backdated_seq,omitempty"`
+}
+
+func (m *ChannelStatsPayload) Reset()         { *m = ChannelStatsPayload{} }
+func (m *ChannelStatsPayload) String() string { return proto.CompactTextString(m) }
+func (*ChannelStatsPayload) ProtoMessage()    {}
+func (*ChannelStatsPayload) Descriptor() ([]byte, []int) {
+	return fileDescriptor_871986018790d2fd, []int{5}
+}
+func (m *ChannelStatsPayload) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ChannelStatsPayload) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ChannelStatsPayload.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	return i, nil
+}
+func (m *ChannelStatsPayload) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChannelStatsPayload.Merge(m, src)
+}
+func (m *ChannelStatsPayload) XXX_Size() int {
+	return m.Size()
+}
+func (m *ChannelStatsPayload) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChannelStatsPayload.DiscardUnknown(m)
 }
 
-func encodeVarintData(dAtA []byte, offset int, v uint64) int {
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+var xxx_messageInfo_ChannelStatsPayload proto.InternalMessageInfo
+
+func (m *ChannelStatsPayload) GetQueuedCount() int64 {
+	if m != nil {
+		return m.QueuedCount
 	}
-	dAtA[offset] = uint8(v)
-	return offset + 1
+	return 0
 }
-func (m *ChannelPayload) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.EventState != 0 {
-		n += 1 + sovData(uint64(m.EventState))
+
+func (m *ChannelStatsPayload) GetDequeuedOkCount() int64 {
+	if m != nil {
+		return m.DequeuedOkCount
 	}
-	if m.RequeueCount != 0 {
-		n += 1 + sovData(uint64(m.RequeueCount))
+	return 0
+}
+
+func (m *ChannelStatsPayload) GetDequeuedErrorCount() int64 {
+	if m != nil {
+		return m.DequeuedErrorCount
 	}
-	return n
+	return 0
 }
 
-func (m *EventTimePayload) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *ChannelStatsPayload) GetRequeueHistogram() []int64 {
+	if m != nil {
+		return m.RequeueHistogram
 	}
-	var l int
-	_ = l
-	if m.CreateTime != 0 {
-		n += 9
+	return nil
+}
+
+func (m *ChannelStatsPayload) GetQueuedCursor() []byte {
+	if m != nil {
+		return m.QueuedCursor
 	}
-	return n
+	return nil
 }
 
-func (m *IndexPayload) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *ChannelStatsPayload) GetBackdatedSeq() int64 {
+	if m != nil {
+		return m.BackdatedSeq
 	}
-	var l int
-	_ = l
-	l = len(m.EventId)
-	if l > 0 {
-		n += 1 + l + sovData(uint64(l))
+	return 0
+}
+
+// ExportEvent is an event written by Store.Export. Unlike the payloads stored in the database, it
+// must remain compatible across database versions.
+type ExportEvent struct {
+	Id           string                `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Topic        string                `protobuf:"bytes,2,opt,name=topic,proto3" json:"topic,omitempty"`
+	CreateTime   int64                 `protobuf:"fixed64,3,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	Payload      []byte                `protobuf:"bytes,4,opt,name=payload,proto3" json:"payload,omitempty"`
+	Indexes      []string              `protobuf:"bytes,5,rep,name=indexes,proto3" json:"indexes,omitempty"`
+	DefaultState EventState            `protobuf:"varint,6,opt,name=default_state,json=defaultState,proto3,enum=EventState" json:"default_state,omitempty"`
+	Channels     []*ExportChannelState `protobuf:"bytes,7,rep,name=channels,proto3" json:"channels,omitempty"`
+	Metadata     map[string]string     `protobuf:"bytes,8,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	RequeueLimit int32                 `protobuf:"varint,9,opt,name=requeue_limit,json=requeueLimit,proto3" json:"requeue_limit,omitempty"`
+	DeliverAt    int64                 `protobuf:"fixed64,10,opt,name=deliver_at,json=deliverAt,proto3" json:"deliver_at,omitempty"`
+	Priority     int32                 `protobuf:"varint,11,opt,name=priority,proto3" json:"priority,omitempty"`
+}
+
+func (m *ExportEvent) Reset()         { *m = ExportEvent{} }
+func (m *ExportEvent) String() string { return proto.CompactTextString(m) }
+func (*ExportEvent) ProtoMessage()    {}
+func (*ExportEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_871986018790d2fd, []int{6}
+}
+func (m *ExportEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ExportEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ExportEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if m.CreateTime != 0 {
-		n += 9
+}
+func (m *ExportEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportEvent.Merge(m, src)
+}
+func (m *ExportEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *ExportEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportEvent proto.InternalMessageInfo
+
+func (m *ExportEvent) GetId() string {
+	if m != nil {
+		return m.Id
 	}
-	return n
+	return ""
 }
 
-func (m *EventPayload) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *ExportEvent) GetTopic() string {
+	if m != nil {
+		return m.Topic
 	}
-	var l int
-	_ = l
-	l = len(m.Payload)
-	if l > 0 {
-		n += 1 + l + sovData(uint64(l))
+	return ""
+}
+
+func (m *ExportEvent) GetCreateTime() int64 {
+	if m != nil {
+		return m.CreateTime
 	}
-	if m.DefaultEventState != 0 {
-		n += 1 + sovData(uint64(m.DefaultEventState))
+	return 0
+}
+
+func (m *ExportEvent) GetPayload() []byte {
+	if m != nil {
+		return m.Payload
 	}
-	if len(m.Indexes) > 0 {
-		for _, s := range m.Indexes {
-			l = len(s)
-			n += 1 + l + sovData(uint64(l))
+	return nil
+}
+
+func (m *ExportEvent) GetIndexes() []string {
+	if m != nil {
+		return m.Indexes
+	}
+	return nil
+}
+
+func (m *ExportEvent) GetDefaultState() EventState {
+	if m != nil {
+		return m.DefaultState
+	}
+	return EventState_UNSPECIFIED_STATE
+}
+
+func (m *ExportEvent) GetChannels() []*ExportChannelState {
+	if m != nil {
+		return m.Channels
+	}
+	return nil
+}
+
+func (m *ExportEvent) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *ExportEvent) GetRequeueLimit() int32 {
+	if m != nil {
+		return m.RequeueLimit
+	}
+	return 0
+}
+
+func (m *ExportEvent) GetDeliverAt() int64 {
+	if m != nil {
+		return m.DeliverAt
+	}
+	return 0
+}
+
+func (m *ExportEvent) GetPriority() int32 {
+	if m != nil {
+		return m.Priority
+	}
+	return 0
+}
+
+// ExportChannelState is the state of an exported event on a channel.
+type ExportChannelState struct {
+	Channel      string     `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel,omitempty"`
+	State        EventState `protobuf:"varint,2,opt,name=state,proto3,enum=EventState" json:"state,omitempty"`
+	RequeueCount int32      `protobuf:"varint,3,opt,name=requeue_count,json=requeueCount,proto3" json:"requeue_count,omitempty"`
+}
+
+func (m *ExportChannelState) Reset()         { *m = ExportChannelState{} }
+func (m *ExportChannelState) String() string { return proto.CompactTextString(m) }
+func (*ExportChannelState) ProtoMessage()    {}
+func (*ExportChannelState) Descriptor() ([]byte, []int) {
+	return fileDescriptor_871986018790d2fd, []int{7}
+}
+func (m *ExportChannelState) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ExportChannelState) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ExportChannelState.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ExportChannelState) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportChannelState.Merge(m, src)
+}
+func (m *ExportChannelState) XXX_Size() int {
+	return m.Size()
+}
+func (m *ExportChannelState) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportChannelState.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportChannelState proto.InternalMessageInfo
+
+func (m *ExportChannelState) GetChannel() string {
+	if m != nil {
+		return m.Channel
+	}
+	return ""
+}
+
+func (m *ExportChannelState) GetState() EventState {
+	if m != nil {
+		return m.State
+	}
+	return EventState_UNSPECIFIED_STATE
+}
+
+func (m *ExportChannelState) GetRequeueCount() int32 {
+	if m != nil {
+		return m.RequeueCount
+	}
+	return 0
+}
+
+// SchemaPayload holds the schema registered for the payloads of a topic.
+type SchemaPayload struct {
+	// message_type is the full name of the protobuf message type of the payloads.
+	MessageType string `protobuf:"bytes,1,opt,name=message_type,json=messageType,proto3" json:"message_type,omitempty"`
+	// file_descriptor_set is a serialized google.protobuf.FileDescriptorSet that contains
+	// message_type and its dependencies.
+	FileDescriptorSet []byte `protobuf:"bytes,2,opt,name=file_descriptor_set,json=fileDescriptorSet,proto3" json:"file_descriptor_set,omitempty"`
+	// version is incremented each time the schema is updated, starting at 1.
+	Version int64 `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (m *SchemaPayload) Reset()         { *m = SchemaPayload{} }
+func (m *SchemaPayload) String() string { return proto.CompactTextString(m) }
+func (*SchemaPayload) ProtoMessage()    {}
+func (*SchemaPayload) Descriptor() ([]byte, []int) {
+	return fileDescriptor_871986018790d2fd, []int{8}
+}
+func (m *SchemaPayload) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SchemaPayload) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SchemaPayload.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SchemaPayload) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SchemaPayload.Merge(m, src)
+}
+func (m *SchemaPayload) XXX_Size() int {
+	return m.Size()
+}
+func (m *SchemaPayload) XXX_DiscardUnknown() {
+	xxx_messageInfo_SchemaPayload.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SchemaPayload proto.InternalMessageInfo
+
+func (m *SchemaPayload) GetMessageType() string {
+	if m != nil {
+		return m.MessageType
+	}
+	return ""
+}
+
+func (m *SchemaPayload) GetFileDescriptorSet() []byte {
+	if m != nil {
+		return m.FileDescriptorSet
+	}
+	return nil
+}
+
+func (m *SchemaPayload) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+// TopicConfigPayload holds the persisted configuration of a topic. Zero values use the store's
+// defaults.
+type TopicConfigPayload struct {
+	RequeueLimit int32   `protobuf:"varint,1,opt,name=requeue_limit,json=requeueLimit,proto3" json:"requeue_limit,omitempty"`
+	Backoff      Backoff `protobuf:"varint,2,opt,name=backoff,proto3,enum=Backoff" json:"backoff,omitempty"`
+	// requeue_delay is the base delay of the backoff, in nanoseconds.
+	RequeueDelay int64      `protobuf:"varint,3,opt,name=requeue_delay,json=requeueDelay,proto3" json:"requeue_delay,omitempty"`
+	DefaultState EventState `protobuf:"varint,4,opt,name=default_state,json=defaultState,proto3,enum=EventState" json:"default_state,omitempty"`
+	// retention_max_age is the maximum age of the topic's events, in nanoseconds.
+	RetentionMaxAge   int64  `protobuf:"varint,5,opt,name=retention_max_age,json=retentionMaxAge,proto3" json:"retention_max_age,omitempty"`
+	RetentionMaxCount int64  `protobuf:"varint,6,opt,name=retention_max_count,json=retentionMaxCount,proto3" json:"retention_max_count,omitempty"`
+	DeadLetterTopic   string `protobuf:"bytes,7,opt,name=dead_letter_topic,json=deadLetterTopic,proto3" json:"dead_letter_topic,omitempty"`
+}
+
+func (m *TopicConfigPayload) Reset()         { *m = TopicConfigPayload{} }
+func (m *TopicConfigPayload) String() string { return proto.CompactTextString(m) }
+func (*TopicConfigPayload) ProtoMessage()    {}
+func (*TopicConfigPayload) Descriptor() ([]byte, []int) {
+	return fileDescriptor_871986018790d2fd, []int{9}
+}
+func (m *TopicConfigPayload) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TopicConfigPayload) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TopicConfigPayload.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TopicConfigPayload) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TopicConfigPayload.Merge(m, src)
+}
+func (m *TopicConfigPayload) XXX_Size() int {
+	return m.Size()
+}
+func (m *TopicConfigPayload) XXX_DiscardUnknown() {
+	xxx_messageInfo_TopicConfigPayload.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TopicConfigPayload proto.InternalMessageInfo
+
+func (m *TopicConfigPayload) GetRequeueLimit() int32 {
+	if m != nil {
+		return m.RequeueLimit
+	}
+	return 0
+}
+
+func (m *TopicConfigPayload) GetBackoff() Backoff {
+	if m != nil {
+		return m.Backoff
+	}
+	return Backoff_BACKOFF_UNSPECIFIED
+}
+
+func (m *TopicConfigPayload) GetRequeueDelay() int64 {
+	if m != nil {
+		return m.RequeueDelay
+	}
+	return 0
+}
+
+func (m *TopicConfigPayload) GetDefaultState() EventState {
+	if m != nil {
+		return m.DefaultState
+	}
+	return EventState_UNSPECIFIED_STATE
+}
+
+func (m *TopicConfigPayload) GetRetentionMaxAge() int64 {
+	if m != nil {
+		return m.RetentionMaxAge
+	}
+	return 0
+}
+
+func (m *TopicConfigPayload) GetRetentionMaxCount() int64 {
+	if m != nil {
+		return m.RetentionMaxCount
+	}
+	return 0
+}
+
+func (m *TopicConfigPayload) GetDeadLetterTopic() string {
+	if m != nil {
+		return m.DeadLetterTopic
+	}
+	return ""
+}
+
+// SchedulePayload holds a schedule, which publishes an event on each of its ticks.
+type SchedulePayload struct {
+	// Exactly one of cron and interval is set.
+	Cron string `protobuf:"bytes,1,opt,name=cron,proto3" json:"cron,omitempty"`
+	// interval is the time between ticks, in nanoseconds.
+	Interval int64             `protobuf:"varint,2,opt,name=interval,proto3" json:"interval,omitempty"`
+	Topic    string            `protobuf:"bytes,3,opt,name=topic,proto3" json:"topic,omitempty"`
+	Payload  []byte            `protobuf:"bytes,4,opt,name=payload,proto3" json:"payload,omitempty"`
+	Metadata map[string]string `protobuf:"bytes,5,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// last_tick is the time of the last tick that published an event, in nanoseconds since the unix
+	// epoch.
+	LastTick int64 `protobuf:"fixed64,6,opt,name=last_tick,json=lastTick,proto3" json:"last_tick,omitempty"`
+}
+
+func (m *SchedulePayload) Reset()         { *m = SchedulePayload{} }
+func (m *SchedulePayload) String() string { return proto.CompactTextString(m) }
+func (*SchedulePayload) ProtoMessage()    {}
+func (*SchedulePayload) Descriptor() ([]byte, []int) {
+	return fileDescriptor_871986018790d2fd, []int{10}
+}
+func (m *SchedulePayload) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SchedulePayload) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SchedulePayload.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SchedulePayload) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SchedulePayload.Merge(m, src)
+}
+func (m *SchedulePayload) XXX_Size() int {
+	return m.Size()
+}
+func (m *SchedulePayload) XXX_DiscardUnknown() {
+	xxx_messageInfo_SchedulePayload.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SchedulePayload proto.InternalMessageInfo
+
+func (m *SchedulePayload) GetCron() string {
+	if m != nil {
+		return m.Cron
+	}
+	return ""
+}
+
+func (m *SchedulePayload) GetInterval() int64 {
+	if m != nil {
+		return m.Interval
+	}
+	return 0
+}
+
+func (m *SchedulePayload) GetTopic() string {
+	if m != nil {
+		return m.Topic
+	}
+	return ""
+}
+
+func (m *SchedulePayload) GetPayload() []byte {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *SchedulePayload) GetMetadata() map[string]string {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *SchedulePayload) GetLastTick() int64 {
+	if m != nil {
+		return m.LastTick
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterEnum("Backoff", Backoff_name, Backoff_value)
+	proto.RegisterEnum("Codec", Codec_name, Codec_value)
+	proto.RegisterEnum("EventState", EventState_name, EventState_value)
+	proto.RegisterType((*ChannelPayload)(nil), "ChannelPayload")
+	proto.RegisterType((*EventTimePayload)(nil), "EventTimePayload")
+	proto.RegisterType((*IndexPayload)(nil), "IndexPayload")
+	proto.RegisterType((*EventPayload)(nil), "EventPayload")
+	proto.RegisterMapType((map[string]string)(nil), "EventPayload.MetadataEntry")
+	proto.RegisterType((*TopicStatsPayload)(nil), "TopicStatsPayload")
+	proto.RegisterType((*ChannelStatsPayload)(nil), "ChannelStatsPayload")
+	proto.RegisterType((*ExportEvent)(nil), "ExportEvent")
+	proto.RegisterMapType((map[string]string)(nil), "ExportEvent.MetadataEntry")
+	proto.RegisterType((*ExportChannelState)(nil), "ExportChannelState")
+	proto.RegisterType((*SchemaPayload)(nil), "SchemaPayload")
+	proto.RegisterType((*TopicConfigPayload)(nil), "TopicConfigPayload")
+	proto.RegisterType((*SchedulePayload)(nil), "SchedulePayload")
+	proto.RegisterMapType((map[string]string)(nil), "SchedulePayload.MetadataEntry")
+}
+
+func init() { proto.RegisterFile("data.proto", fileDescriptor_871986018790d2fd) }
+
+var fileDescriptor_871986018790d2fd = []byte{
+	// 1179 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x56, 0xcd, 0x6e, 0xdb, 0x46,
+	0x10, 0x36, 0x45, 0xeb, 0x6f, 0x24, 0xcb, 0xd4, 0x3a, 0x41, 0xd9, 0xa4, 0x55, 0x15, 0xe5, 0x50,
+	0xc1, 0x2d, 0xd4, 0x20, 0x01, 0xda, 0x22, 0x39, 0xc9, 0x12, 0xd3, 0xaa, 0x71, 0x24, 0x97, 0x52,
+	0x80, 0xa2, 0x17, 0x82, 0x26, 0xc7, 0x36, 0x21, 0x8a, 0x94, 0xc9, 0x95, 0x60, 0x05, 0x3d, 0xf5,
+	0xd2, 0x53, 0x81, 0xbe, 0x48, 0xdf, 0xa0, 0x87, 0x1e, 0x7b, 0x29, 0x90, 0x63, 0x8f, 0x85, 0xfd,
+	0x22, 0xc5, 0xfe, 0x90, 0xa6, 0xac, 0xb8, 0x39, 0xe4, 0xc6, 0xf9, 0x66, 0x76, 0x34, 0x33, 0xdf,
+	0x7c, 0xab, 0x05, 0x70, 0x6d, 0x6a, 0x77, 0xe6, 0x51, 0x48, 0xc3, 0xd6, 0xcf, 0x0a, 0xd4, 0x7a,
+	0x67, 0x76, 0x10, 0xa0, 0x7f, 0x64, 0xaf, 0xfc, 0xd0, 0x76, 0xc9, 0xe7, 0x50, 0xc1, 0x25, 0x06,
+	0xd4, 0x8a, 0xa9, 0x4d, 0x51, 0x57, 0x9a, 0x4a, 0xbb, 0xf6, 0xb8, 0xd2, 0x31, 0x18, 0x36, 0x66,
+	0x90, 0x09, 0x98, 0x7e, 0x93, 0x87, 0xb0, 0x13, 0xe1, 0xf9, 0x02, 0x17, 0x68, 0x39, 0xe1, 0x22,
+	0xa0, 0x7a, 0xae, 0xa9, 0xb4, 0xf3, 0x66, 0x55, 0x82, 0x3d, 0x86, 0x91, 0x8f, 0x01, 0x7c, 0x3b,
+	0xa6, 0x16, 0x46, 0x51, 0x18, 0xe9, 0x6a, 0x53, 0x69, 0x97, 0xcd, 0x32, 0x43, 0x0c, 0x06, 0xb4,
+	0x9e, 0x80, 0xc6, 0xb3, 0x4f, 0xbc, 0x19, 0x26, 0x55, 0x7c, 0x02, 0x15, 0x27, 0x42, 0x9b, 0xa2,
+	0x45, 0xbd, 0x99, 0xa8, 0x42, 0x33, 0x41, 0x40, 0x2c, 0xae, 0xf5, 0x1d, 0x54, 0x07, 0x81, 0x8b,
+	0x17, 0xc9, 0x81, 0x0f, 0xa1, 0x24, 0xca, 0xf6, 0x5c, 0x1e, 0x5d, 0x36, 0x8b, 0xdc, 0x1e, 0x6c,
+	0xe4, 0xca, 0x6d, 0xe4, 0xfa, 0x5d, 0x85, 0x2a, 0xaf, 0x20, 0x49, 0xa6, 0x43, 0x71, 0x2e, 0x3e,
+	0x79, 0xae, 0xaa, 0x99, 0x98, 0xe4, 0x19, 0xec, 0xb9, 0x78, 0x62, 0x2f, 0x7c, 0x6a, 0x65, 0xa7,
+	0x94, 0xdb, 0x9c, 0x52, 0x5d, 0xc6, 0x5d, 0x43, 0x2c, 0xad, 0xc7, 0x6a, 0xc6, 0x58, 0x57, 0x9b,
+	0x2a, 0x2b, 0x51, 0x9a, 0xe4, 0x2b, 0x28, 0xcd, 0x90, 0xda, 0x8c, 0x19, 0x7d, 0xbb, 0xa9, 0xb6,
+	0x2b, 0x8f, 0xef, 0x77, 0xb2, 0x15, 0x75, 0x5e, 0x4a, 0xaf, 0x11, 0xd0, 0x68, 0x65, 0xa6, 0xc1,
+	0xe4, 0x23, 0xc8, 0x3b, 0xa1, 0x8b, 0x8e, 0x9e, 0xe7, 0x15, 0x14, 0x3a, 0x3d, 0x66, 0x99, 0x02,
+	0x24, 0x77, 0xa1, 0x30, 0xc5, 0x15, 0x1b, 0x49, 0x81, 0x8f, 0x24, 0x3f, 0xc5, 0xd5, 0xc0, 0xcd,
+	0x92, 0xe6, 0x7b, 0x33, 0x8f, 0xea, 0xc5, 0x35, 0xd2, 0x0e, 0x19, 0xc6, 0x48, 0x73, 0xd1, 0xf7,
+	0x96, 0x18, 0x59, 0x36, 0xd5, 0x4b, 0x7c, 0x68, 0x65, 0x89, 0x74, 0x29, 0xb9, 0x07, 0xa5, 0x79,
+	0xe4, 0x85, 0x91, 0x47, 0x57, 0x7a, 0x99, 0x1f, 0x4f, 0x6d, 0xf2, 0x00, 0xaa, 0x72, 0x5e, 0x56,
+	0xec, 0xbd, 0x46, 0x1d, 0x9a, 0x4a, 0x5b, 0x35, 0x2b, 0x12, 0x1b, 0x7b, 0xaf, 0xf1, 0xde, 0x33,
+	0xd8, 0x59, 0x6b, 0x89, 0x68, 0xa0, 0x4e, 0x71, 0x25, 0xa9, 0x63, 0x9f, 0xe4, 0x0e, 0xe4, 0x97,
+	0xb6, 0xbf, 0x10, 0xc3, 0x2d, 0x9b, 0xc2, 0x78, 0x9a, 0xfb, 0x5a, 0x69, 0xfd, 0xad, 0x40, 0x7d,
+	0x12, 0xce, 0x3d, 0x87, 0x8d, 0x35, 0xce, 0xac, 0x8c, 0xa0, 0x44, 0x2c, 0xa2, 0xc2, 0x7f, 0x54,
+	0xec, 0xaa, 0x58, 0xc3, 0x87, 0xb0, 0x93, 0x94, 0x75, 0xbc, 0xa2, 0x18, 0xf3, 0xc4, 0xaa, 0x99,
+	0xd4, 0x7a, 0xc0, 0x30, 0xd2, 0x06, 0x8d, 0xef, 0x6a, 0x76, 0x63, 0x54, 0xde, 0x7c, 0x8d, 0xe1,
+	0xbd, 0x74, 0x6b, 0x58, 0xba, 0x63, 0xdb, 0x99, 0xba, 0x36, 0x45, 0xd7, 0x62, 0xb5, 0x6f, 0xf3,
+	0x55, 0xa9, 0xa6, 0xe0, 0x0b, 0x5c, 0xad, 0x07, 0xc5, 0x78, 0xce, 0x79, 0x52, 0x33, 0x41, 0x63,
+	0x3c, 0x6f, 0xfd, 0x9a, 0x83, 0x3d, 0xa9, 0xc2, 0xb5, 0x8e, 0x1e, 0x40, 0x95, 0x13, 0xe2, 0xae,
+	0xb5, 0x54, 0x11, 0x98, 0xe8, 0x69, 0x1f, 0xea, 0x2e, 0xca, 0xa0, 0x70, 0x9a, 0xd1, 0xa0, 0x6a,
+	0xee, 0x26, 0x8e, 0xd1, 0x54, 0xc4, 0x3e, 0x82, 0x3b, 0x69, 0x2c, 0x97, 0xa2, 0x0c, 0x57, 0x79,
+	0x38, 0x49, 0x7c, 0x5c, 0x94, 0xe2, 0xc4, 0x67, 0x50, 0x4f, 0x16, 0xe5, 0xcc, 0x8b, 0x69, 0x78,
+	0x1a, 0xd9, 0x33, 0xbe, 0x9f, 0xaa, 0xa9, 0x49, 0xc7, 0xb7, 0x09, 0xce, 0x5a, 0x4d, 0xaa, 0x5d,
+	0x44, 0x71, 0x18, 0xf1, 0x56, 0xab, 0xa6, 0x6c, 0xa1, 0xc7, 0xb1, 0xcd, 0x79, 0x14, 0xde, 0x32,
+	0x8f, 0x3f, 0x55, 0xa8, 0x18, 0x17, 0xf3, 0x30, 0x12, 0xe2, 0x21, 0x35, 0xc8, 0xa5, 0xaa, 0xce,
+	0x79, 0x2e, 0xdb, 0x0c, 0xca, 0xe8, 0x4f, 0x36, 0x83, 0x1b, 0x37, 0x65, 0xae, 0xde, 0x94, 0x79,
+	0x56, 0xd5, 0xdb, 0xeb, 0xaa, 0xce, 0x08, 0x33, 0xbf, 0x2e, 0xcc, 0x47, 0xb0, 0x93, 0xe8, 0x5d,
+	0x28, 0xbd, 0xb0, 0xa9, 0xf4, 0xaa, 0x8c, 0xe0, 0x16, 0xf9, 0x02, 0x4a, 0x8e, 0xe0, 0x32, 0xd6,
+	0x8b, 0x5c, 0xca, 0x7b, 0x1d, 0xd1, 0x4c, 0x86, 0x62, 0x34, 0xd3, 0x20, 0xf2, 0x65, 0x46, 0xfb,
+	0x25, 0x7e, 0xe0, 0x5e, 0x27, 0xd3, 0xfd, 0xad, 0xd2, 0xdf, 0x50, 0x71, 0xf9, 0x9d, 0x2a, 0x86,
+	0xff, 0x53, 0x71, 0x65, 0x5d, 0xc5, 0xef, 0x27, 0xd1, 0x25, 0x90, 0xcd, 0xa6, 0xd9, 0x9c, 0x65,
+	0xdb, 0xc9, 0x1d, 0x2d, 0x4d, 0xf2, 0x00, 0xf2, 0xb7, 0xde, 0xa4, 0xc2, 0xb3, 0xf9, 0x57, 0xa3,
+	0x6e, 0xfe, 0xd5, 0xb4, 0x7e, 0x82, 0x9d, 0xb1, 0x73, 0x86, 0x33, 0x3b, 0xa3, 0xa1, 0x19, 0xc6,
+	0xb1, 0x7d, 0x8a, 0x16, 0x5d, 0xcd, 0x51, 0xfe, 0x6e, 0x45, 0x62, 0x93, 0xd5, 0x1c, 0x49, 0x07,
+	0xf6, 0x4e, 0x3c, 0x1f, 0x2d, 0x17, 0x63, 0x27, 0xf2, 0xe6, 0x34, 0x8c, 0xac, 0x18, 0x85, 0x8a,
+	0xaa, 0x66, 0x9d, 0xb9, 0xfa, 0xa9, 0x67, 0x8c, 0x94, 0x75, 0xb1, 0xc4, 0x28, 0xf6, 0xc2, 0x40,
+	0x4a, 0x27, 0x31, 0x5b, 0x7f, 0xe4, 0x80, 0xf0, 0x8b, 0xa9, 0x17, 0x06, 0x27, 0xde, 0x69, 0x52,
+	0xc3, 0x06, 0x53, 0xca, 0x5b, 0x98, 0x6a, 0x41, 0x91, 0x89, 0x20, 0x3c, 0x39, 0x91, 0x33, 0x28,
+	0x75, 0x0e, 0x84, 0x6d, 0x26, 0x8e, 0x6c, 0x22, 0x17, 0x7d, 0x7b, 0x25, 0x7f, 0x3f, 0x49, 0xd4,
+	0x67, 0xd8, 0xe6, 0xca, 0x6e, 0xbf, 0x6b, 0x65, 0xf7, 0x99, 0xcc, 0x29, 0x06, 0xd4, 0x0b, 0x03,
+	0x6b, 0x66, 0x5f, 0x58, 0xf6, 0x29, 0xca, 0x8b, 0x6a, 0x37, 0x75, 0xbc, 0xb4, 0x2f, 0xba, 0xa7,
+	0x7c, 0x58, 0xeb, 0xb1, 0x82, 0x0b, 0x21, 0xe3, 0x7a, 0x36, 0x3a, 0x73, 0x41, 0xd9, 0xae, 0xe5,
+	0x23, 0xa5, 0x18, 0x59, 0x42, 0xb7, 0x45, 0x4e, 0xc2, 0x2e, 0x73, 0x1c, 0x72, 0x9c, 0x0f, 0xad,
+	0xf5, 0x4b, 0x0e, 0x76, 0x19, 0x7b, 0xee, 0xc2, 0x4f, 0x1f, 0x02, 0x04, 0xb6, 0x9d, 0x28, 0x0c,
+	0x24, 0x6f, 0xfc, 0x9b, 0x6d, 0xad, 0x17, 0x50, 0x8c, 0x96, 0xb6, 0x2f, 0xef, 0xba, 0xd4, 0xbe,
+	0xbe, 0x1b, 0xd4, 0xec, 0xdd, 0x70, 0xbb, 0xf4, 0x9f, 0x66, 0xd4, 0x97, 0xe7, 0xea, 0x6b, 0x74,
+	0x6e, 0xd4, 0x70, 0xab, 0x02, 0xef, 0x03, 0x7f, 0xc5, 0x58, 0xd4, 0x73, 0xa6, 0x7c, 0x02, 0x9a,
+	0x59, 0x62, 0xc0, 0xc4, 0x73, 0xa6, 0xef, 0x25, 0x9f, 0x7d, 0x84, 0xa2, 0x24, 0x9f, 0x7c, 0x00,
+	0x7b, 0x07, 0xdd, 0xde, 0x8b, 0xd1, 0xf3, 0xe7, 0xd6, 0xab, 0xe1, 0xf8, 0xc8, 0xe8, 0x0d, 0x9e,
+	0x0f, 0x8c, 0xbe, 0xb6, 0x95, 0x75, 0x18, 0x3f, 0x1c, 0x8d, 0x86, 0xc6, 0x70, 0x32, 0xe8, 0x1e,
+	0x6a, 0x0a, 0x21, 0x50, 0x4b, 0x1c, 0x87, 0x83, 0xa1, 0xd1, 0x35, 0xb5, 0x1c, 0xb9, 0x03, 0x5a,
+	0x82, 0xf5, 0x46, 0xc3, 0xf1, 0xa4, 0x3b, 0x9c, 0x68, 0xea, 0xfe, 0xa7, 0x90, 0xe7, 0xef, 0x05,
+	0x52, 0x03, 0xe8, 0x8d, 0xfa, 0x46, 0xcf, 0x1a, 0x8e, 0x86, 0x86, 0xb6, 0x75, 0x6d, 0x7f, 0xf3,
+	0xe3, 0xe0, 0x48, 0x53, 0xf6, 0x27, 0x00, 0x99, 0x77, 0xcc, 0x5d, 0xa8, 0x67, 0x4a, 0xb1, 0xc6,
+	0x93, 0xee, 0x84, 0x1d, 0x02, 0x28, 0x7c, 0xff, 0xca, 0x78, 0x65, 0xf4, 0x35, 0x85, 0xec, 0x42,
+	0xa5, 0x6f, 0x08, 0xcb, 0x1a, 0xbd, 0xd0, 0x72, 0xac, 0xa8, 0x14, 0x30, 0x4c, 0x73, 0x64, 0x6a,
+	0xea, 0x81, 0xfe, 0xd7, 0x65, 0x43, 0x79, 0x73, 0xd9, 0x50, 0xfe, 0xbd, 0x6c, 0x28, 0xbf, 0x5d,
+	0x35, 0xb6, 0xde, 0x5c, 0x35, 0xb6, 0xfe, 0xb9, 0x6a, 0x6c, 0x1d, 0x17, 0xf8, 0xf3, 0xf4, 0xc9,
+	0x7f, 0x03, 0x00, 0xd3, 0x16, 0x7b, 0xdd, 0xac, 0x0a, 0x00, 0x00,
+}
+
+func (m *ChannelPayload) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ChannelPayload) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.EventState != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintData(dAtA, i, uint64(m.EventState))
+	}
+	if m.RequeueCount != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintData(dAtA, i, uint64(m.RequeueCount))
+	}
+	if len(m.LastError) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintData(dAtA, i, uint64(len(m.LastError)))
+		i += copy(dAtA[i:], m.LastError)
+	}
+	return i, nil
+}
+
+func (m *EventTimePayload) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *EventTimePayload) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.CreateTime != 0 {
+		dAtA[i] = 0x9
+		i++
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.CreateTime))
+		i += 8
+	}
+	return i, nil
+}
+
+func (m *IndexPayload) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *IndexPayload) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.EventId) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintData(dAtA, i, uint64(len(m.EventId)))
+		i += copy(dAtA[i:], m.EventId)
+	}
+	if m.CreateTime != 0 {
+		dAtA[i] = 0x11
+		i++
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.CreateTime))
+		i += 8
+	}
+	return i, nil
+}
+
+func (m *EventPayload) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *EventPayload) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Payload) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintData(dAtA, i, uint64(len(m.Payload)))
+		i += copy(dAtA[i:], m.Payload)
+	}
+	if m.DefaultEventState != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintData(dAtA, i, uint64(m.DefaultEventState))
+	}
+	if len(m.Indexes) > 0 {
+		for _, s := range m.Indexes {
+			dAtA[i] = 0x1a
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if len(m.Metadata) > 0 {
+		for k, _ := range m.Metadata {
+			dAtA[i] = 0x22
+			i++
+			v := m.Metadata[k]
+			mapSize := 1 + len(k) + sovData(uint64(len(k))) + 1 + len(v) + sovData(uint64(len(v)))
+			i = encodeVarintData(dAtA, i, uint64(mapSize))
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintData(dAtA, i, uint64(len(k)))
+			i += copy(dAtA[i:], k)
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintData(dAtA, i, uint64(len(v)))
+			i += copy(dAtA[i:], v)
+		}
+	}
+	if m.Codec != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintData(dAtA, i, uint64(m.Codec))
+	}
+	if len(m.KeyId) > 0 {
+		dAtA[i] = 0x32
+		i++
+		i = encodeVarintData(dAtA, i, uint64(len(m.KeyId)))
+		i += copy(dAtA[i:], m.KeyId)
+	}
+	if m.RequeueLimit != 0 {
+		dAtA[i] = 0x38
+		i++
+		i = encodeVarintData(dAtA, i, uint64(m.RequeueLimit))
+	}
+	if m.DeliverAt != 0 {
+		dAtA[i] = 0x41
+		i++
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.DeliverAt))
+		i += 8
+	}
+	if m.Priority != 0 {
+		dAtA[i] = 0x48
+		i++
+		i = encodeVarintData(dAtA, i, uint64(m.Priority))
+	}
+	if m.PayloadSize != 0 {
+		dAtA[i] = 0x50
+		i++
+		i = encodeVarintData(dAtA, i, uint64(m.PayloadSize))
+	}
+	return i, nil
+}
+
+func (m *TopicStatsPayload) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TopicStatsPayload) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.EventCount != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintData(dAtA, i, uint64(m.EventCount))
+	}
+	if m.PayloadBytes != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintData(dAtA, i, uint64(m.PayloadBytes))
+	}
+	if m.LastCreateTime != 0 {
+		dAtA[i] = 0x19
+		i++
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.LastCreateTime))
+		i += 8
+	}
+	if len(m.BackdatedKey) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintData(dAtA, i, uint64(len(m.BackdatedKey)))
+		i += copy(dAtA[i:], m.BackdatedKey)
+	}
+	if m.BackdatedSeq != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintData(dAtA, i, uint64(m.BackdatedSeq))
+	}
+	return i, nil
+}
+
+func (m *ChannelStatsPayload) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ChannelStatsPayload) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.QueuedCount != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintData(dAtA, i, uint64(m.QueuedCount))
+	}
+	if m.DequeuedOkCount != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintData(dAtA, i, uint64(m.DequeuedOkCount))
+	}
+	if m.DequeuedErrorCount != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintData(dAtA, i, uint64(m.DequeuedErrorCount))
+	}
+	if len(m.RequeueHistogram) > 0 {
+		dAtA2 := make([]byte, len(m.RequeueHistogram)*10)
+		var j1 int
+		for _, num1 := range m.RequeueHistogram {
+			num := uint64(num1)
+			for num >= 1<<7 {
+				dAtA2[j1] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j1++
+			}
+			dAtA2[j1] = uint8(num)
+			j1++
+		}
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintData(dAtA, i, uint64(j1))
+		i += copy(dAtA[i:], dAtA2[:j1])
+	}
+	if len(m.QueuedCursor) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintData(dAtA, i, uint64(len(m.QueuedCursor)))
+		i += copy(dAtA[i:], m.QueuedCursor)
+	}
+	if m.BackdatedSeq != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintData(dAtA, i, uint64(m.BackdatedSeq))
+	}
+	return i, nil
+}
+
+func (m *ExportEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ExportEvent) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Id) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintData(dAtA, i, uint64(len(m.Id)))
+		i += copy(dAtA[i:], m.Id)
+	}
+	if len(m.Topic) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintData(dAtA, i, uint64(len(m.Topic)))
+		i += copy(dAtA[i:], m.Topic)
+	}
+	if m.CreateTime != 0 {
+		dAtA[i] = 0x19
+		i++
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.CreateTime))
+		i += 8
+	}
+	if len(m.Payload) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintData(dAtA, i, uint64(len(m.Payload)))
+		i += copy(dAtA[i:], m.Payload)
+	}
+	if len(m.Indexes) > 0 {
+		for _, s := range m.Indexes {
+			dAtA[i] = 0x2a
+			i++
+			l = len(s)
+			for l >= 1<<7 {
+				dAtA[i] = uint8(uint64(l)&0x7f | 0x80)
+				l >>= 7
+				i++
+			}
+			dAtA[i] = uint8(l)
+			i++
+			i += copy(dAtA[i:], s)
+		}
+	}
+	if m.DefaultState != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintData(dAtA, i, uint64(m.DefaultState))
+	}
+	if len(m.Channels) > 0 {
+		for _, msg := range m.Channels {
+			dAtA[i] = 0x3a
+			i++
+			i = encodeVarintData(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.Metadata) > 0 {
+		for k, _ := range m.Metadata {
+			dAtA[i] = 0x42
+			i++
+			v := m.Metadata[k]
+			mapSize := 1 + len(k) + sovData(uint64(len(k))) + 1 + len(v) + sovData(uint64(len(v)))
+			i = encodeVarintData(dAtA, i, uint64(mapSize))
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintData(dAtA, i, uint64(len(k)))
+			i += copy(dAtA[i:], k)
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintData(dAtA, i, uint64(len(v)))
+			i += copy(dAtA[i:], v)
+		}
+	}
+	if m.RequeueLimit != 0 {
+		dAtA[i] = 0x48
+		i++
+		i = encodeVarintData(dAtA, i, uint64(m.RequeueLimit))
+	}
+	if m.DeliverAt != 0 {
+		dAtA[i] = 0x51
+		i++
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.DeliverAt))
+		i += 8
+	}
+	if m.Priority != 0 {
+		dAtA[i] = 0x58
+		i++
+		i = encodeVarintData(dAtA, i, uint64(m.Priority))
+	}
+	return i, nil
+}
+
+func (m *ExportChannelState) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ExportChannelState) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Channel) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintData(dAtA, i, uint64(len(m.Channel)))
+		i += copy(dAtA[i:], m.Channel)
+	}
+	if m.State != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintData(dAtA, i, uint64(m.State))
+	}
+	if m.RequeueCount != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintData(dAtA, i, uint64(m.RequeueCount))
+	}
+	return i, nil
+}
+
+func (m *SchemaPayload) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SchemaPayload) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.MessageType) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintData(dAtA, i, uint64(len(m.MessageType)))
+		i += copy(dAtA[i:], m.MessageType)
+	}
+	if len(m.FileDescriptorSet) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintData(dAtA, i, uint64(len(m.FileDescriptorSet)))
+		i += copy(dAtA[i:], m.FileDescriptorSet)
+	}
+	if m.Version != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintData(dAtA, i, uint64(m.Version))
+	}
+	return i, nil
+}
+
+func (m *TopicConfigPayload) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TopicConfigPayload) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.RequeueLimit != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintData(dAtA, i, uint64(m.RequeueLimit))
+	}
+	if m.Backoff != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintData(dAtA, i, uint64(m.Backoff))
+	}
+	if m.RequeueDelay != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintData(dAtA, i, uint64(m.RequeueDelay))
+	}
+	if m.DefaultState != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintData(dAtA, i, uint64(m.DefaultState))
+	}
+	if m.RetentionMaxAge != 0 {
+		dAtA[i] = 0x28
+		i++
+		i = encodeVarintData(dAtA, i, uint64(m.RetentionMaxAge))
+	}
+	if m.RetentionMaxCount != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintData(dAtA, i, uint64(m.RetentionMaxCount))
+	}
+	if len(m.DeadLetterTopic) > 0 {
+		dAtA[i] = 0x3a
+		i++
+		i = encodeVarintData(dAtA, i, uint64(len(m.DeadLetterTopic)))
+		i += copy(dAtA[i:], m.DeadLetterTopic)
+	}
+	return i, nil
+}
+
+func (m *SchedulePayload) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SchedulePayload) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Cron) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintData(dAtA, i, uint64(len(m.Cron)))
+		i += copy(dAtA[i:], m.Cron)
+	}
+	if m.Interval != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintData(dAtA, i, uint64(m.Interval))
+	}
+	if len(m.Topic) > 0 {
+		dAtA[i] = 0x1a
+		i++
+		i = encodeVarintData(dAtA, i, uint64(len(m.Topic)))
+		i += copy(dAtA[i:], m.Topic)
+	}
+	if len(m.Payload) > 0 {
+		dAtA[i] = 0x22
+		i++
+		i = encodeVarintData(dAtA, i, uint64(len(m.Payload)))
+		i += copy(dAtA[i:], m.Payload)
+	}
+	if len(m.Metadata) > 0 {
+		for k, _ := range m.Metadata {
+			dAtA[i] = 0x2a
+			i++
+			v := m.Metadata[k]
+			mapSize := 1 + len(k) + sovData(uint64(len(k))) + 1 + len(v) + sovData(uint64(len(v)))
+			i = encodeVarintData(dAtA, i, uint64(mapSize))
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintData(dAtA, i, uint64(len(k)))
+			i += copy(dAtA[i:], k)
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintData(dAtA, i, uint64(len(v)))
+			i += copy(dAtA[i:], v)
+		}
+	}
+	if m.LastTick != 0 {
+		dAtA[i] = 0x31
+		i++
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(m.LastTick))
+		i += 8
+	}
+	return i, nil
+}
+
+func encodeVarintData(dAtA []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return offset + 1
+}
+func (m *ChannelPayload) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.EventState != 0 {
+		n += 1 + sovData(uint64(m.EventState))
+	}
+	if m.RequeueCount != 0 {
+		n += 1 + sovData(uint64(m.RequeueCount))
+	}
+	l = len(m.LastError)
+	if l > 0 {
+		n += 1 + l + sovData(uint64(l))
+	}
+	return n
+}
+
+func (m *EventTimePayload) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.CreateTime != 0 {
+		n += 9
+	}
+	return n
+}
+
+func (m *IndexPayload) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.EventId)
+	if l > 0 {
+		n += 1 + l + sovData(uint64(l))
+	}
+	if m.CreateTime != 0 {
+		n += 9
+	}
+	return n
+}
+
+func (m *EventPayload) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Payload)
+	if l > 0 {
+		n += 1 + l + sovData(uint64(l))
+	}
+	if m.DefaultEventState != 0 {
+		n += 1 + sovData(uint64(m.DefaultEventState))
+	}
+	if len(m.Indexes) > 0 {
+		for _, s := range m.Indexes {
+			l = len(s)
+			n += 1 + l + sovData(uint64(l))
+		}
+	}
+	if len(m.Metadata) > 0 {
+		for k, v := range m.Metadata {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovData(uint64(len(k))) + 1 + len(v) + sovData(uint64(len(v)))
+			n += mapEntrySize + 1 + sovData(uint64(mapEntrySize))
+		}
+	}
+	if m.Codec != 0 {
+		n += 1 + sovData(uint64(m.Codec))
+	}
+	l = len(m.KeyId)
+	if l > 0 {
+		n += 1 + l + sovData(uint64(l))
+	}
+	if m.RequeueLimit != 0 {
+		n += 1 + sovData(uint64(m.RequeueLimit))
+	}
+	if m.DeliverAt != 0 {
+		n += 9
+	}
+	if m.Priority != 0 {
+		n += 1 + sovData(uint64(m.Priority))
+	}
+	if m.PayloadSize != 0 {
+		n += 1 + sovData(uint64(m.PayloadSize))
+	}
+	return n
+}
+
+func (m *TopicStatsPayload) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.EventCount != 0 {
+		n += 1 + sovData(uint64(m.EventCount))
+	}
+	if m.PayloadBytes != 0 {
+		n += 1 + sovData(uint64(m.PayloadBytes))
+	}
+	if m.LastCreateTime != 0 {
+		n += 9
+	}
+	l = len(m.BackdatedKey)
+	if l > 0 {
+		n += 1 + l + sovData(uint64(l))
+	}
+	if m.BackdatedSeq != 0 {
+		n += 1 + sovData(uint64(m.BackdatedSeq))
+	}
+	return n
+}
+
+func (m *ChannelStatsPayload) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.QueuedCount != 0 {
+		n += 1 + sovData(uint64(m.QueuedCount))
+	}
+	if m.DequeuedOkCount != 0 {
+		n += 1 + sovData(uint64(m.DequeuedOkCount))
+	}
+	if m.DequeuedErrorCount != 0 {
+		n += 1 + sovData(uint64(m.DequeuedErrorCount))
+	}
+	if len(m.RequeueHistogram) > 0 {
+		l = 0
+		for _, e := range m.RequeueHistogram {
+			l += sovData(uint64(e))
+		}
+		n += 1 + sovData(uint64(l)) + l
+	}
+	l = len(m.QueuedCursor)
+	if l > 0 {
+		n += 1 + l + sovData(uint64(l))
+	}
+	if m.BackdatedSeq != 0 {
+		n += 1 + sovData(uint64(m.BackdatedSeq))
+	}
+	return n
+}
+
+func (m *ExportEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Id)
+	if l > 0 {
+		n += 1 + l + sovData(uint64(l))
+	}
+	l = len(m.Topic)
+	if l > 0 {
+		n += 1 + l + sovData(uint64(l))
+	}
+	if m.CreateTime != 0 {
+		n += 9
+	}
+	l = len(m.Payload)
+	if l > 0 {
+		n += 1 + l + sovData(uint64(l))
+	}
+	if len(m.Indexes) > 0 {
+		for _, s := range m.Indexes {
+			l = len(s)
+			n += 1 + l + sovData(uint64(l))
+		}
+	}
+	if m.DefaultState != 0 {
+		n += 1 + sovData(uint64(m.DefaultState))
+	}
+	if len(m.Channels) > 0 {
+		for _, e := range m.Channels {
+			l = e.Size()
+			n += 1 + l + sovData(uint64(l))
+		}
+	}
+	if len(m.Metadata) > 0 {
+		for k, v := range m.Metadata {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovData(uint64(len(k))) + 1 + len(v) + sovData(uint64(len(v)))
+			n += mapEntrySize + 1 + sovData(uint64(mapEntrySize))
+		}
+	}
+	if m.RequeueLimit != 0 {
+		n += 1 + sovData(uint64(m.RequeueLimit))
+	}
+	if m.DeliverAt != 0 {
+		n += 9
+	}
+	if m.Priority != 0 {
+		n += 1 + sovData(uint64(m.Priority))
+	}
+	return n
+}
+
+func (m *ExportChannelState) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Channel)
+	if l > 0 {
+		n += 1 + l + sovData(uint64(l))
+	}
+	if m.State != 0 {
+		n += 1 + sovData(uint64(m.State))
+	}
+	if m.RequeueCount != 0 {
+		n += 1 + sovData(uint64(m.RequeueCount))
+	}
+	return n
+}
+
+func (m *SchemaPayload) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.MessageType)
+	if l > 0 {
+		n += 1 + l + sovData(uint64(l))
+	}
+	l = len(m.FileDescriptorSet)
+	if l > 0 {
+		n += 1 + l + sovData(uint64(l))
+	}
+	if m.Version != 0 {
+		n += 1 + sovData(uint64(m.Version))
+	}
+	return n
+}
+
+func (m *TopicConfigPayload) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.RequeueLimit != 0 {
+		n += 1 + sovData(uint64(m.RequeueLimit))
+	}
+	if m.Backoff != 0 {
+		n += 1 + sovData(uint64(m.Backoff))
+	}
+	if m.RequeueDelay != 0 {
+		n += 1 + sovData(uint64(m.RequeueDelay))
+	}
+	if m.DefaultState != 0 {
+		n += 1 + sovData(uint64(m.DefaultState))
+	}
+	if m.RetentionMaxAge != 0 {
+		n += 1 + sovData(uint64(m.RetentionMaxAge))
+	}
+	if m.RetentionMaxCount != 0 {
+		n += 1 + sovData(uint64(m.RetentionMaxCount))
+	}
+	l = len(m.DeadLetterTopic)
+	if l > 0 {
+		n += 1 + l + sovData(uint64(l))
+	}
+	return n
+}
+
+func (m *SchedulePayload) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Cron)
+	if l > 0 {
+		n += 1 + l + sovData(uint64(l))
+	}
+	if m.Interval != 0 {
+		n += 1 + sovData(uint64(m.Interval))
+	}
+	l = len(m.Topic)
+	if l > 0 {
+		n += 1 + l + sovData(uint64(l))
+	}
+	l = len(m.Payload)
+	if l > 0 {
+		n += 1 + l + sovData(uint64(l))
+	}
+	if len(m.Metadata) > 0 {
+		for k, v := range m.Metadata {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovData(uint64(len(k))) + 1 + len(v) + sovData(uint64(len(v)))
+			n += mapEntrySize + 1 + sovData(uint64(mapEntrySize))
+		}
+	}
+	if m.LastTick != 0 {
+		n += 9
+	}
+	return n
+}
+
+func sovData(x uint64) (n int) {
+	for {
+		n++
+		x >>= 7
+		if x == 0 {
+			break
+		}
+	}
+	return n
+}
+func sozData(x uint64) (n int) {
+	return sovData(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *ChannelPayload) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowData
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ChannelPayload: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ChannelPayload: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EventState", wireType)
+			}
+			m.EventState = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowData
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.EventState |= EventState(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RequeueCount", wireType)
+			}
+			m.RequeueCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowData
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RequeueCount |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastError", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowData
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthData
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthData
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LastError = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipData(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthData
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthData
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *EventTimePayload) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowData
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: EventTimePayload: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: EventTimePayload: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CreateTime", wireType)
+			}
+			m.CreateTime = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CreateTime = int64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		default:
+			iNdEx = preIndex
+			skippy, err := skipData(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthData
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthData
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *IndexPayload) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowData
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: IndexPayload: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: IndexPayload: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EventId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowData
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthData
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthData
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EventId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CreateTime", wireType)
+			}
+			m.CreateTime = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CreateTime = int64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		default:
+			iNdEx = preIndex
+			skippy, err := skipData(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthData
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthData
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *EventPayload) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowData
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: EventPayload: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: EventPayload: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowData
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthData
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthData
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Payload = append(m.Payload[:0], dAtA[iNdEx:postIndex]...)
+			if m.Payload == nil {
+				m.Payload = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DefaultEventState", wireType)
+			}
+			m.DefaultEventState = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowData
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DefaultEventState |= EventState(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Indexes", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowData
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthData
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthData
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Indexes = append(m.Indexes, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowData
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthData
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthData
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Metadata == nil {
+				m.Metadata = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowData
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowData
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthData
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthData
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowData
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthData
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue < 0 {
+						return ErrInvalidLengthData
+					}
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipData(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthData
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Metadata[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Codec", wireType)
+			}
+			m.Codec = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowData
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Codec |= Codec(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KeyId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowData
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthData
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthData
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.KeyId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RequeueLimit", wireType)
+			}
+			m.RequeueLimit = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowData
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RequeueLimit |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeliverAt", wireType)
+			}
+			m.DeliverAt = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DeliverAt = int64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Priority", wireType)
+			}
+			m.Priority = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowData
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Priority |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PayloadSize", wireType)
+			}
+			m.PayloadSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowData
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PayloadSize |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipData(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthData
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthData
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TopicStatsPayload) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowData
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TopicStatsPayload: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TopicStatsPayload: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EventCount", wireType)
+			}
+			m.EventCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowData
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.EventCount |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PayloadBytes", wireType)
+			}
+			m.PayloadBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowData
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PayloadBytes |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastCreateTime", wireType)
+			}
+			m.LastCreateTime = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LastCreateTime = int64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BackdatedKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowData
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthData
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthData
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.BackdatedKey = append(m.BackdatedKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.BackdatedKey == nil {
+				m.BackdatedKey = []byte{}
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BackdatedSeq", wireType)
+			}
+			m.BackdatedSeq = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowData
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BackdatedSeq |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipData(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthData
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthData
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ChannelStatsPayload) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowData
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ChannelStatsPayload: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ChannelStatsPayload: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field QueuedCount", wireType)
+			}
+			m.QueuedCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowData
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.QueuedCount |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DequeuedOkCount", wireType)
+			}
+			m.DequeuedOkCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowData
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DequeuedOkCount |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DequeuedErrorCount", wireType)
+			}
+			m.DequeuedErrorCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowData
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DequeuedErrorCount |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType == 0 {
+				var v int64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowData
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= int64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.RequeueHistogram = append(m.RequeueHistogram, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowData
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthData
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthData
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.RequeueHistogram) == 0 {
+					m.RequeueHistogram = make([]int64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v int64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowData
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= int64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.RequeueHistogram = append(m.RequeueHistogram, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field RequeueHistogram", wireType)
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field QueuedCursor", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowData
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthData
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthData
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.QueuedCursor = append(m.QueuedCursor[:0], dAtA[iNdEx:postIndex]...)
+			if m.QueuedCursor == nil {
+				m.QueuedCursor = []byte{}
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BackdatedSeq", wireType)
+			}
+			m.BackdatedSeq = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowData
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BackdatedSeq |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipData(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthData
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthData
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ExportEvent) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowData
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ExportEvent: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ExportEvent: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowData
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthData
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthData
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Id = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Topic", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowData
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthData
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthData
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Topic = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CreateTime", wireType)
+			}
+			m.CreateTime = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CreateTime = int64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowData
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthData
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthData
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Payload = append(m.Payload[:0], dAtA[iNdEx:postIndex]...)
+			if m.Payload == nil {
+				m.Payload = []byte{}
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Indexes", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowData
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthData
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthData
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Indexes = append(m.Indexes, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DefaultState", wireType)
+			}
+			m.DefaultState = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowData
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DefaultState |= EventState(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Channels", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowData
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthData
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthData
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Channels = append(m.Channels, &ExportChannelState{})
+			if err := m.Channels[len(m.Channels)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowData
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthData
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthData
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Metadata == nil {
+				m.Metadata = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowData
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowData
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthData
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthData
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowData
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthData
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue < 0 {
+						return ErrInvalidLengthData
+					}
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipData(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthData
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Metadata[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RequeueLimit", wireType)
+			}
+			m.RequeueLimit = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowData
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RequeueLimit |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 10:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeliverAt", wireType)
+			}
+			m.DeliverAt = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DeliverAt = int64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Priority", wireType)
+			}
+			m.Priority = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowData
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Priority |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipData(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthData
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthData
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
 		}
 	}
-	return n
-}
 
-func sovData(x uint64) (n int) {
-	for {
-		n++
-		x >>= 7
-		if x == 0 {
-			break
-		}
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	return n
-}
-func sozData(x uint64) (n int) {
-	return sovData(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	return nil
 }
-func (m *ChannelPayload) Unmarshal(dAtA []byte) error {
+func (m *ExportChannelState) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -532,17 +3524,17 @@ func (m *ChannelPayload) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ChannelPayload: wiretype end group for non-group")
+			return fmt.Errorf("proto: ExportChannelState: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ChannelPayload: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ExportChannelState: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EventState", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Channel", wireType)
 			}
-			m.EventState = 0
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowData
@@ -552,12 +3544,44 @@ func (m *ChannelPayload) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.EventState |= EventState(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthData
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthData
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Channel = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field State", wireType)
+			}
+			m.State = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowData
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.State |= EventState(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
 			if wireType != 0 {
 				return fmt.Errorf("proto: wrong wireType = %d for field RequeueCount", wireType)
 			}
@@ -600,7 +3624,7 @@ func (m *ChannelPayload) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *EventTimePayload) Unmarshal(dAtA []byte) error {
+func (m *SchemaPayload) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -623,22 +3647,97 @@ func (m *EventTimePayload) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: EventTimePayload: wiretype end group for non-group")
+			return fmt.Errorf("proto: SchemaPayload: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: EventTimePayload: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SchemaPayload: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 1 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CreateTime", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MessageType", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowData
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthData
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthData
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.MessageType = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FileDescriptorSet", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowData
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			m.CreateTime = 0
-			if (iNdEx + 8) > l {
+			if byteLen < 0 {
+				return ErrInvalidLengthData
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthData
+			}
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.CreateTime = int64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
-			iNdEx += 8
+			m.FileDescriptorSet = append(m.FileDescriptorSet[:0], dAtA[iNdEx:postIndex]...)
+			if m.FileDescriptorSet == nil {
+				m.FileDescriptorSet = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
+			}
+			m.Version = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowData
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Version |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipData(dAtA[iNdEx:])
@@ -663,7 +3762,7 @@ func (m *EventTimePayload) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *IndexPayload) Unmarshal(dAtA []byte) error {
+func (m *TopicConfigPayload) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -686,15 +3785,129 @@ func (m *IndexPayload) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: IndexPayload: wiretype end group for non-group")
+			return fmt.Errorf("proto: TopicConfigPayload: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: IndexPayload: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: TopicConfigPayload: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RequeueLimit", wireType)
+			}
+			m.RequeueLimit = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowData
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RequeueLimit |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Backoff", wireType)
+			}
+			m.Backoff = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowData
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Backoff |= Backoff(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RequeueDelay", wireType)
+			}
+			m.RequeueDelay = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowData
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RequeueDelay |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DefaultState", wireType)
+			}
+			m.DefaultState = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowData
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DefaultState |= EventState(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RetentionMaxAge", wireType)
+			}
+			m.RetentionMaxAge = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowData
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RetentionMaxAge |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RetentionMaxCount", wireType)
+			}
+			m.RetentionMaxCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowData
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RetentionMaxCount |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EventId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field DeadLetterTopic", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -722,18 +3935,8 @@ func (m *IndexPayload) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.EventId = string(dAtA[iNdEx:postIndex])
+			m.DeadLetterTopic = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 2:
-			if wireType != 1 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CreateTime", wireType)
-			}
-			m.CreateTime = 0
-			if (iNdEx + 8) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.CreateTime = int64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
-			iNdEx += 8
 		default:
 			iNdEx = preIndex
 			skippy, err := skipData(dAtA[iNdEx:])
@@ -758,7 +3961,7 @@ func (m *IndexPayload) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *EventPayload) Unmarshal(dAtA []byte) error {
+func (m *SchedulePayload) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -781,17 +3984,17 @@ func (m *EventPayload) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: EventPayload: wiretype end group for non-group")
+			return fmt.Errorf("proto: SchedulePayload: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: EventPayload: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SchedulePayload: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Cron", wireType)
 			}
-			var byteLen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowData
@@ -801,31 +4004,29 @@ func (m *EventPayload) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthData
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthData
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Payload = append(m.Payload[:0], dAtA[iNdEx:postIndex]...)
-			if m.Payload == nil {
-				m.Payload = []byte{}
-			}
+			m.Cron = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DefaultEventState", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Interval", wireType)
 			}
-			m.DefaultEventState = 0
+			m.Interval = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowData
@@ -835,14 +4036,14 @@ func (m *EventPayload) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.DefaultEventState |= EventState(b&0x7F) << shift
+				m.Interval |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Indexes", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Topic", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -870,8 +4071,179 @@ func (m *EventPayload) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Indexes = append(m.Indexes, string(dAtA[iNdEx:postIndex]))
+			m.Topic = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Payload", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowData
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthData
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthData
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Payload = append(m.Payload[:0], dAtA[iNdEx:postIndex]...)
+			if m.Payload == nil {
+				m.Payload = []byte{}
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Metadata", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowData
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthData
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthData
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Metadata == nil {
+				m.Metadata = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowData
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowData
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthData
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthData
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowData
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthData
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue < 0 {
+						return ErrInvalidLengthData
+					}
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipData(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthData
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Metadata[mapkey] = mapvalue
 			iNdEx = postIndex
+		case 6:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LastTick", wireType)
+			}
+			m.LastTick = 0
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LastTick = int64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
 		default:
 			iNdEx = preIndex
 			skippy, err := skipData(dAtA[iNdEx:])
@@ -9,7 +9,6 @@ import (
 func TestMarshalIndexKey(t *testing.T) {
 	expected := IndexKey{
 		Topic: "abc",
-		Type:  "abc",
 		Value: "def",
 	}
 	buf, err := expected.Marshal(nil)
@@ -18,6 +18,14 @@ const (
 	EventV0Tag   = 'E'
 	EventTimeTag = 't'
 	IndexTag     = 'I'
+	PriorityTag  = 'p'
+
+	TopicStatsTag   = 'S'
+	ChannelStatsTag = 'c'
+
+	SchemaTag      = 'R'
+	TopicConfigTag = 'T'
+	ScheduleTag    = 'P'
 
 	Sep byte = 0
 
@@ -52,7 +60,19 @@ func UnmarshalTo(src []byte, dest Key) error {
 		return UnmarshalChannelKey(src, dest)
 	case *IndexKey:
 		return UnmarshalIndexKey(src, dest)
-	case EventKey, ChannelKey, EventTimeKey, IndexKey:
+	case *TopicStatsKey:
+		return UnmarshalTopicStatsKey(src, dest)
+	case *ChannelStatsKey:
+		return UnmarshalChannelStatsKey(src, dest)
+	case *SchemaKey:
+		return UnmarshalSchemaKey(src, dest)
+	case *TopicConfigKey:
+		return UnmarshalTopicConfigKey(src, dest)
+	case *ScheduleKey:
+		return UnmarshalScheduleKey(src, dest)
+	case *PriorityKey:
+		return UnmarshalPriorityKey(src, dest)
+	case EventKey, ChannelKey, EventTimeKey, IndexKey, TopicStatsKey, ChannelStatsKey, SchemaKey, TopicConfigKey, ScheduleKey, PriorityKey:
 		return errors.New("dest must be pointer to a key")
 	default:
 		return errors.New("unrecognized type")
@@ -79,6 +99,30 @@ func Unmarshal(src []byte) (Key, error) {
 		var key ChannelKey
 		err := UnmarshalChannelKey(src, &key)
 		return key, err
+	case TopicStatsTag:
+		var key TopicStatsKey
+		err := UnmarshalTopicStatsKey(src, &key)
+		return key, err
+	case ChannelStatsTag:
+		var key ChannelStatsKey
+		err := UnmarshalChannelStatsKey(src, &key)
+		return key, err
+	case SchemaTag:
+		var key SchemaKey
+		err := UnmarshalSchemaKey(src, &key)
+		return key, err
+	case TopicConfigTag:
+		var key TopicConfigKey
+		err := UnmarshalTopicConfigKey(src, &key)
+		return key, err
+	case ScheduleTag:
+		var key ScheduleKey
+		err := UnmarshalScheduleKey(src, &key)
+		return key, err
+	case PriorityTag:
+		var key PriorityKey
+		err := UnmarshalPriorityKey(src, &key)
+		return key, err
 	default:
 		return nil, errors.New("unrecognized type")
 	}
@@ -95,6 +139,16 @@ func UnmarshalPayload(data []byte, key Key) (proto.Message, error) {
 		payload = new(EventTimePayload)
 	case ChannelKey, *ChannelKey:
 		payload = new(ChannelPayload)
+	case TopicStatsKey, *TopicStatsKey:
+		payload = new(TopicStatsPayload)
+	case ChannelStatsKey, *ChannelStatsKey:
+		payload = new(ChannelStatsPayload)
+	case SchemaKey, *SchemaKey:
+		payload = new(SchemaPayload)
+	case TopicConfigKey, *TopicConfigKey:
+		payload = new(TopicConfigPayload)
+	case ScheduleKey, *ScheduleKey:
+		payload = new(SchedulePayload)
 	default:
 		return nil, errors.New("unrecognized type")
 	}
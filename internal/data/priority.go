@@ -0,0 +1,115 @@
+package data
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math"
+	"strings"
+	"time"
+)
+
+// PriorityKey indexes the events of a topic that have a non-zero priority, so they can be iterated
+// in the order they are delivered: by descending Priority, then by CreateTime and ID. Its value is
+// empty.
+//
+// The marshalled format of a PriorityKey is:
+// PriorityTag + Sep + Topic as string data + Sep + (MaxInt32 - Priority) as 4 byte integer +
+// CreateTime as 8 byte unix nano integer + ID
+type PriorityKey struct {
+	// Topic must not contain the null character
+	Topic    string
+	Priority int32
+	// Must be after unix epoch
+	CreateTime time.Time
+	ID         string
+}
+
+func (key PriorityKey) isKey() {}
+
+// Size returns the length of this key's marshalled data. The result is only
+// valid until the key is modified.
+func (key PriorityKey) Size() int {
+	return len(key.Topic) + len(key.ID) + 15
+}
+
+// Marshal marshals a key into a byte slice, prefixed according to the key's type.
+//
+// If buf is nil or has insufficient capacity, a new buffer is allocated. Marshal returns the
+// slice that index was marshalled to.
+func (key PriorityKey) Marshal(buf []byte) ([]byte, error) {
+
+	if key.CreateTime.Before(time.Unix(0, 1)) {
+		return nil, errors.New("CreateTime must be after the unix epoch")
+	}
+	if strings.ContainsRune(key.Topic, 0) {
+		return nil, errors.New("Topic cannot contain null character")
+	}
+	if key.Topic == "" {
+		return nil, errors.New("Topic is required")
+	}
+	if key.ID == "" {
+		return nil, errors.New("ID is required")
+	}
+
+	size := key.Size()
+	if cap(buf) < size {
+		buf = make([]byte, 0, size)
+	} else {
+		buf = buf[:0]
+	}
+
+	buf = append(buf, PriorityTag, Sep)
+	buf = append(buf, key.Topic...)
+	buf = append(buf, Sep)
+	buf = buf[:len(buf)+12]
+	binary.BigEndian.PutUint32(buf[len(buf)-12:], uint32(math.MaxInt32-int64(key.Priority)))
+	binary.BigEndian.PutUint64(buf[len(buf)-8:], uint64(key.CreateTime.UnixNano()))
+	buf = append(buf, key.ID...)
+
+	return buf, nil
+}
+
+// UnmarshalPriorityKey unmarshals a key marshaled by key.Marshal()
+func UnmarshalPriorityKey(buf []byte, key *PriorityKey) error {
+	buf = buf[2:]
+	i := bytes.IndexByte(buf, Sep)
+	if i == -1 {
+		return errors.New("parse Topic: null terminator not found")
+	}
+	if i+13 > len(buf) {
+		return errors.New("parse Priority: unexpected end of input")
+	}
+	key.Topic = string(buf[:i])
+	buf = buf[i+1:]
+	key.Priority = int32(math.MaxInt32 - int64(binary.BigEndian.Uint32(buf[:4])))
+	key.CreateTime = time.Unix(0, int64(binary.BigEndian.Uint64(buf[4:12])))
+	key.ID = string(buf[12:])
+	return nil
+}
+
+// PriorityPrefixTopic creates a prefix for PriorityKeys of a given topic.
+func PriorityPrefixTopic(topic string) ([]byte, error) {
+	if strings.ContainsRune(topic, 0) {
+		return nil, errors.New("Topic cannot contain null character")
+	}
+	ret := make([]byte, 0, len(topic)+3)
+	ret = append(ret, PriorityTag, Sep)
+	ret = append(ret, topic...)
+	ret = append(ret, Sep)
+
+	return ret, nil
+}
+
+// PriorityCursorTopic returns a PriorityKey cursor before the keys of a given topic with the given
+// priority, and after the keys of the topic with a higher priority.
+func PriorityCursorTopic(topic string, priority int32) ([]byte, error) {
+	prefix, err := PriorityPrefixTopic(topic)
+	if err != nil {
+		return nil, err
+	}
+	ret := append(prefix, 0, 0, 0, 0)
+	binary.BigEndian.PutUint32(ret[len(ret)-4:], uint32(math.MaxInt32-int64(priority)))
+
+	return ret, nil
+}
@@ -0,0 +1,76 @@
+package data
+
+import (
+	"bytes"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMarshalPriorityKey(t *testing.T) {
+	expected := PriorityKey{
+		Topic:      "abc",
+		Priority:   -5,
+		CreateTime: time.Unix(0, 1234),
+		ID:         "def",
+	}
+	buf, err := expected.Marshal(nil)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if buf[0] != PriorityTag {
+		t.Errorf("expected serialized prefix %d, got %d", PriorityTag, buf[0])
+	}
+
+	var unmarshaled PriorityKey
+	err = UnmarshalTo(buf, &unmarshaled)
+	if err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if !cmp.Equal(expected, unmarshaled) {
+		t.Errorf("%s", cmp.Diff(expected, unmarshaled))
+	}
+}
+
+func TestPriorityKeyOrder(t *testing.T) {
+	t.Parallel()
+
+	// Keys in the order they are delivered.
+	keys := []PriorityKey{
+		{Topic: "abc", Priority: math.MaxInt32, CreateTime: time.Unix(0, 2), ID: "a"},
+		{Topic: "abc", Priority: 10, CreateTime: time.Unix(0, 1), ID: "b"},
+		{Topic: "abc", Priority: 10, CreateTime: time.Unix(0, 1), ID: "c"},
+		{Topic: "abc", Priority: 10, CreateTime: time.Unix(0, 2), ID: "a"},
+		{Topic: "abc", Priority: 1, CreateTime: time.Unix(0, 1), ID: "a"},
+		{Topic: "abc", Priority: -1, CreateTime: time.Unix(0, 1), ID: "a"},
+		{Topic: "abc", Priority: math.MinInt32, CreateTime: time.Unix(0, 1), ID: "a"},
+	}
+
+	var prev []byte
+	for _, key := range keys {
+		buf, err := key.Marshal(nil)
+		if err != nil {
+			t.Fatalf("marshal %+v: %v", key, err)
+		}
+		if prev != nil && bytes.Compare(prev, buf) >= 0 {
+			t.Errorf("expected %+v to sort after the previous key", key)
+		}
+		prev = buf
+	}
+
+	cursor, err := PriorityCursorTopic("abc", 1)
+	if err != nil {
+		t.Fatalf("priority cursor: %v", err)
+	}
+	for i, key := range keys {
+		buf, err := key.Marshal(nil)
+		if err != nil {
+			t.Fatalf("marshal %+v: %v", key, err)
+		}
+		if before := key.Priority > 1; before != (bytes.Compare(buf, cursor) < 0) {
+			t.Errorf("key %d: expected key before cursor for priority 1 to be %v", i, before)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package data
+
+import (
+	"errors"
+	"strings"
+)
+
+// ScheduleKey is a key for SchedulePayloads. It can be marshalled and used in a key-value
+// store.
+//
+// The marshalled format of a ScheduleKey is:
+// ScheduleTag + Sep + Name
+type ScheduleKey struct {
+	// Name must not contain the null character.
+	Name string
+}
+
+func (key ScheduleKey) isKey() {}
+
+// Size returns the length of this key's marshalled data. The result is only
+// valid until the key is modified.
+func (key ScheduleKey) Size() int {
+	return len(key.Name) + 2
+}
+
+// Marshal marshals a key into a byte slice, prefixed according to the key's type.
+//
+// If buf is nil or has insufficient capacity, a new buffer is allocated. Marshal returns the
+// slice that index was marshalled to.
+func (key ScheduleKey) Marshal(buf []byte) ([]byte, error) {
+
+	if strings.ContainsRune(key.Name, 0) {
+		return nil, errors.New("Name cannot contain null character")
+	}
+
+	size := key.Size()
+	if cap(buf) < size {
+		buf = make([]byte, 0, size)
+	} else {
+		buf = buf[:0]
+	}
+
+	buf = append(buf, ScheduleTag, Sep)
+	buf = append(buf, key.Name...)
+
+	return buf, nil
+}
+
+// UnmarshalScheduleKey updates the this key's values by decoding the provided buf
+func UnmarshalScheduleKey(buf []byte, key *ScheduleKey) error {
+	if len(buf) < 2 || buf[0] != ScheduleTag || buf[1] != Sep {
+		return errors.New("not a ScheduleKey")
+	}
+	key.Name = string(buf[2:])
+	return nil
+}
+
+// SchedulePrefix is the prefix of all ScheduleKeys.
+var SchedulePrefix = []byte{ScheduleTag, Sep}
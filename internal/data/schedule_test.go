@@ -0,0 +1,29 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMarshalScheduleKey(t *testing.T) {
+	expected := ScheduleKey{
+		Name: "abc",
+	}
+	buf, err := expected.Marshal(nil)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if buf[0] != ScheduleTag {
+		t.Errorf("expected serialized prefix %d, got %d", ScheduleTag, buf[0])
+	}
+
+	var unmarshaled ScheduleKey
+	err = UnmarshalTo(buf, &unmarshaled)
+	if err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if expected != unmarshaled {
+		t.Errorf("%s", cmp.Diff(expected, unmarshaled))
+	}
+}
@@ -0,0 +1,58 @@
+package data
+
+import (
+	"errors"
+	"strings"
+)
+
+// SchemaKey is a key for SchemaPayloads. It can be marshalled and used in a key-value store.
+//
+// The marshalled format of a SchemaKey is:
+// SchemaTag + Sep + Topic
+type SchemaKey struct {
+	// Topic must not contain the null character.
+	Topic string
+}
+
+func (key SchemaKey) isKey() {}
+
+// Size returns the length of this key's marshalled data. The result is only
+// valid until the key is modified.
+func (key SchemaKey) Size() int {
+	return len(key.Topic) + 2
+}
+
+// Marshal marshals a key into a byte slice, prefixed according to the key's type.
+//
+// If buf is nil or has insufficient capacity, a new buffer is allocated. Marshal returns the
+// slice that index was marshalled to.
+func (key SchemaKey) Marshal(buf []byte) ([]byte, error) {
+
+	if strings.ContainsRune(key.Topic, 0) {
+		return nil, errors.New("Topic cannot contain null character")
+	}
+
+	size := key.Size()
+	if cap(buf) < size {
+		buf = make([]byte, 0, size)
+	} else {
+		buf = buf[:0]
+	}
+
+	buf = append(buf, SchemaTag, Sep)
+	buf = append(buf, key.Topic...)
+
+	return buf, nil
+}
+
+// UnmarshalSchemaKey updates the this key's values by decoding the provided buf
+func UnmarshalSchemaKey(buf []byte, key *SchemaKey) error {
+	if len(buf) < 2 || buf[0] != SchemaTag || buf[1] != Sep {
+		return errors.New("not a SchemaKey")
+	}
+	key.Topic = string(buf[2:])
+	return nil
+}
+
+// SchemaPrefix is the prefix of all SchemaKeys.
+var SchemaPrefix = []byte{SchemaTag, Sep}
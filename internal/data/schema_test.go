@@ -0,0 +1,29 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMarshalSchemaKey(t *testing.T) {
+	expected := SchemaKey{
+		Topic: "abc",
+	}
+	buf, err := expected.Marshal(nil)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if buf[0] != SchemaTag {
+		t.Errorf("expected serialized prefix %d, got %d", SchemaTag, buf[0])
+	}
+
+	var unmarshaled SchemaKey
+	err = UnmarshalTo(buf, &unmarshaled)
+	if err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if expected != unmarshaled {
+		t.Errorf("%s", cmp.Diff(expected, unmarshaled))
+	}
+}
@@ -0,0 +1,123 @@
+package data
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+)
+
+// TopicStatsKey is a key for TopicStatsPayloads. It can be marshalled and used in a key-value
+// store.
+//
+// The marshalled format of a TopicStatsKey is:
+// TopicStatsTag + Sep + Topic
+type TopicStatsKey struct {
+	// Topic must not contain the null character.
+	Topic string
+}
+
+func (key TopicStatsKey) isKey() {}
+
+// Size returns the length of this key's marshalled data. The result is only
+// valid until the key is modified.
+func (key TopicStatsKey) Size() int {
+	return len(key.Topic) + 2
+}
+
+// Marshal marshals a key into a byte slice, prefixed according to the key's type.
+//
+// If buf is nil or has insufficient capacity, a new buffer is allocated. Marshal returns the
+// slice that index was marshalled to.
+func (key TopicStatsKey) Marshal(buf []byte) ([]byte, error) {
+
+	if strings.ContainsRune(key.Topic, 0) {
+		return nil, errors.New("Topic cannot contain null character")
+	}
+
+	size := key.Size()
+	if cap(buf) < size {
+		buf = make([]byte, 0, size)
+	} else {
+		buf = buf[:0]
+	}
+
+	buf = append(buf, TopicStatsTag, Sep)
+	buf = append(buf, key.Topic...)
+
+	return buf, nil
+}
+
+// UnmarshalTopicStatsKey updates the this key's values by decoding the provided buf
+func UnmarshalTopicStatsKey(buf []byte, key *TopicStatsKey) error {
+	if len(buf) < 2 || buf[0] != TopicStatsTag || buf[1] != Sep {
+		return errors.New("not a TopicStatsKey")
+	}
+	key.Topic = string(buf[2:])
+	return nil
+}
+
+// TopicStatsPrefix is the prefix of all TopicStatsKeys.
+var TopicStatsPrefix = []byte{TopicStatsTag, Sep}
+
+// ChannelStatsKey is a key for ChannelStatsPayloads. It can be marshalled and used in a key-value
+// store.
+//
+// The marshalled format of a ChannelStatsKey is:
+// ChannelStatsTag + Sep + Channel + Sep + Topic
+type ChannelStatsKey struct {
+	// Channel must not contain the null character.
+	Channel string
+	// Topic must not contain the null character.
+	Topic string
+}
+
+func (key ChannelStatsKey) isKey() {}
+
+// Size returns the length of this key's marshalled data. The result is only
+// valid until the key is modified.
+func (key ChannelStatsKey) Size() int {
+	return len(key.Channel) + len(key.Topic) + 3
+}
+
+// Marshal marshals a key into a byte slice, prefixed according to the key's type.
+//
+// If buf is nil or has insufficient capacity, a new buffer is allocated. Marshal returns the
+// slice that index was marshalled to.
+func (key ChannelStatsKey) Marshal(buf []byte) ([]byte, error) {
+
+	if strings.ContainsRune(key.Topic, 0) {
+		return nil, errors.New("Topic cannot contain null character")
+	}
+	if strings.ContainsRune(key.Channel, 0) {
+		return nil, errors.New("Channel cannot contain null character")
+	}
+
+	size := key.Size()
+	if cap(buf) < size {
+		buf = make([]byte, 0, size)
+	} else {
+		buf = buf[:0]
+	}
+
+	buf = append(buf, ChannelStatsTag, Sep)
+	buf = append(buf, key.Channel...)
+	buf = append(buf, Sep)
+	buf = append(buf, key.Topic...)
+
+	return buf, nil
+}
+
+// UnmarshalChannelStatsKey updates the this key's values by decoding the provided buf
+func UnmarshalChannelStatsKey(buf []byte, key *ChannelStatsKey) error {
+	if len(buf) < 2 || buf[0] != ChannelStatsTag || buf[1] != Sep {
+		return errors.New("not a ChannelStatsKey")
+	}
+	buf = buf[2:]
+	i := bytes.IndexByte(buf, Sep)
+	if i == -1 {
+		return errors.New("parse Channel: null terminator not found")
+	}
+	key.Channel = string(buf[:i])
+	key.Topic = string(buf[i+1:])
+	return nil
+}
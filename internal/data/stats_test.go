@@ -0,0 +1,52 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMarshalTopicStatsKey(t *testing.T) {
+	expected := TopicStatsKey{
+		Topic: "abc",
+	}
+	buf, err := expected.Marshal(nil)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if buf[0] != TopicStatsTag {
+		t.Errorf("expected serialized prefix %d, got %d", TopicStatsTag, buf[0])
+	}
+
+	var unmarshaled TopicStatsKey
+	err = UnmarshalTo(buf, &unmarshaled)
+	if err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if expected != unmarshaled {
+		t.Errorf("%s", cmp.Diff(expected, unmarshaled))
+	}
+}
+
+func TestMarshalChannelStatsKey(t *testing.T) {
+	expected := ChannelStatsKey{
+		Channel: "123",
+		Topic:   "abc",
+	}
+	buf, err := expected.Marshal(nil)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if buf[0] != ChannelStatsTag {
+		t.Errorf("expected serialized prefix %d, got %d", ChannelStatsTag, buf[0])
+	}
+
+	var unmarshaled ChannelStatsKey
+	err = UnmarshalTo(buf, &unmarshaled)
+	if err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if expected != unmarshaled {
+		t.Errorf("%s", cmp.Diff(expected, unmarshaled))
+	}
+}
@@ -0,0 +1,20 @@
+package data
+
+import "time"
+
+// UnixNano returns t as the number of nanoseconds since the unix epoch, or zero if t is the zero
+// time. Optional times, such as the time an event is delivered at, are stored this way.
+func UnixNano(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano()
+}
+
+// FromUnixNano is the inverse of UnixNano.
+func FromUnixNano(nsec int64) time.Time {
+	if nsec == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, nsec)
+}
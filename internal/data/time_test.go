@@ -0,0 +1,20 @@
+package data
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUnixNano(t *testing.T) {
+	if nsec := UnixNano(time.Time{}); nsec != 0 {
+		t.Errorf("expected zero time as 0, got %d", nsec)
+	}
+	if actual := FromUnixNano(0); !actual.IsZero() {
+		t.Errorf("expected 0 as zero time, got %v", actual)
+	}
+
+	expected := time.Unix(1500000000, 123)
+	if actual := FromUnixNano(UnixNano(expected)); !actual.Equal(expected) {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+}
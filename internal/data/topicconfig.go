@@ -0,0 +1,59 @@
+package data
+
+import (
+	"errors"
+	"strings"
+)
+
+// TopicConfigKey is a key for TopicConfigPayloads. It can be marshalled and used in a key-value
+// store.
+//
+// The marshalled format of a TopicConfigKey is:
+// TopicConfigTag + Sep + Topic
+type TopicConfigKey struct {
+	// Topic must not contain the null character.
+	Topic string
+}
+
+func (key TopicConfigKey) isKey() {}
+
+// Size returns the length of this key's marshalled data. The result is only
+// valid until the key is modified.
+func (key TopicConfigKey) Size() int {
+	return len(key.Topic) + 2
+}
+
+// Marshal marshals a key into a byte slice, prefixed according to the key's type.
+//
+// If buf is nil or has insufficient capacity, a new buffer is allocated. Marshal returns the
+// slice that index was marshalled to.
+func (key TopicConfigKey) Marshal(buf []byte) ([]byte, error) {
+
+	if strings.ContainsRune(key.Topic, 0) {
+		return nil, errors.New("Topic cannot contain null character")
+	}
+
+	size := key.Size()
+	if cap(buf) < size {
+		buf = make([]byte, 0, size)
+	} else {
+		buf = buf[:0]
+	}
+
+	buf = append(buf, TopicConfigTag, Sep)
+	buf = append(buf, key.Topic...)
+
+	return buf, nil
+}
+
+// UnmarshalTopicConfigKey updates the this key's values by decoding the provided buf
+func UnmarshalTopicConfigKey(buf []byte, key *TopicConfigKey) error {
+	if len(buf) < 2 || buf[0] != TopicConfigTag || buf[1] != Sep {
+		return errors.New("not a TopicConfigKey")
+	}
+	key.Topic = string(buf[2:])
+	return nil
+}
+
+// TopicConfigPrefix is the prefix of all TopicConfigKeys.
+var TopicConfigPrefix = []byte{TopicConfigTag, Sep}
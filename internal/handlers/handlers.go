@@ -1,25 +1,41 @@
 package handlers
 
 import (
+	"bufio"
 	"context"
+	"fmt"
+	"io"
 	"log"
 	"math"
 	"time"
 
 	"gitlab.com/katcheCode/deq"
 	pb "gitlab.com/katcheCode/deq/api/v1/deq"
+	"gitlab.com/katcheCode/deq/internal/data"
+	"gitlab.com/katcheCode/deq/trace"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
 // Server represents the gRPC server
 type Server struct {
-	store *deq.Store
+	store   *deq.Store
+	metrics *Metrics
 }
 
-// NewServer creates a new event store server initalized with a backing event store
-func NewServer(eventStore *deq.Store) *Server {
-	return &Server{eventStore}
+// NewServer creates a new event store server initalized with a backing event store. If m is
+// non-nil, the server records its metrics to m, and m's registry collects the store's metrics.
+func NewServer(eventStore *deq.Store, m *Metrics) *Server {
+	if m != nil {
+		m.registry.OnCollect(func() {
+			m.collect(eventStore)
+		})
+	}
+	return &Server{
+		store:   eventStore,
+		metrics: m,
+	}
 }
 
 // Pub implements DEQ.Pub
@@ -28,17 +44,9 @@ func (s *Server) Pub(ctx context.Context, in *pb.PubRequest) (*pb.Event, error)
 	if in.Event == nil {
 		return nil, status.Error(codes.InvalidArgument, "Missing required argument event")
 	}
-	if in.Event.Id == "" {
-		return nil, status.Error(codes.InvalidArgument, "Missing required argument event.id")
-	}
-	if in.Event.Topic == "" {
-		return nil, status.Error(codes.InvalidArgument, "Missing required argument event.topic")
-	}
-	if in.Event.DefaultState == pb.EventState_UNSPECIFIED_STATE {
-		in.Event.DefaultState = pb.EventState_QUEUED
-	}
-	if in.Event.CreateTime <= 0 {
-		in.Event.CreateTime = time.Now().UnixNano()
+	err := prepareEvent(in.Event, "event")
+	if err != nil {
+		return nil, err
 	}
 
 	channel := s.store.Channel(in.AwaitChannel, in.Event.Topic)
@@ -50,14 +58,20 @@ func (s *Server) Pub(ctx context.Context, in *pb.PubRequest) (*pb.Event, error)
 		defer sub.Close()
 	}
 
-	e, err := s.store.Pub(ctx, protoToEvent(in.Event))
+	e, published, err := s.store.Publish(ctx, withRequestTraceContext(ctx, protoToEvent(in.Event)))
 	if err == deq.ErrAlreadyExists {
 		return nil, status.Error(codes.AlreadyExists, "a different event with the same id already exists")
 	}
+	if err, ok := err.(*deq.InvalidPayloadError); ok {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 	if err != nil {
 		log.Printf("create event: %v", err)
 		return nil, status.Error(codes.Internal, "")
 	}
+	if published {
+		s.metrics.eventsPublished(e.Topic, 1)
+	}
 
 	if sub != nil {
 		for e.State == deq.EventStateQueued {
@@ -75,16 +89,87 @@ func (s *Server) Pub(ctx context.Context, in *pb.PubRequest) (*pb.Event, error)
 	return eventToProto(e), nil
 }
 
+// PubBatch implements DEQ.PubBatch
+func (s *Server) PubBatch(ctx context.Context, in *pb.PubBatchRequest) (*pb.PubBatchResponse, error) {
+
+	if len(in.Events) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Missing required argument events")
+	}
+
+	events := make([]deq.Event, len(in.Events))
+	for i, e := range in.Events {
+		if e == nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Missing required argument events[%d]", i)
+		}
+		err := prepareEvent(e, fmt.Sprintf("events[%d]", i))
+		if err != nil {
+			return nil, err
+		}
+		events[i] = withRequestTraceContext(ctx, protoToEvent(e))
+	}
+
+	results, published, err := s.store.PublishBatch(ctx, events)
+	if err == deq.ErrAlreadyExists {
+		return nil, status.Error(codes.AlreadyExists, "a different event with the same id already exists")
+	}
+	if err, ok := err.(*deq.InvalidPayloadError); ok {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		log.Printf("PubBatch: %v", err)
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	resp := &pb.PubBatchResponse{
+		Events: make([]*pb.Event, len(results)),
+	}
+	for i, e := range results {
+		resp.Events[i] = eventToProto(e)
+		if published[i] {
+			s.metrics.eventsPublished(e.Topic, 1)
+		}
+	}
+
+	return resp, nil
+}
+
+// prepareEvent validates an event to be published and applies its defaults. name is the name of
+// the argument used in error messages.
+func prepareEvent(e *pb.Event, name string) error {
+	if e.Id == "" {
+		return status.Errorf(codes.InvalidArgument, "Missing required argument %s.id", name)
+	}
+	if e.Topic == "" {
+		return status.Errorf(codes.InvalidArgument, "Missing required argument %s.topic", name)
+	}
+	if _, ok := pb.EventState_name[int32(e.DefaultState)]; !ok {
+		return status.Errorf(codes.InvalidArgument, "Invalid value for argument %s.default_state", name)
+	}
+	if e.RequeueLimit < -1 {
+		return status.Errorf(codes.InvalidArgument, "Invalid value for argument %s.requeue_limit", name)
+	}
+	if e.DeliverAt < 0 {
+		return status.Errorf(codes.InvalidArgument, "Invalid value for argument %s.deliver_at", name)
+	}
+	if e.CreateTime <= 0 {
+		e.CreateTime = time.Now().UnixNano()
+	}
+	return nil
+}
+
 // Sub implements DEQ.Sub
 func (s *Server) Sub(in *pb.SubRequest, stream pb.DEQ_SubServer) error {
 
 	if in.Channel == "" {
 		return status.Error(codes.InvalidArgument, "Missing required argument 'channel'")
 	}
+	if in.DeadLetterTopic != "" && in.DeadLetterTopic == in.Topic {
+		return status.Error(codes.InvalidArgument, "argument dead_letter_topic must be different from topic")
+	}
 
-	baseRequeueDelay := time.Duration(in.RequeueDelayMilliseconds) * time.Millisecond
-	if baseRequeueDelay == 0 {
-		baseRequeueDelay = 8 * time.Second
+	config := s.store.TopicConfig(in.Topic)
+	if in.RequeueDelayMilliseconds != 0 {
+		config.RequeueDelay = time.Duration(in.RequeueDelayMilliseconds) * time.Millisecond
 	}
 	idleTimeout := time.Duration(in.IdleTimeoutMilliseconds) * time.Millisecond
 	if idleTimeout == 0 && !in.Follow {
@@ -94,7 +179,13 @@ func (s *Server) Sub(in *pb.SubRequest, stream pb.DEQ_SubServer) error {
 	channel := s.store.Channel(in.Channel, in.Topic)
 	defer channel.Close()
 
-	channel.BackoffFunc(deq.ExponentialBackoff(baseRequeueDelay))
+	subDone := s.metrics.subStarted(in.Topic, in.Channel)
+	defer subDone()
+
+	channel.BackoffFunc(config.BackoffFunc(8 * time.Second))
+	if in.DeadLetterTopic != "" {
+		channel.DeadLetterTopic(in.DeadLetterTopic)
+	}
 
 	nextCtx := stream.Context()
 	cancel := func() {}
@@ -172,6 +263,14 @@ func (s *Server) Ack(ctx context.Context, in *pb.AckRequest) (*pb.AckResponse, e
 		return nil, status.Error(codes.Internal, "")
 	}
 
+	if in.Error != "" {
+		err = channel.RecordEventError(in.EventId, in.Error)
+		if err != nil {
+			log.Printf("record event %s error: %v", in.EventId, err)
+			return nil, status.Error(codes.Internal, "")
+		}
+	}
+
 	err = channel.SetEventState(in.EventId, eventState)
 	if err == deq.ErrNotFound {
 		return nil, status.Error(codes.NotFound, "")
@@ -181,6 +280,8 @@ func (s *Server) Ack(ctx context.Context, in *pb.AckRequest) (*pb.AckResponse, e
 		return nil, status.Error(codes.Internal, "")
 	}
 
+	s.metrics.eventAcked(in.Topic, in.Channel, in.Code.String())
+
 	if eventState == deq.EventStateQueued {
 
 		baseTime := time.Second
@@ -309,6 +410,68 @@ func (s *Server) Del(ctx context.Context, in *pb.DelRequest) (*pb.Empty, error)
 	return &pb.Empty{}, nil
 }
 
+// DelTopic implements DEQ.DelTopic
+func (s *Server) DelTopic(ctx context.Context, in *pb.DelTopicRequest) (*pb.DelTopicResponse, error) {
+
+	if in.Topic == "" {
+		return nil, status.Error(codes.InvalidArgument, "topic is required")
+	}
+
+	var opts deq.DelTopicOpts
+	if in.Before > 0 {
+		opts.Before = time.Unix(0, in.Before)
+	}
+
+	deleted, err := s.store.DelTopic(ctx, in.Topic, opts)
+	if err == context.Canceled || err == context.DeadlineExceeded {
+		return nil, status.FromContextError(err).Err()
+	}
+	if err != nil {
+		log.Printf("DelTopic: %v", err)
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	return &pb.DelTopicResponse{
+		DeletedCount: int64(deleted),
+	}, nil
+}
+
+// Redrive implements DEQ.Redrive
+func (s *Server) Redrive(ctx context.Context, in *pb.RedriveRequest) (*pb.RedriveResponse, error) {
+
+	if in.Topic == "" {
+		return nil, status.Error(codes.InvalidArgument, "topic is required")
+	}
+	if in.Channel == "" {
+		return nil, status.Error(codes.InvalidArgument, "channel is required")
+	}
+
+	filter := deq.RedriveFilter{
+		MinID:       in.MinId,
+		MaxID:       in.MaxId,
+		IndexPrefix: in.IndexPrefix,
+	}
+	if in.MinCreateTime > 0 {
+		filter.MinCreateTime = time.Unix(0, in.MinCreateTime)
+	}
+	if in.MaxCreateTime > 0 {
+		filter.MaxCreateTime = time.Unix(0, in.MaxCreateTime)
+	}
+
+	channel := s.store.Channel(in.Channel, in.Topic)
+	defer channel.Close()
+
+	count, err := channel.Redrive(filter)
+	if err != nil {
+		log.Printf("Redrive: %v", err)
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	return &pb.RedriveResponse{
+		RedrivenCount: int64(count),
+	}, nil
+}
+
 // Topics implements DEQ.Topics
 func (s *Server) Topics(ctx context.Context, in *pb.TopicsRequest) (*pb.TopicsResponse, error) {
 
@@ -329,6 +492,392 @@ func (s *Server) Topics(ctx context.Context, in *pb.TopicsRequest) (*pb.TopicsRe
 	}, nil
 }
 
+// RegisterSchema implements DEQ.RegisterSchema
+func (s *Server) RegisterSchema(ctx context.Context, in *pb.RegisterSchemaRequest) (*pb.Schema, error) {
+
+	if in.Topic == "" {
+		return nil, status.Error(codes.InvalidArgument, "argument topic is required")
+	}
+	if len(in.FileDescriptorSet) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "argument file_descriptor_set is required")
+	}
+
+	schema, err := s.store.SetSchema(in.Topic, deq.Schema{
+		MessageType:       in.MessageType,
+		FileDescriptorSet: in.FileDescriptorSet,
+	})
+	if err, ok := err.(*deq.IncompatibleSchemaError); ok {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return schemaToProto(in.Topic, schema), nil
+}
+
+// GetSchema implements DEQ.GetSchema
+func (s *Server) GetSchema(ctx context.Context, in *pb.GetSchemaRequest) (*pb.Schema, error) {
+
+	if in.Topic == "" {
+		return nil, status.Error(codes.InvalidArgument, "argument topic is required")
+	}
+
+	schema, err := s.store.Schema(in.Topic)
+	if err == deq.ErrNotFound {
+		return nil, status.Error(codes.NotFound, "")
+	}
+	if err != nil {
+		log.Printf("get schema: %v", err)
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	return schemaToProto(in.Topic, schema), nil
+}
+
+// SetTopicConfig implements DEQ.SetTopicConfig
+func (s *Server) SetTopicConfig(ctx context.Context, in *pb.SetTopicConfigRequest) (*pb.TopicConfig, error) {
+
+	if in.Config == nil {
+		return nil, status.Error(codes.InvalidArgument, "argument config is required")
+	}
+	if in.Config.Topic == "" {
+		return nil, status.Error(codes.InvalidArgument, "argument config.topic is required")
+	}
+	if _, ok := pb.EventState_name[int32(in.Config.DefaultState)]; !ok {
+		return nil, status.Error(codes.InvalidArgument, "Invalid value for argument config.default_state")
+	}
+
+	config := deq.TopicConfig{
+		RequeueLimit: int(in.Config.RequeueLimit),
+		Backoff:      deq.Backoff(in.Config.Backoff),
+		RequeueDelay: time.Duration(in.Config.RequeueDelayMilliseconds) * time.Millisecond,
+		DefaultState: protoToState(in.Config.DefaultState),
+		Retention: deq.RetentionPolicy{
+			MaxAge:   time.Duration(in.Config.RetentionMaxAgeMilliseconds) * time.Millisecond,
+			MaxCount: int(in.Config.RetentionMaxCount),
+		},
+		DeadLetterTopic: in.Config.DeadLetterTopic,
+	}
+	err := s.store.SetTopicConfig(in.Config.Topic, config)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return topicConfigToProto(in.Config.Topic, config), nil
+}
+
+// GetTopicConfig implements DEQ.GetTopicConfig
+func (s *Server) GetTopicConfig(ctx context.Context, in *pb.GetTopicConfigRequest) (*pb.TopicConfig, error) {
+
+	if in.Topic == "" {
+		return nil, status.Error(codes.InvalidArgument, "argument topic is required")
+	}
+
+	return topicConfigToProto(in.Topic, s.store.TopicConfig(in.Topic)), nil
+}
+
+// SetSchedule implements DEQ.SetSchedule
+func (s *Server) SetSchedule(ctx context.Context, in *pb.SetScheduleRequest) (*pb.Schedule, error) {
+
+	if in.Schedule == nil {
+		return nil, status.Error(codes.InvalidArgument, "argument schedule is required")
+	}
+	if in.Schedule.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "argument schedule.name is required")
+	}
+	if in.Schedule.Topic == "" {
+		return nil, status.Error(codes.InvalidArgument, "argument schedule.topic is required")
+	}
+
+	schedule, err := s.store.SetSchedule(deq.Schedule{
+		Name:     in.Schedule.Name,
+		Cron:     in.Schedule.Cron,
+		Interval: time.Duration(in.Schedule.IntervalMilliseconds) * time.Millisecond,
+		Topic:    in.Schedule.Topic,
+		Payload:  in.Schedule.Payload,
+		Metadata: in.Schedule.Metadata,
+	})
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return scheduleToProto(schedule), nil
+}
+
+// ListSchedules implements DEQ.ListSchedules
+func (s *Server) ListSchedules(ctx context.Context, in *pb.ListSchedulesRequest) (*pb.ListSchedulesResponse, error) {
+
+	schedules := s.store.Schedules()
+
+	resp := &pb.ListSchedulesResponse{
+		Schedules: make([]*pb.Schedule, len(schedules)),
+	}
+	for i, schedule := range schedules {
+		resp.Schedules[i] = scheduleToProto(schedule)
+	}
+
+	return resp, nil
+}
+
+// DelSchedule implements DEQ.DelSchedule
+func (s *Server) DelSchedule(ctx context.Context, in *pb.DelScheduleRequest) (*pb.Empty, error) {
+
+	if in.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "argument name is required")
+	}
+
+	err := s.store.DelSchedule(in.Name)
+	if err == deq.ErrNotFound {
+		return nil, status.Error(codes.NotFound, "")
+	}
+	if err != nil {
+		log.Printf("DelSchedule: %v", err)
+		return nil, status.Error(codes.Internal, "")
+	}
+
+	return &pb.Empty{}, nil
+}
+
+func scheduleToProto(schedule deq.Schedule) *pb.Schedule {
+	return &pb.Schedule{
+		Name:                 schedule.Name,
+		Cron:                 schedule.Cron,
+		IntervalMilliseconds: int64(schedule.Interval / time.Millisecond),
+		Topic:                schedule.Topic,
+		Payload:              schedule.Payload,
+		Metadata:             schedule.Metadata,
+		LastTick:             schedule.LastTick.UnixNano(),
+	}
+}
+
+func topicConfigToProto(topic string, config deq.TopicConfig) *pb.TopicConfig {
+	return &pb.TopicConfig{
+		Topic:                       topic,
+		RequeueLimit:                int32(config.RequeueLimit),
+		Backoff:                     pb.Backoff(config.Backoff),
+		RequeueDelayMilliseconds:    int32(config.RequeueDelay / time.Millisecond),
+		DefaultState:                stateToProto(config.DefaultState),
+		RetentionMaxAgeMilliseconds: int64(config.Retention.MaxAge / time.Millisecond),
+		RetentionMaxCount:           int64(config.Retention.MaxCount),
+		DeadLetterTopic:             config.DeadLetterTopic,
+	}
+}
+
+func schemaToProto(topic string, schema deq.Schema) *pb.Schema {
+	return &pb.Schema{
+		Topic:             topic,
+		MessageType:       schema.MessageType,
+		FileDescriptorSet: schema.FileDescriptorSet,
+		Version:           schema.Version,
+	}
+}
+
+// backupChunkSize is the maximum size of the data in each BackupChunk.
+const backupChunkSize = 64 * 1024
+
+// Backup implements DEQ.Backup
+func (s *Server) Backup(in *pb.BackupRequest, stream pb.DEQ_BackupServer) error {
+
+	w := bufio.NewWriterSize(&backupChunkWriter{stream}, backupChunkSize)
+
+	version, err := s.store.Backup(w, in.Since)
+	if err == nil {
+		err = w.Flush()
+	}
+	if ctxErr := stream.Context().Err(); ctxErr != nil {
+		return status.FromContextError(ctxErr).Err()
+	}
+	if err != nil {
+		log.Printf("Backup: %v", err)
+		return status.Error(codes.Internal, "")
+	}
+
+	err = stream.Send(&pb.BackupChunk{
+		Version: version,
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// backupChunkWriter is an io.Writer that sends each write as a BackupChunk.
+type backupChunkWriter struct {
+	stream pb.DEQ_BackupServer
+}
+
+func (w *backupChunkWriter) Write(p []byte) (int, error) {
+	err := w.stream.Send(&pb.BackupChunk{
+		Data: p,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Restore implements DEQ.Restore
+func (s *Server) Restore(stream pb.DEQ_RestoreServer) error {
+
+	err := s.store.Restore(&restoreChunkReader{stream: stream})
+	if ctxErr := stream.Context().Err(); ctxErr != nil {
+		return status.FromContextError(ctxErr).Err()
+	}
+	if err == deq.ErrBadBackup {
+		return status.Error(codes.InvalidArgument, "backup is corrupt")
+	}
+	if err == deq.ErrVersionMismatch {
+		return status.Error(codes.FailedPrecondition, "backup was created by a different version of deq")
+	}
+	if err != nil {
+		log.Printf("Restore: %v", err)
+		return status.Error(codes.Internal, "")
+	}
+
+	return stream.SendAndClose(&pb.RestoreResponse{})
+}
+
+// restoreChunkReader is an io.Reader that reads the data of each RestoreRequest of a stream.
+type restoreChunkReader struct {
+	stream pb.DEQ_RestoreServer
+	buf    []byte
+}
+
+func (r *restoreChunkReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		in, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		r.buf = in.Data
+	}
+
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// Export implements DEQ.Export
+func (s *Server) Export(in *pb.ExportRequest, stream pb.DEQ_ExportServer) error {
+
+	if in.Topic == "" {
+		return status.Error(codes.InvalidArgument, "topic is required")
+	}
+	format, err := protoToExportFormat(in.Format)
+	if err != nil {
+		return err
+	}
+
+	w := bufio.NewWriterSize(&exportChunkWriter{stream}, backupChunkSize)
+
+	_, err = s.store.Export(stream.Context(), w, in.Topic, deq.ExportOpts{
+		Format:        format,
+		ChannelStates: in.ChannelStates,
+	})
+	if err == nil {
+		err = w.Flush()
+	}
+	if ctxErr := stream.Context().Err(); ctxErr != nil {
+		return status.FromContextError(ctxErr).Err()
+	}
+	if err != nil {
+		log.Printf("Export: %v", err)
+		return status.Error(codes.Internal, "")
+	}
+
+	return nil
+}
+
+// exportChunkWriter is an io.Writer that sends each write as an ExportChunk.
+type exportChunkWriter struct {
+	stream pb.DEQ_ExportServer
+}
+
+func (w *exportChunkWriter) Write(p []byte) (int, error) {
+	err := w.stream.Send(&pb.ExportChunk{
+		Data: p,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Import implements DEQ.Import
+func (s *Server) Import(stream pb.DEQ_ImportServer) error {
+
+	first, err := stream.Recv()
+	if err == io.EOF {
+		return stream.SendAndClose(&pb.ImportResponse{})
+	}
+	if err != nil {
+		return err
+	}
+	format, err := protoToExportFormat(first.Format)
+	if err != nil {
+		return err
+	}
+
+	r := &importChunkReader{
+		stream: stream,
+		buf:    first.Data,
+	}
+
+	count, err := s.store.Import(stream.Context(), r, deq.ImportOpts{
+		Format: format,
+	})
+	if ctxErr := stream.Context().Err(); ctxErr != nil {
+		return status.FromContextError(ctxErr).Err()
+	}
+	if err == deq.ErrBadExport {
+		return status.Errorf(codes.InvalidArgument, "export is corrupt after %d events", count)
+	}
+	if err == deq.ErrAlreadyExists {
+		return status.Errorf(codes.AlreadyExists, "a different event with the same id already exists, %d events imported", count)
+	}
+	if err != nil {
+		log.Printf("Import: %v", err)
+		return status.Error(codes.Internal, "")
+	}
+
+	return stream.SendAndClose(&pb.ImportResponse{
+		ImportedCount: int64(count),
+	})
+}
+
+// importChunkReader is an io.Reader that reads the data of each ImportRequest of a stream.
+type importChunkReader struct {
+	stream pb.DEQ_ImportServer
+	buf    []byte
+}
+
+func (r *importChunkReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		in, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		r.buf = in.Data
+	}
+
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+func protoToExportFormat(f pb.ExportFormat) (deq.ExportFormat, error) {
+	switch f {
+	case pb.ExportFormat_PROTO:
+		return deq.ExportFormatProto, nil
+	case pb.ExportFormat_JSON_LINES:
+		return deq.ExportFormatJSON, nil
+	default:
+		return 0, status.Errorf(codes.InvalidArgument, "unrecognized export format %v", f)
+	}
+}
+
 func eventToProto(e deq.Event) *pb.Event {
 	return &pb.Event{
 		Id:           e.ID,
@@ -338,6 +887,11 @@ func eventToProto(e deq.Event) *pb.Event {
 		DefaultState: stateToProto(e.DefaultState),
 		State:        stateToProto(e.State),
 		RequeueCount: int32(e.RequeueCount),
+		Metadata:     e.Metadata,
+		RequeueLimit: int32(e.RequeueLimit),
+		DeliverAt:    data.UnixNano(e.DeliverAt),
+		Scheduled:    e.Scheduled(time.Now()),
+		Priority:     int32(e.Priority),
 	}
 }
 
@@ -350,7 +904,38 @@ func protoToEvent(e *pb.Event) deq.Event {
 		DefaultState: protoToState(e.DefaultState),
 		State:        protoToState(e.State),
 		RequeueCount: int(e.RequeueCount),
+		Metadata:     e.Metadata,
+		RequeueLimit: int(e.RequeueLimit),
+		DeliverAt:    data.FromUnixNano(e.DeliverAt),
+		Priority:     int(e.Priority),
+	}
+}
+
+// withRequestTraceContext returns e with the trace context of the request recorded in its Metadata,
+// if the request carries a traceparent in its gRPC metadata and e doesn't already have one. This
+// lets clients that propagate trace context as request headers trace their events.
+func withRequestTraceContext(ctx context.Context, e deq.Event) deq.Event {
+	if _, ok := e.Metadata[trace.TraceparentKey]; ok {
+		return e
+	}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return e
+	}
+	traceparent := md.Get(trace.TraceparentKey)
+	if len(traceparent) == 0 {
+		return e
 	}
+	sc, err := trace.ParseTraceparent(traceparent[0])
+	if err != nil {
+		return e
+	}
+	if tracestate := md.Get(trace.TracestateKey); len(tracestate) > 0 {
+		sc.TraceState = tracestate[0]
+	}
+
+	e.Metadata = trace.Inject(sc, e.Metadata)
+	return e
 }
 
 func protoToState(s pb.EventState) deq.EventState {
@@ -0,0 +1,234 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"path"
+	"sync"
+	"time"
+
+	"gitlab.com/katcheCode/deq"
+	"gitlab.com/katcheCode/deq/internal/metrics"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// Metrics records metrics about the requests handled by a Server and the store it serves.
+//
+// A nil *Metrics is valid and records nothing.
+type Metrics struct {
+	registry *metrics.Registry
+
+	rpcDuration *metrics.Histogram
+	published   *metrics.Counter
+	acks        *metrics.Counter
+	subStreams  *metrics.Gauge
+
+	requeues           *metrics.Counter
+	requeuesExhausted  *metrics.Counter
+	topicEvents        *metrics.Gauge
+	topicPayloadBytes  *metrics.Gauge
+	channelBacklog     *metrics.Gauge
+	channelOldestQueue *metrics.Gauge
+	lsmSize            *metrics.Gauge
+	vlogSize           *metrics.Gauge
+
+	subsMu sync.Mutex
+	// subs counts the active Sub streams of each channel.
+	subs map[subKey]int
+
+	oldestMu sync.Mutex
+	// oldest caches the oldest queued event of each channel with active Sub streams.
+	oldest map[subKey]oldestQueued
+}
+
+// oldestQueuedInterval is how often the oldest queued event of a channel is looked up when
+// collecting metrics. In between, the age of the cached event is reported.
+const oldestQueuedInterval = time.Minute
+
+// oldestQueued is the oldest queued event of a channel as of checked.
+type oldestQueued struct {
+	checked time.Time
+	// createTime is the create time of the oldest queued event, or the zero time if there were no
+	// queued events.
+	createTime time.Time
+}
+
+type subKey struct {
+	channel, topic string
+}
+
+// NewMetrics creates a Metrics that registers its metrics on r.
+func NewMetrics(r *metrics.Registry) *Metrics {
+	return &Metrics{
+		registry: r,
+
+		rpcDuration: r.NewHistogram("deq_rpc_duration_seconds",
+			"Duration of DEQ RPCs, by method and status code. Streaming RPCs are measured until the stream ends.",
+			nil, "method", "code"),
+		published: r.NewCounter("deq_published_events_total",
+			"Number of new events published with Pub or PubBatch, by topic. Events that match an existing event are not counted.", "topic"),
+		acks: r.NewCounter("deq_acks_total",
+			"Number of events acknowledged with Ack, by topic, channel and ack code.", "topic", "channel", "code"),
+		subStreams: r.NewGauge("deq_sub_streams",
+			"Number of active Sub streams, by topic and channel.", "topic", "channel"),
+
+		requeues: r.NewCounter("deq_requeues_total",
+			"Number of times an event has been requeued on any channel."),
+		requeuesExhausted: r.NewCounter("deq_requeue_limit_exhausted_total",
+			"Number of times an event has been dequeued with an error because it reached the requeue limit."),
+		topicEvents: r.NewGauge("deq_topic_events",
+			"Number of events stored, by topic.", "topic"),
+		topicPayloadBytes: r.NewGauge("deq_topic_payload_bytes",
+			"Total size of the payloads of the stored events, by topic.", "topic"),
+		channelBacklog: r.NewGauge("deq_channel_backlog",
+			"Number of queued events on channels with active Sub streams, by topic and channel.", "topic", "channel"),
+		channelOldestQueue: r.NewGauge("deq_channel_oldest_queued_age_seconds",
+			"Age of the oldest queued event on channels with active Sub streams, by topic and channel.", "topic", "channel"),
+		lsmSize: r.NewGauge("deq_badger_lsm_size_bytes",
+			"Size of the database's LSM tree on disk."),
+		vlogSize: r.NewGauge("deq_badger_vlog_size_bytes",
+			"Size of the database's value log on disk."),
+
+		subs:   make(map[subKey]int),
+		oldest: make(map[subKey]oldestQueued),
+	}
+}
+
+// UnaryServerInterceptor records the duration of unary RPCs. It can be installed with
+// grpc.UnaryInterceptor.
+func (m *Metrics) UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	m.observeRPC(info.FullMethod, start, err)
+	return resp, err
+}
+
+// StreamServerInterceptor records the duration of streaming RPCs. It can be installed with
+// grpc.StreamInterceptor.
+func (m *Metrics) StreamServerInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(srv, ss)
+	m.observeRPC(info.FullMethod, start, err)
+	return err
+}
+
+func (m *Metrics) observeRPC(fullMethod string, start time.Time, err error) {
+	if m == nil {
+		return
+	}
+	m.rpcDuration.Observe(time.Since(start).Seconds(), path.Base(fullMethod), status.Code(err).String())
+}
+
+func (m *Metrics) eventsPublished(topic string, count int) {
+	if m == nil {
+		return
+	}
+	m.published.Add(float64(count), topic)
+}
+
+func (m *Metrics) eventAcked(topic, channel, code string) {
+	if m == nil {
+		return
+	}
+	m.acks.Inc(topic, channel, code)
+}
+
+// subStarted records the start of a Sub stream. The returned func must be called when the stream
+// ends.
+func (m *Metrics) subStarted(topic, channel string) func() {
+	if m == nil {
+		return func() {}
+	}
+
+	key := subKey{channel, topic}
+
+	m.subsMu.Lock()
+	m.subs[key]++
+	m.subStreams.Set(float64(m.subs[key]), topic, channel)
+	m.subsMu.Unlock()
+
+	return func() {
+		m.subsMu.Lock()
+		defer m.subsMu.Unlock()
+
+		m.subs[key]--
+		m.subStreams.Set(float64(m.subs[key]), topic, channel)
+		if m.subs[key] == 0 {
+			delete(m.subs, key)
+		}
+	}
+}
+
+// collect updates the metrics that are read from store.
+func (m *Metrics) collect(store *deq.Store) {
+	stats, err := store.Stats()
+	if err != nil {
+		log.Printf("[WARN] collect metrics: get store stats: %v", err)
+		return
+	}
+
+	m.requeues.Set(float64(stats.Requeues))
+	m.requeuesExhausted.Set(float64(stats.RequeueLimitExhausted))
+	m.lsmSize.Set(float64(stats.LSMSize))
+	m.vlogSize.Set(float64(stats.VLogSize))
+
+	m.topicEvents.Reset()
+	m.topicPayloadBytes.Reset()
+	for topic, topicStats := range stats.Topics {
+		m.topicEvents.Set(float64(topicStats.Events), topic)
+		m.topicPayloadBytes.Set(float64(topicStats.PayloadBytes), topic)
+	}
+
+	m.subsMu.Lock()
+	subs := make([]subKey, 0, len(m.subs))
+	for key := range m.subs {
+		subs = append(subs, key)
+	}
+	m.subsMu.Unlock()
+
+	m.channelBacklog.Reset()
+	m.channelOldestQueue.Reset()
+
+	m.oldestMu.Lock()
+	defer m.oldestMu.Unlock()
+
+	oldest := make(map[subKey]oldestQueued, len(subs))
+	for _, key := range subs {
+		channel := store.Channel(key.channel, key.topic)
+		backlog, err := channel.Backlog()
+		if err != nil {
+			channel.Close()
+			log.Printf("[WARN] collect metrics: get backlog of channel %s on topic %s: %v", key.channel, key.topic, err)
+			continue
+		}
+		m.channelBacklog.Set(float64(backlog), key.topic, key.channel)
+
+		// Finding the oldest queued event is more expensive than reading the counters, so it is only
+		// done periodically.
+		cached, ok := m.oldest[key]
+		if !ok || time.Since(cached.checked) >= oldestQueuedInterval {
+			channelStats, err := channel.Stats()
+			if err != nil {
+				channel.Close()
+				log.Printf("[WARN] collect metrics: get stats of channel %s on topic %s: %v", key.channel, key.topic, err)
+				continue
+			}
+			now := time.Now()
+			cached = oldestQueued{checked: now}
+			if channelStats.OldestQueuedAge > 0 {
+				cached.createTime = now.Add(-channelStats.OldestQueuedAge)
+			}
+		}
+		channel.Close()
+
+		oldest[key] = cached
+		if backlog > 0 && !cached.createTime.IsZero() {
+			m.channelOldestQueue.Set(time.Since(cached.createTime).Seconds(), key.topic, key.channel)
+		} else {
+			m.channelOldestQueue.Set(0, key.topic, key.channel)
+		}
+	}
+	// Channels without active Sub streams are dropped from the cache.
+	m.oldest = oldest
+}
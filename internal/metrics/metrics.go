@@ -0,0 +1,304 @@
+// Package metrics provides counters, gauges and histograms that are served over HTTP in the
+// Prometheus text exposition format.
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultBuckets are the default upper bounds of a histogram's buckets, suitable for request
+// latencies in seconds.
+var DefaultBuckets = []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// Registry holds a set of metrics. A Registry is an http.Handler that serves its metrics in the
+// Prometheus text exposition format.
+//
+// All methods of Registry are safe for concurrent use.
+type Registry struct {
+	mu       sync.Mutex
+	metrics  []*vec
+	names    map[string]bool
+	collects []func()
+}
+
+// NewRegistry creates a new, empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		names: make(map[string]bool),
+	}
+}
+
+// OnCollect registers f to be called each time the registry's metrics are served, before they are
+// written. It can be used to update metrics that are read from another source, such as the size
+// of a queue.
+func (r *Registry) OnCollect(f func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.collects = append(r.collects, f)
+}
+
+// Counter is a metric that only increases, partitioned by a set of labels.
+type Counter struct {
+	vec *vec
+}
+
+// NewCounter registers a new counter. NewCounter panics if a metric with the same name is already
+// registered.
+func (r *Registry) NewCounter(name, help string, labels ...string) *Counter {
+	return &Counter{r.register(name, help, "counter", nil, labels)}
+}
+
+// Inc increments the counter with the given label values by one.
+func (c *Counter) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// Add adds v to the counter with the given label values. v must not be negative.
+func (c *Counter) Add(v float64, labelValues ...string) {
+	c.vec.update(labelValues, func(s *series) {
+		s.value += v
+	})
+}
+
+// Set sets the counter with the given label values to v. It is intended for counters that are
+// maintained elsewhere and copied into the registry by an OnCollect func.
+func (c *Counter) Set(v float64, labelValues ...string) {
+	c.vec.update(labelValues, func(s *series) {
+		s.value = v
+	})
+}
+
+// Gauge is a metric that can increase and decrease, partitioned by a set of labels.
+type Gauge struct {
+	vec *vec
+}
+
+// NewGauge registers a new gauge. NewGauge panics if a metric with the same name is already
+// registered.
+func (r *Registry) NewGauge(name, help string, labels ...string) *Gauge {
+	return &Gauge{r.register(name, help, "gauge", nil, labels)}
+}
+
+// Set sets the gauge with the given label values to v.
+func (g *Gauge) Set(v float64, labelValues ...string) {
+	g.vec.update(labelValues, func(s *series) {
+		s.value = v
+	})
+}
+
+// Add adds v to the gauge with the given label values.
+func (g *Gauge) Add(v float64, labelValues ...string) {
+	g.vec.update(labelValues, func(s *series) {
+		s.value += v
+	})
+}
+
+// Reset removes every series of the gauge. It can be used by an OnCollect func to drop series that
+// no longer exist before setting the current ones.
+func (g *Gauge) Reset() {
+	g.vec.mu.Lock()
+	defer g.vec.mu.Unlock()
+
+	g.vec.series = make(map[string]*series)
+}
+
+// Histogram is a metric that samples observations into buckets, partitioned by a set of labels.
+type Histogram struct {
+	vec *vec
+}
+
+// NewHistogram registers a new histogram with the given bucket upper bounds, which must be sorted
+// in increasing order. If buckets is nil, DefaultBuckets are used. NewHistogram panics if a metric
+// with the same name is already registered.
+func (r *Registry) NewHistogram(name, help string, buckets []float64, labels ...string) *Histogram {
+	if buckets == nil {
+		buckets = DefaultBuckets
+	}
+	return &Histogram{r.register(name, help, "histogram", buckets, labels)}
+}
+
+// Observe adds an observation of v to the histogram with the given label values.
+func (h *Histogram) Observe(v float64, labelValues ...string) {
+	h.vec.update(labelValues, func(s *series) {
+		if s.buckets == nil {
+			s.buckets = make([]uint64, len(h.vec.buckets))
+		}
+		for i, bound := range h.vec.buckets {
+			if v <= bound {
+				s.buckets[i]++
+			}
+		}
+		s.count++
+		s.value += v
+	})
+}
+
+// ServeHTTP writes the registry's metrics to w.
+func (r *Registry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mu.Lock()
+	collects := r.collects
+	metrics := r.metrics
+	r.mu.Unlock()
+
+	for _, collect := range collects {
+		collect()
+	}
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+
+	buf := bufio.NewWriter(w)
+	for _, m := range metrics {
+		m.write(buf)
+	}
+	buf.Flush()
+}
+
+func (r *Registry) register(name, help, kind string, buckets []float64, labels []string) *vec {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.names[name] {
+		panic(fmt.Sprintf("metrics: %s is already registered", name))
+	}
+	r.names[name] = true
+
+	v := &vec{
+		name:    name,
+		help:    help,
+		kind:    kind,
+		labels:  labels,
+		buckets: buckets,
+		series:  make(map[string]*series),
+	}
+	r.metrics = append(r.metrics, v)
+	return v
+}
+
+// vec is a metric along with each of its series.
+type vec struct {
+	name    string
+	help    string
+	kind    string
+	labels  []string
+	buckets []float64
+
+	mu     sync.Mutex
+	series map[string]*series
+}
+
+// series is the value of a metric for one set of label values.
+type series struct {
+	labelValues []string
+	value       float64
+	// count and buckets are only used by histograms.
+	count   uint64
+	buckets []uint64
+}
+
+func (v *vec) update(labelValues []string, f func(*series)) {
+	if len(labelValues) != len(v.labels) {
+		panic(fmt.Sprintf("metrics: %s has %d labels, got %d values", v.name, len(v.labels), len(labelValues)))
+	}
+
+	key := strings.Join(labelValues, "\x00")
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	s := v.series[key]
+	if s == nil {
+		s = &series{labelValues: append([]string(nil), labelValues...)}
+		v.series[key] = s
+	}
+	f(s)
+}
+
+func (v *vec) write(w *bufio.Writer) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	fmt.Fprintf(w, "# HELP %s %s\n", v.name, escapeHelp(v.help))
+	fmt.Fprintf(w, "# TYPE %s %s\n", v.name, v.kind)
+
+	keys := make([]string, 0, len(v.series))
+	for key := range v.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		s := v.series[key]
+		labels := formatLabels(v.labels, s.labelValues)
+
+		if v.kind != "histogram" {
+			fmt.Fprintf(w, "%s%s %s\n", v.name, labels, formatValue(s.value))
+			continue
+		}
+
+		// Limit the capacity of the slices so appending "le" copies them.
+		names := append(v.labels[:len(v.labels):len(v.labels)], "le")
+		values := s.labelValues[:len(s.labelValues):len(s.labelValues)]
+		for i, bound := range v.buckets {
+			le := formatLabels(names, append(values, formatValue(bound)))
+			fmt.Fprintf(w, "%s_bucket%s %d\n", v.name, le, s.buckets[i])
+		}
+		le := formatLabels(names, append(values, "+Inf"))
+		fmt.Fprintf(w, "%s_bucket%s %d\n", v.name, le, s.count)
+		fmt.Fprintf(w, "%s_sum%s %s\n", v.name, labels, formatValue(s.value))
+		fmt.Fprintf(w, "%s_count%s %d\n", v.name, labels, s.count)
+	}
+}
+
+func formatLabels(names, values []string) string {
+	if len(names) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, name := range names {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(name)
+		b.WriteString(`="`)
+		b.WriteString(escapeLabel(values[i]))
+		b.WriteByte('"')
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+func formatValue(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	default:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+}
+
+var (
+	helpEscaper  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+func escapeHelp(s string) string {
+	return helpEscaper.Replace(s)
+}
+
+func escapeLabel(s string) string {
+	return labelEscaper.Replace(s)
+}
@@ -0,0 +1,53 @@
+package metrics
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	r := NewRegistry()
+
+	counter := r.NewCounter("test_events_total", "Number of events.", "topic")
+	gauge := r.NewGauge("test_streams", "Number of \"open\" streams.")
+	histogram := r.NewHistogram("test_duration_seconds", "Duration.", []float64{0.1, 1}, "method")
+
+	counter.Inc("b")
+	counter.Add(2, "a")
+	counter.Inc("a\"quoted\"")
+	histogram.Observe(0.05, "Pub")
+	histogram.Observe(0.5, "Pub")
+	histogram.Observe(5, "Pub")
+
+	r.OnCollect(func() {
+		gauge.Set(3)
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+
+	expected := `# HELP test_events_total Number of events.
+# TYPE test_events_total counter
+test_events_total{topic="a"} 2
+test_events_total{topic="a\"quoted\""} 1
+test_events_total{topic="b"} 1
+# HELP test_streams Number of "open" streams.
+# TYPE test_streams gauge
+test_streams 3
+# HELP test_duration_seconds Duration.
+# TYPE test_duration_seconds histogram
+test_duration_seconds_bucket{method="Pub",le="0.1"} 1
+test_duration_seconds_bucket{method="Pub",le="1"} 2
+test_duration_seconds_bucket{method="Pub",le="+Inf"} 3
+test_duration_seconds_sum{method="Pub"} 5.55
+test_duration_seconds_count{method="Pub"} 3
+`
+	actual := w.Body.String()
+	if expected != actual {
+		t.Errorf("\n%s", cmp.Diff(expected, actual))
+	}
+}
@@ -0,0 +1,143 @@
+package schema
+
+import (
+	"fmt"
+
+	"github.com/gogo/protobuf/protoc-gen-gogo/descriptor"
+)
+
+// CheckCompatible returns an error if payloads encoded with old can't be read with updated.
+//
+// updated is backward compatible with old if, for every message reachable from old's message type:
+//   - Every field of the old message either has the same number in the updated message with a
+//     compatible type and the same cardinality, or its number is reserved in the updated message.
+//   - No field of the updated message reuses a number or name that is reserved in the old message.
+//   - The updated message doesn't add required fields.
+//
+// Types are compatible if they are encoded the same way: int32, uint32, int64, uint64, bool and
+// enums are compatible with each other, as are sint32 and sint64, fixed32 and sfixed32, fixed64 and
+// sfixed64, and string and bytes. Message fields are compatible if their message types are.
+func CheckCompatible(old, updated *Schema) error {
+	c := compatChecker{
+		old:     old,
+		updated: updated,
+		checked: make(map[[2]*message]bool),
+	}
+	return c.checkMessage(old.messages[old.MessageType], updated.messages[updated.MessageType])
+}
+
+type compatChecker struct {
+	old, updated *Schema
+	// checked holds the pairs of messages that are already checked, to handle recursive types.
+	checked map[[2]*message]bool
+}
+
+func (c *compatChecker) checkMessage(old, updated *message) error {
+	pair := [2]*message{old, updated}
+	if c.checked[pair] {
+		return nil
+	}
+	c.checked[pair] = true
+
+	for _, oldField := range old.desc.Field {
+		number := oldField.GetNumber()
+		field, ok := updated.fields[number]
+		if !ok {
+			if !isReserved(updated.desc, number, oldField.GetName()) {
+				return fmt.Errorf("field %s.%s (%d) was removed without reserving its number", old.name, oldField.GetName(), number)
+			}
+			continue
+		}
+
+		name := fmt.Sprintf("field %s.%s (%d)", updated.name, field.GetName(), number)
+
+		oldRepeated := oldField.GetLabel() == descriptor.FieldDescriptorProto_LABEL_REPEATED
+		repeated := field.GetLabel() == descriptor.FieldDescriptorProto_LABEL_REPEATED
+		if oldRepeated != repeated {
+			return fmt.Errorf("%s changed between repeated and singular", name)
+		}
+		if field.GetLabel() == descriptor.FieldDescriptorProto_LABEL_REQUIRED &&
+			oldField.GetLabel() != descriptor.FieldDescriptorProto_LABEL_REQUIRED {
+			return fmt.Errorf("%s was made required", name)
+		}
+
+		if typeClass(oldField.GetType()) != typeClass(field.GetType()) {
+			return fmt.Errorf("%s changed type from %v to %v", name, oldField.GetType(), field.GetType())
+		}
+
+		switch field.GetType() {
+		case descriptor.FieldDescriptorProto_TYPE_MESSAGE, descriptor.FieldDescriptorProto_TYPE_GROUP:
+			oldNested, err := c.old.fieldMessage(oldField)
+			if err != nil {
+				return err
+			}
+			nested, err := c.updated.fieldMessage(field)
+			if err != nil {
+				return err
+			}
+			err = c.checkMessage(oldNested, nested)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, field := range updated.desc.Field {
+		number := field.GetNumber()
+		if _, ok := old.fields[number]; ok {
+			continue
+		}
+		name := fmt.Sprintf("field %s.%s (%d)", updated.name, field.GetName(), number)
+		if isReserved(old.desc, number, field.GetName()) {
+			return fmt.Errorf("%s reuses a reserved number or name", name)
+		}
+		if field.GetLabel() == descriptor.FieldDescriptorProto_LABEL_REQUIRED {
+			return fmt.Errorf("%s is a new required field", name)
+		}
+	}
+
+	return nil
+}
+
+// isReserved returns true if the number or name of a field is reserved in m.
+func isReserved(m *descriptor.DescriptorProto, number int32, name string) bool {
+	for _, r := range m.ReservedRange {
+		// The end of a reserved range is exclusive.
+		if number >= r.GetStart() && number < r.GetEnd() {
+			return true
+		}
+	}
+	for _, reserved := range m.ReservedName {
+		if reserved == name {
+			return true
+		}
+	}
+	return false
+}
+
+// typeClass groups field types that are encoded compatibly.
+func typeClass(t descriptor.FieldDescriptorProto_Type) string {
+	switch t {
+	case descriptor.FieldDescriptorProto_TYPE_INT32,
+		descriptor.FieldDescriptorProto_TYPE_UINT32,
+		descriptor.FieldDescriptorProto_TYPE_INT64,
+		descriptor.FieldDescriptorProto_TYPE_UINT64,
+		descriptor.FieldDescriptorProto_TYPE_BOOL,
+		descriptor.FieldDescriptorProto_TYPE_ENUM:
+		return "varint"
+	case descriptor.FieldDescriptorProto_TYPE_SINT32,
+		descriptor.FieldDescriptorProto_TYPE_SINT64:
+		return "zigzag"
+	case descriptor.FieldDescriptorProto_TYPE_FIXED32,
+		descriptor.FieldDescriptorProto_TYPE_SFIXED32:
+		return "fixed32"
+	case descriptor.FieldDescriptorProto_TYPE_FIXED64,
+		descriptor.FieldDescriptorProto_TYPE_SFIXED64:
+		return "fixed64"
+	case descriptor.FieldDescriptorProto_TYPE_STRING,
+		descriptor.FieldDescriptorProto_TYPE_BYTES:
+		return "bytes"
+	default:
+		return t.String()
+	}
+}
@@ -0,0 +1,337 @@
+// Package schema validates protobuf encoded payloads against message descriptors, and checks that
+// updates to the schema of a message are backward compatible.
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/gogo/protobuf/protoc-gen-gogo/descriptor"
+)
+
+// maxDepth is the maximum nesting depth of messages in a validated payload.
+const maxDepth = 100
+
+// Schema is a protobuf message type along with the descriptors of every type it depends on.
+type Schema struct {
+	// MessageType is the full name of the message type, without a leading dot.
+	MessageType string
+
+	messages map[string]*message
+	enums    map[string]bool
+}
+
+// message is an indexed message descriptor.
+type message struct {
+	name   string
+	desc   *descriptor.DescriptorProto
+	proto3 bool
+	fields map[int32]*descriptor.FieldDescriptorProto
+}
+
+// Parse parses a serialized google.protobuf.FileDescriptorSet, as written by protoc with the
+// --descriptor_set_out and --include_imports flags, and returns the schema of messageType.
+//
+// The set must contain messageType and every type that it depends on.
+func Parse(fileDescriptorSet []byte, messageType string) (*Schema, error) {
+	var set descriptor.FileDescriptorSet
+	err := proto.Unmarshal(fileDescriptorSet, &set)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal file descriptor set: %v", err)
+	}
+
+	s := &Schema{
+		MessageType: strings.TrimPrefix(messageType, "."),
+		messages:    make(map[string]*message),
+		enums:       make(map[string]bool),
+	}
+
+	for _, file := range set.File {
+		prefix := ""
+		if file.GetPackage() != "" {
+			prefix = file.GetPackage() + "."
+		}
+		proto3 := file.GetSyntax() == "proto3"
+		for _, enum := range file.EnumType {
+			s.enums[prefix+enum.GetName()] = true
+		}
+		for _, desc := range file.MessageType {
+			s.addMessage(prefix, desc, proto3)
+		}
+	}
+
+	root, ok := s.messages[s.MessageType]
+	if !ok {
+		return nil, fmt.Errorf("message type %s is not in the file descriptor set", s.MessageType)
+	}
+
+	err = s.checkReferences(root, make(map[*message]bool))
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *Schema) addMessage(prefix string, desc *descriptor.DescriptorProto, proto3 bool) {
+	m := &message{
+		name:   prefix + desc.GetName(),
+		desc:   desc,
+		proto3: proto3,
+		fields: make(map[int32]*descriptor.FieldDescriptorProto, len(desc.Field)),
+	}
+	for _, field := range desc.Field {
+		m.fields[field.GetNumber()] = field
+	}
+	s.messages[m.name] = m
+
+	for _, enum := range desc.EnumType {
+		s.enums[m.name+"."+enum.GetName()] = true
+	}
+	for _, nested := range desc.NestedType {
+		s.addMessage(m.name+".", nested, proto3)
+	}
+}
+
+// checkReferences checks that every message and enum referenced by m is in the schema.
+func (s *Schema) checkReferences(m *message, checked map[*message]bool) error {
+	if checked[m] {
+		return nil
+	}
+	checked[m] = true
+
+	for _, field := range m.desc.Field {
+		switch field.GetType() {
+		case descriptor.FieldDescriptorProto_TYPE_MESSAGE, descriptor.FieldDescriptorProto_TYPE_GROUP:
+			nested, err := s.fieldMessage(field)
+			if err != nil {
+				return fmt.Errorf("field %s.%s: %v", m.name, field.GetName(), err)
+			}
+			err = s.checkReferences(nested, checked)
+			if err != nil {
+				return err
+			}
+		case descriptor.FieldDescriptorProto_TYPE_ENUM:
+			name, err := typeName(field)
+			if err != nil {
+				return fmt.Errorf("field %s.%s: %v", m.name, field.GetName(), err)
+			}
+			if !s.enums[name] {
+				return fmt.Errorf("field %s.%s: enum type %s is not in the file descriptor set", m.name, field.GetName(), name)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Schema) fieldMessage(field *descriptor.FieldDescriptorProto) (*message, error) {
+	name, err := typeName(field)
+	if err != nil {
+		return nil, err
+	}
+	m, ok := s.messages[name]
+	if !ok {
+		return nil, fmt.Errorf("message type %s is not in the file descriptor set", name)
+	}
+	return m, nil
+}
+
+// typeName returns the full type name of a message or enum field, without a leading dot.
+func typeName(field *descriptor.FieldDescriptorProto) (string, error) {
+	name := field.GetTypeName()
+	if !strings.HasPrefix(name, ".") {
+		return "", fmt.Errorf("type name %q is not fully qualified", name)
+	}
+	return name[1:], nil
+}
+
+// Validate returns an error if payload isn't a valid encoding of the schema's message type.
+//
+// Unknown fields are rejected, so producers must register a schema with new fields before they
+// publish them.
+func (s *Schema) Validate(payload []byte) error {
+	_, err := s.validateMessage(payload, s.messages[s.MessageType], false, 0)
+	return err
+}
+
+// validateMessage validates the fields of m encoded in buf. If group is true, the message is a
+// group and ends at the first end group tag. validateMessage returns the number of bytes read.
+func (s *Schema) validateMessage(buf []byte, m *message, group bool, depth int) (int, error) {
+	if depth > maxDepth {
+		return 0, errors.New("messages are nested too deeply")
+	}
+
+	var required map[int32]bool
+	for _, field := range m.desc.Field {
+		if field.GetLabel() == descriptor.FieldDescriptorProto_LABEL_REQUIRED {
+			if required == nil {
+				required = make(map[int32]bool)
+			}
+			required[field.GetNumber()] = true
+		}
+	}
+
+	i := 0
+	for i < len(buf) {
+		tag, n := proto.DecodeVarint(buf[i:])
+		if n == 0 {
+			return 0, fmt.Errorf("%s: malformed tag", m.name)
+		}
+		i += n
+
+		number, wireType := int32(tag>>3), int(tag&7)
+		if wireType == proto.WireEndGroup {
+			if !group {
+				return 0, fmt.Errorf("%s: unexpected end group", m.name)
+			}
+			return i, checkRequired(m, required)
+		}
+		if number <= 0 {
+			return 0, fmt.Errorf("%s: invalid field number %d", m.name, number)
+		}
+
+		field, ok := m.fields[number]
+		if !ok {
+			return 0, fmt.Errorf("%s: unknown field number %d", m.name, number)
+		}
+		delete(required, number)
+
+		n, err := s.validateField(buf[i:], m, field, wireType, depth)
+		if err != nil {
+			return 0, err
+		}
+		i += n
+	}
+
+	if group {
+		return 0, fmt.Errorf("%s: missing end group", m.name)
+	}
+	return i, checkRequired(m, required)
+}
+
+func checkRequired(m *message, missing map[int32]bool) error {
+	for number := range missing {
+		return fmt.Errorf("%s: missing required field %s", m.name, m.fields[number].GetName())
+	}
+	return nil
+}
+
+// validateField validates a value of field encoded with wireType at the start of buf, and returns
+// the number of bytes read.
+func (s *Schema) validateField(buf []byte, m *message, field *descriptor.FieldDescriptorProto, wireType, depth int) (int, error) {
+	name := m.name + "." + field.GetName()
+	expected := fieldWireType(field.GetType())
+
+	if wireType == proto.WireStartGroup {
+		if field.GetType() != descriptor.FieldDescriptorProto_TYPE_GROUP {
+			return 0, fmt.Errorf("%s: wire type %d doesn't match type %v", name, wireType, field.GetType())
+		}
+		nested, err := s.fieldMessage(field)
+		if err != nil {
+			return 0, err
+		}
+		return s.validateMessage(buf, nested, true, depth+1)
+	}
+
+	packed := wireType == proto.WireBytes && expected != proto.WireBytes && expected != proto.WireStartGroup &&
+		field.GetLabel() == descriptor.FieldDescriptorProto_LABEL_REPEATED
+	if wireType != expected && !packed {
+		return 0, fmt.Errorf("%s: wire type %d doesn't match type %v", name, wireType, field.GetType())
+	}
+
+	switch wireType {
+	case proto.WireVarint:
+		_, n := proto.DecodeVarint(buf)
+		if n == 0 {
+			return 0, fmt.Errorf("%s: malformed varint", name)
+		}
+		return n, nil
+	case proto.WireFixed64:
+		if len(buf) < 8 {
+			return 0, fmt.Errorf("%s: unexpected end of payload", name)
+		}
+		return 8, nil
+	case proto.WireFixed32:
+		if len(buf) < 4 {
+			return 0, fmt.Errorf("%s: unexpected end of payload", name)
+		}
+		return 4, nil
+	case proto.WireBytes:
+		size, n := proto.DecodeVarint(buf)
+		if n == 0 || size > uint64(len(buf)-n) {
+			return 0, fmt.Errorf("%s: malformed length", name)
+		}
+		value := buf[n : n+int(size)]
+
+		var err error
+		switch {
+		case packed:
+			err = validatePacked(value, expected)
+		case field.GetType() == descriptor.FieldDescriptorProto_TYPE_MESSAGE:
+			var nested *message
+			nested, err = s.fieldMessage(field)
+			if err == nil {
+				_, err = s.validateMessage(value, nested, false, depth+1)
+			}
+		case field.GetType() == descriptor.FieldDescriptorProto_TYPE_STRING && m.proto3:
+			if !utf8.Valid(value) {
+				err = errors.New("string is not valid UTF-8")
+			}
+		}
+		if err != nil {
+			return 0, fmt.Errorf("%s: %v", name, err)
+		}
+		return n + int(size), nil
+	default:
+		return 0, fmt.Errorf("%s: invalid wire type %d", name, wireType)
+	}
+}
+
+// validatePacked validates the values of a packed repeated field.
+func validatePacked(buf []byte, wireType int) error {
+	switch wireType {
+	case proto.WireVarint:
+		for len(buf) > 0 {
+			_, n := proto.DecodeVarint(buf)
+			if n == 0 {
+				return errors.New("malformed packed varint")
+			}
+			buf = buf[n:]
+		}
+	case proto.WireFixed64:
+		if len(buf)%8 != 0 {
+			return errors.New("malformed packed fixed64")
+		}
+	case proto.WireFixed32:
+		if len(buf)%4 != 0 {
+			return errors.New("malformed packed fixed32")
+		}
+	}
+	return nil
+}
+
+// fieldWireType returns the wire type that a non-packed value of type t is encoded with.
+func fieldWireType(t descriptor.FieldDescriptorProto_Type) int {
+	switch t {
+	case descriptor.FieldDescriptorProto_TYPE_DOUBLE,
+		descriptor.FieldDescriptorProto_TYPE_FIXED64,
+		descriptor.FieldDescriptorProto_TYPE_SFIXED64:
+		return proto.WireFixed64
+	case descriptor.FieldDescriptorProto_TYPE_FLOAT,
+		descriptor.FieldDescriptorProto_TYPE_FIXED32,
+		descriptor.FieldDescriptorProto_TYPE_SFIXED32:
+		return proto.WireFixed32
+	case descriptor.FieldDescriptorProto_TYPE_STRING,
+		descriptor.FieldDescriptorProto_TYPE_BYTES,
+		descriptor.FieldDescriptorProto_TYPE_MESSAGE:
+		return proto.WireBytes
+	case descriptor.FieldDescriptorProto_TYPE_GROUP:
+		return proto.WireStartGroup
+	default:
+		return proto.WireVarint
+	}
+}
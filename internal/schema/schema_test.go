@@ -0,0 +1,218 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/gogo/protobuf/protoc-gen-gogo/descriptor"
+)
+
+// newFileDescriptorSet returns a serialized file descriptor set with a single proto3 file in
+// package test containing messages.
+func newFileDescriptorSet(t *testing.T, messages ...*descriptor.DescriptorProto) []byte {
+	t.Helper()
+
+	set := &descriptor.FileDescriptorSet{
+		File: []*descriptor.FileDescriptorProto{
+			{
+				Name:        proto.String("test.proto"),
+				Package:     proto.String("test"),
+				Syntax:      proto.String("proto3"),
+				MessageType: messages,
+			},
+		},
+	}
+	buf, err := proto.Marshal(set)
+	if err != nil {
+		t.Fatalf("marshal file descriptor set: %v", err)
+	}
+	return buf
+}
+
+func newField(name string, number int32, typ descriptor.FieldDescriptorProto_Type, label descriptor.FieldDescriptorProto_Label, typeName string) *descriptor.FieldDescriptorProto {
+	f := &descriptor.FieldDescriptorProto{
+		Name:   proto.String(name),
+		Number: proto.Int32(number),
+		Type:   typ.Enum(),
+		Label:  label.Enum(),
+	}
+	if typeName != "" {
+		f.TypeName = proto.String(typeName)
+	}
+	return f
+}
+
+const (
+	optional = descriptor.FieldDescriptorProto_LABEL_OPTIONAL
+	repeated = descriptor.FieldDescriptorProto_LABEL_REPEATED
+)
+
+// order returns the descriptor of the following message, with fields appended:
+//
+//   message Order {
+//     string id = 1;
+//     repeated int64 quantities = 2;
+//     Item item = 3;
+//   }
+func order(fields ...*descriptor.FieldDescriptorProto) *descriptor.DescriptorProto {
+	return &descriptor.DescriptorProto{
+		Name: proto.String("Order"),
+		Field: append([]*descriptor.FieldDescriptorProto{
+			newField("id", 1, descriptor.FieldDescriptorProto_TYPE_STRING, optional, ""),
+			newField("quantities", 2, descriptor.FieldDescriptorProto_TYPE_INT64, repeated, ""),
+			newField("item", 3, descriptor.FieldDescriptorProto_TYPE_MESSAGE, optional, ".test.Item"),
+		}, fields...),
+	}
+}
+
+// item returns the descriptor of:
+//
+//   message Item {
+//     string name = 1;
+//   }
+func item() *descriptor.DescriptorProto {
+	return &descriptor.DescriptorProto{
+		Name: proto.String("Item"),
+		Field: []*descriptor.FieldDescriptorProto{
+			newField("name", 1, descriptor.FieldDescriptorProto_TYPE_STRING, optional, ""),
+		},
+	}
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	set := newFileDescriptorSet(t, order(), item())
+
+	s, err := Parse(set, ".test.Order")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if s.MessageType != "test.Order" {
+		t.Errorf("expected message type test.Order, got %s", s.MessageType)
+	}
+
+	_, err = Parse(set, "test.Missing")
+	if err == nil {
+		t.Errorf("parse missing message type: expected error")
+	}
+
+	_, err = Parse(newFileDescriptorSet(t, order()), "test.Order")
+	if err == nil {
+		t.Errorf("parse with missing dependency: expected error")
+	}
+
+	_, err = Parse([]byte("not a descriptor set"), "test.Order")
+	if err == nil {
+		t.Errorf("parse invalid descriptor set: expected error")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	s, err := Parse(newFileDescriptorSet(t, order(), item()), "test.Order")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	// Encode the item message by hand: field 1, wire type 2, "pen".
+	encodedItem := []byte{0x0a, 3, 'p', 'e', 'n'}
+
+	for _, tc := range []struct {
+		name    string
+		payload []byte
+		valid   bool
+	}{
+		{"empty", nil, true},
+		{"string", []byte{0x0a, 2, 'a', 'b'}, true},
+		{"repeated", []byte{0x10, 1, 0x10, 2}, true},
+		{"packed", []byte{0x12, 2, 1, 2}, true},
+		{"nested", append([]byte{0x1a, byte(len(encodedItem))}, encodedItem...), true},
+		{"unknown field", []byte{0x20, 1}, false},
+		{"wrong wire type", []byte{0x0d, 1, 2, 3, 4}, false},
+		{"invalid utf-8", []byte{0x0a, 1, 0xff}, false},
+		{"truncated", []byte{0x0a, 5, 'a'}, false},
+		{"invalid nested", []byte{0x1a, 2, 0x10, 1}, false},
+		{"end group", []byte{0x0c}, false},
+	} {
+		err := s.Validate(tc.payload)
+		if tc.valid && err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+		}
+		if !tc.valid && err == nil {
+			t.Errorf("%s: expected error", tc.name)
+		}
+	}
+}
+
+func TestCheckCompatible(t *testing.T) {
+	t.Parallel()
+
+	old, err := Parse(newFileDescriptorSet(t, order(), item()), "test.Order")
+	if err != nil {
+		t.Fatalf("parse old: %v", err)
+	}
+
+	removed := order()
+	removed.Field = removed.Field[1:]
+	reserved := order()
+	reserved.Field = reserved.Field[1:]
+	reserved.ReservedRange = []*descriptor.DescriptorProto_ReservedRange{
+		{Start: proto.Int32(1), End: proto.Int32(2)},
+	}
+	retyped := order()
+	retyped.Field[0] = newField("id", 1, descriptor.FieldDescriptorProto_TYPE_INT64, optional, "")
+	widened := order()
+	widened.Field[1] = newField("quantities", 2, descriptor.FieldDescriptorProto_TYPE_INT32, repeated, "")
+	singular := order()
+	singular.Field[1] = newField("quantities", 2, descriptor.FieldDescriptorProto_TYPE_INT64, optional, "")
+	nestedRetyped := item()
+	nestedRetyped.Field[0] = newField("name", 1, descriptor.FieldDescriptorProto_TYPE_DOUBLE, optional, "")
+
+	for _, tc := range []struct {
+		name       string
+		messages   []*descriptor.DescriptorProto
+		compatible bool
+	}{
+		{"unchanged", []*descriptor.DescriptorProto{order(), item()}, true},
+		{"added field", []*descriptor.DescriptorProto{
+			order(newField("note", 4, descriptor.FieldDescriptorProto_TYPE_STRING, optional, "")), item(),
+		}, true},
+		{"removed and reserved", []*descriptor.DescriptorProto{reserved, item()}, true},
+		{"compatible type", []*descriptor.DescriptorProto{widened, item()}, true},
+		{"removed", []*descriptor.DescriptorProto{removed, item()}, false},
+		{"changed type", []*descriptor.DescriptorProto{retyped, item()}, false},
+		{"changed cardinality", []*descriptor.DescriptorProto{singular, item()}, false},
+		{"changed nested type", []*descriptor.DescriptorProto{order(), nestedRetyped}, false},
+	} {
+		updated, err := Parse(newFileDescriptorSet(t, tc.messages...), "test.Order")
+		if err != nil {
+			t.Fatalf("%s: parse: %v", tc.name, err)
+		}
+		err = CheckCompatible(old, updated)
+		if tc.compatible && err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+		}
+		if !tc.compatible && err == nil {
+			t.Errorf("%s: expected error", tc.name)
+		}
+	}
+
+	// Reusing a reserved number is incompatible.
+	withReserved, err := Parse(newFileDescriptorSet(t, reserved, item()), "test.Order")
+	if err != nil {
+		t.Fatalf("parse reserved: %v", err)
+	}
+	reused := order()
+	reused.Field[0] = newField("code", 1, descriptor.FieldDescriptorProto_TYPE_STRING, optional, "")
+	reused.Field = append(reused.Field[1:], reused.Field[0])
+	updated, err := Parse(newFileDescriptorSet(t, reused, item()), "test.Order")
+	if err != nil {
+		t.Fatalf("parse reused: %v", err)
+	}
+	err = CheckCompatible(withReserved, updated)
+	if err == nil {
+		t.Errorf("reused reserved number: expected error")
+	}
+}
@@ -0,0 +1,163 @@
+package storage
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// A backup is a sequence of entries. Each entry is a single byte entryType, followed by the
+// uvarint encoded length of the key and the key. Entries of type entrySet are then followed by the
+// uvarint encoded length of the value and the value.
+const (
+	entrySet    byte = 1
+	entryDelete byte = 2
+)
+
+// maxBackupEntrySize is the maximum size of the key or value of a backup entry.
+const maxBackupEntrySize = 1 << 30
+
+// loadBatchSize is the maximum number of entries written in a single transaction by Load.
+const loadBatchSize = 1000
+
+// ErrBadBackup is returned when loading a backup that is corrupt.
+var ErrBadBackup = errors.New("corrupt backup")
+
+// backupWriter writes the entries of a backup.
+type backupWriter struct {
+	w   *bufio.Writer
+	buf [binary.MaxVarintLen64]byte
+}
+
+func newBackupWriter(w io.Writer) *backupWriter {
+	return &backupWriter{w: bufio.NewWriter(w)}
+}
+
+func (w *backupWriter) Set(key, val []byte) error {
+	err := w.w.WriteByte(entrySet)
+	if err != nil {
+		return err
+	}
+	err = w.writeBytes(key)
+	if err != nil {
+		return err
+	}
+	return w.writeBytes(val)
+}
+
+func (w *backupWriter) Delete(key []byte) error {
+	err := w.w.WriteByte(entryDelete)
+	if err != nil {
+		return err
+	}
+	return w.writeBytes(key)
+}
+
+func (w *backupWriter) writeBytes(b []byte) error {
+	n := binary.PutUvarint(w.buf[:], uint64(len(b)))
+	_, err := w.w.Write(w.buf[:n])
+	if err != nil {
+		return err
+	}
+	_, err = w.w.Write(b)
+	return err
+}
+
+func (w *backupWriter) Flush() error {
+	return w.w.Flush()
+}
+
+// Load writes the entries of a backup created by DB.Backup to db. Backups created by any DB
+// implementation can be loaded into any other.
+//
+// The entries are written in multiple transactions, so a failed Load may leave db with only some of
+// the backup's entries.
+func Load(db DB, r io.Reader) error {
+	br := bufio.NewReader(r)
+
+	txn := db.NewTransaction(true)
+	defer func() {
+		txn.Discard()
+	}()
+
+	writes := 0
+	for {
+		typ, err := br.ReadByte()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		key, err := readBytes(br)
+		if err != nil {
+			return fmt.Errorf("read key: %v", err)
+		}
+
+		var write func() error
+		switch typ {
+		case entrySet:
+			val, err := readBytes(br)
+			if err != nil {
+				return fmt.Errorf("read value of %q: %v", key, err)
+			}
+			write = func() error { return txn.Set(key, val) }
+		case entryDelete:
+			write = func() error { return txn.Delete(key) }
+		default:
+			return ErrBadBackup
+		}
+
+		if writes >= loadBatchSize {
+			err = txn.Commit()
+			if err != nil {
+				return err
+			}
+			txn.Discard()
+			txn = db.NewTransaction(true)
+			writes = 0
+		}
+
+		err = write()
+		if err == ErrTxnTooBig && writes > 0 {
+			err = txn.Commit()
+			if err != nil {
+				return err
+			}
+			txn.Discard()
+			txn = db.NewTransaction(true)
+			writes = 0
+			err = write()
+		}
+		if err != nil {
+			return err
+		}
+		writes++
+	}
+
+	return txn.Commit()
+}
+
+func readBytes(r *bufio.Reader) ([]byte, error) {
+	n, err := binary.ReadUvarint(r)
+	if err == io.EOF {
+		return nil, ErrBadBackup
+	}
+	if err != nil {
+		return nil, err
+	}
+	if n > maxBackupEntrySize {
+		return nil, ErrBadBackup
+	}
+	buf := make([]byte, n)
+	_, err = io.ReadFull(r, buf)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return nil, ErrBadBackup
+	}
+	if err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
@@ -0,0 +1,156 @@
+package storage
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/dgraph-io/badger"
+)
+
+// OpenBadger opens a DB backed by badger.
+func OpenBadger(opts badger.Options) (DB, error) {
+	db, err := badger.Open(opts)
+	if err != nil {
+		return nil, err
+	}
+	return &badgerDB{db}, nil
+}
+
+type badgerDB struct {
+	db *badger.DB
+}
+
+func (db *badgerDB) NewTransaction(update bool) Txn {
+	return &badgerTxn{db.db.NewTransaction(update)}
+}
+
+func (db *badgerDB) Backup(w io.Writer, since uint64) (uint64, error) {
+	txn := db.db.NewTransaction(false)
+	defer txn.Discard()
+
+	bw := newBackupWriter(w)
+	version := since
+
+	opts := badger.DefaultIteratorOptions
+	opts.AllVersions = true
+	it := txn.NewIterator(opts)
+	defer it.Close()
+
+	var prev []byte
+	for it.Rewind(); it.Valid(); it.Next() {
+		item := it.Item()
+		// Versions of a key are sorted newest first, only the newest is needed.
+		if prev != nil && bytes.Equal(item.Key(), prev) {
+			continue
+		}
+		prev = item.KeyCopy(prev)
+
+		if item.Version() <= since {
+			continue
+		}
+		if item.Version() > version {
+			version = item.Version()
+		}
+
+		if item.IsDeletedOrExpired() {
+			if since == 0 {
+				continue
+			}
+			err := bw.Delete(item.Key())
+			if err != nil {
+				return 0, err
+			}
+			continue
+		}
+
+		val, err := item.Value()
+		if err != nil {
+			return 0, err
+		}
+		err = bw.Set(item.Key(), val)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	err := bw.Flush()
+	if err != nil {
+		return 0, err
+	}
+
+	return version, nil
+}
+
+func (db *badgerDB) RunGC() error {
+	err := db.db.RunValueLogGC(0.7)
+	if err == badger.ErrNoRewrite {
+		return nil
+	}
+	return err
+}
+
+func (db *badgerDB) Size() (lsm, vlog int64) {
+	return db.db.Size()
+}
+
+func (db *badgerDB) Close() error {
+	return db.db.Close()
+}
+
+type badgerTxn struct {
+	txn *badger.Txn
+}
+
+func (txn *badgerTxn) Get(key []byte) (Item, error) {
+	item, err := txn.txn.Get(key)
+	if err != nil {
+		return nil, badgerErr(err)
+	}
+	return item, nil
+}
+
+func (txn *badgerTxn) Set(key, val []byte) error {
+	return badgerErr(txn.txn.Set(key, val))
+}
+
+func (txn *badgerTxn) Delete(key []byte) error {
+	return badgerErr(txn.txn.Delete(key))
+}
+
+func (txn *badgerTxn) NewIterator(opts IteratorOptions) Iterator {
+	return &badgerIterator{txn.txn.NewIterator(badger.IteratorOptions{
+		Reverse:        opts.Reverse,
+		PrefetchValues: opts.PrefetchValues,
+		PrefetchSize:   opts.PrefetchSize,
+	})}
+}
+
+func (txn *badgerTxn) Commit() error {
+	return badgerErr(txn.txn.Commit(nil))
+}
+
+func (txn *badgerTxn) Discard() {
+	txn.txn.Discard()
+}
+
+type badgerIterator struct {
+	*badger.Iterator
+}
+
+func (it *badgerIterator) Item() Item {
+	return it.Iterator.Item()
+}
+
+// badgerErr converts errors returned by badger to the equivelant errors of this package.
+func badgerErr(err error) error {
+	switch err {
+	case badger.ErrKeyNotFound:
+		return ErrKeyNotFound
+	case badger.ErrConflict:
+		return ErrConflict
+	case badger.ErrTxnTooBig:
+		return ErrTxnTooBig
+	default:
+		return err
+	}
+}
@@ -0,0 +1,443 @@
+package storage
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"sync"
+)
+
+var (
+	errDiscardedTxn = errors.New("transaction has been discarded")
+	errReadOnlyTxn  = errors.New("no writes allowed in a read-only transaction")
+)
+
+// NewMemory creates a DB that keeps all data in memory. All data is lost when the DB is closed.
+//
+// Deleted keys are kept in memory until no open transaction can read them. Once a backup has been
+// taken, deleted keys are also kept until the next backup, so incremental backups include every
+// deletion as long as each backup is taken since the version returned by the previous one.
+//
+// The memory DB has the same isolation and conflict semantics as the badger DB: each transaction
+// reads from a snapshot, and committing a transaction fails with ErrConflict if any key it read was
+// committed by another transaction after the snapshot was taken.
+func NewMemory() DB {
+	return &memDB{
+		versions: make(map[string][]memVersion),
+		active:   make(map[uint64]int),
+		stale:    make(map[string]struct{}),
+	}
+}
+
+type memDB struct {
+	mu sync.RWMutex
+	// keys holds every key with at least one version. The tree is persistent, so iterators keep the
+	// root as a snapshot of the keys instead of copying them.
+	keys     *memNode
+	versions map[string][]memVersion
+	// ts is the timestamp of the latest commit.
+	ts uint64
+	// active counts the open transactions for each read timestamp.
+	active map[uint64]int
+	// stale is every key with versions or a deletion that can be pruned once the transactions that
+	// can read them are done.
+	stale map[string]struct{}
+	// oldest is the read timestamp of the oldest open transaction as of the last time stale keys were
+	// pruned.
+	oldest uint64
+	// backupTs is the version returned by the latest backup, or zero if there hasn't been one.
+	// Deletions after backupTs are kept for the next incremental backup.
+	backupTs uint64
+}
+
+type memVersion struct {
+	ts      uint64
+	val     []byte
+	deleted bool
+}
+
+type memWrite struct {
+	val     []byte
+	deleted bool
+}
+
+func (db *memDB) NewTransaction(update bool) Txn {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	db.active[db.ts]++
+
+	txn := &memTxn{
+		db:     db,
+		update: update,
+		readTs: db.ts,
+	}
+	if update {
+		txn.pending = make(map[string]memWrite)
+		txn.reads = make(map[string]struct{})
+	}
+	return txn
+}
+
+func (db *memDB) Backup(w io.Writer, since uint64) (uint64, error) {
+	txn := db.NewTransaction(false).(*memTxn)
+	defer txn.Discard()
+
+	db.mu.RLock()
+	keys := db.keys
+	db.mu.RUnlock()
+
+	bw := newBackupWriter(w)
+
+	var c memCursor
+	for c.first(keys); c.valid(); c.next() {
+		key := c.key()
+		v, ok := db.version(key, txn.readTs)
+		if !ok || v.ts <= since {
+			continue
+		}
+
+		var err error
+		if v.deleted {
+			if since == 0 {
+				continue
+			}
+			err = bw.Delete([]byte(key))
+		} else {
+			err = bw.Set([]byte(key), v.val)
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	err := bw.Flush()
+	if err != nil {
+		return 0, err
+	}
+
+	version := txn.readTs
+	if version < since {
+		version = since
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if version > db.backupTs {
+		db.backupTs = version
+		db.pruneStale()
+	}
+
+	return version, nil
+}
+
+func (db *memDB) RunGC() error {
+	return nil
+}
+
+func (db *memDB) Size() (lsm, vlog int64) {
+	return 0, 0
+}
+
+func (db *memDB) Close() error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	db.keys = nil
+	db.versions = make(map[string][]memVersion)
+	db.stale = make(map[string]struct{})
+
+	return nil
+}
+
+// get returns the value of key as of the timestamp ts.
+func (db *memDB) get(key string, ts uint64) ([]byte, bool) {
+	v, ok := db.version(key, ts)
+	if !ok || v.deleted {
+		return nil, false
+	}
+	return v.val, true
+}
+
+// version returns the latest version of key as of the timestamp ts.
+func (db *memDB) version(key string, ts uint64) (memVersion, bool) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	versions := db.versions[key]
+	for i := len(versions) - 1; i >= 0; i-- {
+		if versions[i].ts <= ts {
+			return versions[i], true
+		}
+	}
+	return memVersion{}, false
+}
+
+// release marks a transaction with read timestamp ts as done. db.mu must be held.
+func (db *memDB) release(ts uint64) {
+	db.active[ts]--
+	if db.active[ts] == 0 {
+		delete(db.active, ts)
+	}
+
+	// Stale versions can only become unreadable once the oldest transaction is done.
+	if len(db.stale) > 0 && db.oldestReadTs() > db.oldest {
+		db.pruneStale()
+	}
+}
+
+// oldestReadTs returns the read timestamp of the oldest open transaction, or of the next
+// transaction if there are none. db.mu must be held.
+func (db *memDB) oldestReadTs() uint64 {
+	oldest := db.ts
+	for ts := range db.active {
+		if ts < oldest {
+			oldest = ts
+		}
+	}
+	return oldest
+}
+
+// pruneStale prunes every stale key. db.mu must be held.
+func (db *memDB) pruneStale() {
+	db.oldest = db.oldestReadTs()
+	for key := range db.stale {
+		db.prune(key, db.oldest)
+	}
+}
+
+// prune removes versions of key that can't be read by any transaction with a read timestamp of at
+// least oldest, and removes key entirely if all that is left is a deletion that isn't needed for
+// incremental backups. db.mu must be held.
+func (db *memDB) prune(key string, oldest uint64) {
+	versions := db.versions[key]
+	// Find the latest version visible to the oldest transaction, everything before it can go.
+	start := 0
+	for i := len(versions) - 1; i >= 0; i-- {
+		if versions[i].ts <= oldest {
+			start = i
+			break
+		}
+	}
+	versions = versions[start:]
+
+	backedUp := db.backupTs == 0 || versions[0].ts <= db.backupTs
+	if len(versions) == 1 && versions[0].deleted && versions[0].ts <= oldest && backedUp {
+		// Every transaction sees the key as deleted, which is the same as it not existing.
+		delete(db.versions, key)
+		delete(db.stale, key)
+		db.keys = db.keys.remove(key)
+		return
+	}
+
+	db.versions[key] = versions
+	if len(versions) > 1 || versions[0].deleted {
+		db.stale[key] = struct{}{}
+	} else {
+		delete(db.stale, key)
+	}
+}
+
+type memTxn struct {
+	db        *memDB
+	update    bool
+	readTs    uint64
+	pending   map[string]memWrite
+	reads     map[string]struct{}
+	discarded bool
+}
+
+// lookup returns the value of key as seen by txn, including pending writes.
+func (txn *memTxn) lookup(key string) ([]byte, bool) {
+	if txn.update {
+		txn.reads[key] = struct{}{}
+		if w, ok := txn.pending[key]; ok {
+			return w.val, !w.deleted
+		}
+	}
+	return txn.db.get(key, txn.readTs)
+}
+
+func (txn *memTxn) Get(key []byte) (Item, error) {
+	if txn.discarded {
+		return nil, errDiscardedTxn
+	}
+	val, ok := txn.lookup(string(key))
+	if !ok {
+		return nil, ErrKeyNotFound
+	}
+	return &memItem{key: key, val: val}, nil
+}
+
+func (txn *memTxn) Set(key, val []byte) error {
+	return txn.write(key, memWrite{val: append([]byte(nil), val...)})
+}
+
+func (txn *memTxn) Delete(key []byte) error {
+	return txn.write(key, memWrite{deleted: true})
+}
+
+func (txn *memTxn) write(key []byte, w memWrite) error {
+	if txn.discarded {
+		return errDiscardedTxn
+	}
+	if !txn.update {
+		return errReadOnlyTxn
+	}
+	if len(key) == 0 {
+		return errors.New("key cannot be empty")
+	}
+	txn.pending[string(key)] = w
+	return nil
+}
+
+func (txn *memTxn) NewIterator(opts IteratorOptions) Iterator {
+	txn.db.mu.RLock()
+	keys := txn.db.keys
+	txn.db.mu.RUnlock()
+
+	// Inserting into the tree leaves the db's keys as they are.
+	for key := range txn.pending {
+		keys = keys.insert(key)
+	}
+
+	return &memIterator{
+		txn:    txn,
+		keys:   keys,
+		cursor: memCursor{reverse: opts.Reverse},
+	}
+}
+
+func (txn *memTxn) Commit() error {
+	if txn.discarded {
+		return errDiscardedTxn
+	}
+	if !txn.update || len(txn.pending) == 0 {
+		txn.Discard()
+		return nil
+	}
+
+	db := txn.db
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	for key := range txn.reads {
+		versions := db.versions[key]
+		if len(versions) > 0 && versions[len(versions)-1].ts > txn.readTs {
+			txn.discard()
+			return ErrConflict
+		}
+	}
+
+	db.ts++
+	for key, w := range txn.pending {
+		versions, ok := db.versions[key]
+		if !ok {
+			db.keys = db.keys.insert(key)
+		}
+		db.versions[key] = append(versions, memVersion{
+			ts:      db.ts,
+			val:     w.val,
+			deleted: w.deleted,
+		})
+	}
+
+	txn.discard()
+
+	oldest := db.oldestReadTs()
+	for key := range txn.pending {
+		db.prune(key, oldest)
+	}
+
+	return nil
+}
+
+func (txn *memTxn) Discard() {
+	if txn.discarded {
+		return
+	}
+	txn.db.mu.Lock()
+	defer txn.db.mu.Unlock()
+	txn.discard()
+}
+
+// discard marks txn as discarded. txn.db.mu must be held.
+func (txn *memTxn) discard() {
+	if txn.discarded {
+		return
+	}
+	txn.discarded = true
+	txn.db.release(txn.readTs)
+}
+
+type memIterator struct {
+	txn    *memTxn
+	keys   *memNode
+	cursor memCursor
+	item   *memItem
+}
+
+func (it *memIterator) Seek(key []byte) {
+	it.cursor.seek(it.keys, string(key))
+	it.settle()
+}
+
+func (it *memIterator) Rewind() {
+	it.cursor.first(it.keys)
+	it.settle()
+}
+
+func (it *memIterator) Next() {
+	it.cursor.next()
+	it.settle()
+}
+
+// settle moves the iterator to the next key visible to the transaction, starting at the current
+// key.
+func (it *memIterator) settle() {
+	it.item = nil
+	for ; it.cursor.valid(); it.cursor.next() {
+		key := it.cursor.key()
+		val, ok := it.txn.lookup(key)
+		if ok {
+			it.item = &memItem{key: []byte(key), val: val}
+			return
+		}
+	}
+}
+
+func (it *memIterator) Valid() bool {
+	return it.item != nil
+}
+
+func (it *memIterator) ValidForPrefix(prefix []byte) bool {
+	return it.item != nil && bytes.HasPrefix(it.item.key, prefix)
+}
+
+func (it *memIterator) Item() Item {
+	return it.item
+}
+
+func (it *memIterator) Close() {}
+
+type memItem struct {
+	key []byte
+	val []byte
+}
+
+func (item *memItem) Key() []byte {
+	return item.key
+}
+
+func (item *memItem) KeyCopy(dst []byte) []byte {
+	return append(dst[:0], item.key...)
+}
+
+func (item *memItem) Value() ([]byte, error) {
+	return item.val, nil
+}
+
+func (item *memItem) ValueCopy(dst []byte) ([]byte, error) {
+	return append(dst[:0], item.val...), nil
+}
@@ -0,0 +1,182 @@
+package storage
+
+import (
+	"hash/fnv"
+)
+
+// memNode is a node of a persistent treap of keys. Nodes are never modified once they are part of a
+// tree, so a tree can be read without locking while new versions of it are made, and taking a
+// snapshot of the keys of a memDB is just a matter of keeping the root.
+//
+// The priority of a node is a hash of its key, which keeps the tree balanced in expectation without
+// any random state.
+type memNode struct {
+	key         string
+	priority    uint64
+	left, right *memNode
+}
+
+func newMemNode(key string) *memNode {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	return &memNode{
+		key:      key,
+		priority: h.Sum64(),
+	}
+}
+
+// has returns whether key is in the tree rooted at n.
+func (n *memNode) has(key string) bool {
+	for n != nil {
+		switch {
+		case key < n.key:
+			n = n.left
+		case key > n.key:
+			n = n.right
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// insert returns a tree with the keys of n and key. n is not modified.
+func (n *memNode) insert(key string) *memNode {
+	if n.has(key) {
+		return n
+	}
+	left, right := n.split(key)
+	return mergeMemNodes(mergeMemNodes(left, newMemNode(key)), right)
+}
+
+// remove returns a tree with the keys of n except key. n is not modified.
+func (n *memNode) remove(key string) *memNode {
+	if !n.has(key) {
+		return n
+	}
+	return n.removeFound(key)
+}
+
+// removeFound removes key from n, which must contain it.
+func (n *memNode) removeFound(key string) *memNode {
+	switch {
+	case key < n.key:
+		c := *n
+		c.left = n.left.removeFound(key)
+		return &c
+	case key > n.key:
+		c := *n
+		c.right = n.right.removeFound(key)
+		return &c
+	default:
+		return mergeMemNodes(n.left, n.right)
+	}
+}
+
+// split returns a tree with the keys of n less than key, and a tree with the rest of its keys. n is
+// not modified.
+func (n *memNode) split(key string) (*memNode, *memNode) {
+	if n == nil {
+		return nil, nil
+	}
+	c := *n
+	if n.key < key {
+		c.right, n = n.right.split(key)
+		return &c, n
+	}
+	n, c.left = n.left.split(key)
+	return n, &c
+}
+
+// mergeMemNodes returns a tree with the keys of a and b. Every key of a must be less than every key
+// of b. Neither a nor b is modified.
+func mergeMemNodes(a, b *memNode) *memNode {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if a.priority > b.priority {
+		c := *a
+		c.right = mergeMemNodes(a.right, b)
+		return &c
+	}
+	c := *b
+	c.left = mergeMemNodes(a, b.left)
+	return &c
+}
+
+// memCursor iterates over the keys of a tree in order, or in reverse order.
+type memCursor struct {
+	reverse bool
+	// stack holds the nodes that are still to be visited above the current node, with the current
+	// node on top.
+	stack []*memNode
+}
+
+// seek moves c to the first key at or after key, or the last key at or before key if c is reversed.
+func (c *memCursor) seek(root *memNode, key string) {
+	c.stack = c.stack[:0]
+	for n := root; n != nil; {
+		switch {
+		case n.key == key:
+			c.stack = append(c.stack, n)
+			return
+		case (n.key > key) != c.reverse:
+			c.stack = append(c.stack, n)
+			n = c.toward(n)
+		default:
+			n = c.away(n)
+		}
+	}
+}
+
+// first moves c to the first key of root, or the last key if c is reversed.
+func (c *memCursor) first(root *memNode) {
+	c.stack = c.stack[:0]
+	c.descend(root)
+}
+
+// next moves c to the next key. It does nothing if c isn't at a key.
+func (c *memCursor) next() {
+	if len(c.stack) == 0 {
+		return
+	}
+	n := c.stack[len(c.stack)-1]
+	c.stack = c.stack[:len(c.stack)-1]
+	c.descend(c.away(n))
+}
+
+// descend pushes n and its descendants toward the start of the iteration.
+func (c *memCursor) descend(n *memNode) {
+	for ; n != nil; n = c.toward(n) {
+		c.stack = append(c.stack, n)
+	}
+}
+
+// toward returns the child of n toward the start of the iteration.
+func (c *memCursor) toward(n *memNode) *memNode {
+	if c.reverse {
+		return n.right
+	}
+	return n.left
+}
+
+// away returns the child of n toward the end of the iteration.
+func (c *memCursor) away(n *memNode) *memNode {
+	if c.reverse {
+		return n.left
+	}
+	return n.right
+}
+
+// valid returns whether c is at a key.
+func (c *memCursor) valid() bool {
+	return len(c.stack) > 0
+}
+
+// key returns the current key of c.
+func (c *memCursor) key() string {
+	return c.stack[len(c.stack)-1].key
+}
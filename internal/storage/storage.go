@@ -0,0 +1,99 @@
+// Package storage provides the transactional key-value store interface that DEQ's data is written
+// to, along with implementations backed by badger and by memory.
+package storage
+
+import (
+	"errors"
+	"io"
+)
+
+var (
+	// ErrKeyNotFound is returned by Txn.Get when the requested key doesn't exist.
+	ErrKeyNotFound = errors.New("key not found")
+	// ErrConflict is returned by Txn.Commit when a key read by the transaction was modified by
+	// another transaction after it was read. The transaction can be retried.
+	ErrConflict = errors.New("transaction conflict")
+	// ErrTxnTooBig is returned when a transaction has too many writes to commit at once.
+	ErrTxnTooBig = errors.New("transaction too big")
+)
+
+// DB is a transactional key-value store. Keys are sorted lexicographically.
+//
+// All methods of DB are safe for concurrent use.
+type DB interface {
+	// NewTransaction creates a new transaction reading from a snapshot of the DB at the time it is
+	// created. If update is true, the transaction may also write to the DB.
+	NewTransaction(update bool) Txn
+	// Backup writes every key that was modified after the version since to w, along with its value,
+	// from a consistent snapshot of the DB. If since is zero, deleted keys are omitted and the backup
+	// contains the entire DB. Backup returns the version of the snapshot, which can be passed to a
+	// later call to Backup to create an incremental backup. The backup can be loaded with Load.
+	Backup(w io.Writer, since uint64) (uint64, error)
+	// RunGC cleans up space from deleted or overwritten values, if supported by the DB.
+	RunGC() error
+	// Size returns the on-disk size in bytes of the DB's LSM tree and value log, or zeros if the DB
+	// isn't stored on disk.
+	Size() (lsm, vlog int64)
+	// Close closes the DB. Close should be called once all transactions are discarded.
+	Close() error
+}
+
+// Txn is a transaction on a DB. Txn is not safe for concurrent use.
+type Txn interface {
+	// Get returns the item for key, or ErrKeyNotFound if it doesn't exist.
+	Get(key []byte) (Item, error)
+	// Set sets the value of key. Neither key or val may be modified until the Txn is committed or
+	// discarded.
+	Set(key, val []byte) error
+	// Delete deletes key. Delete doesn't return an error if key doesn't exist.
+	Delete(key []byte) error
+	// NewIterator creates an iterator over the keys of the transaction, including its own pending
+	// writes. Only one iterator may be open at a time for each Txn.
+	NewIterator(opts IteratorOptions) Iterator
+	// Commit commits the writes of the transaction. If a key read by the transaction was written by
+	// another transaction since this one was created, Commit returns ErrConflict.
+	Commit() error
+	// Discard discards the transaction. Discard may be called after Commit, and should always be
+	// called once the Txn is no longer in use.
+	Discard()
+}
+
+// Item is a key-value pair. The slices returned by Key and Value are only valid until the iterator
+// is advanced or the Txn is discarded.
+type Item interface {
+	Key() []byte
+	KeyCopy(dst []byte) []byte
+	Value() ([]byte, error)
+	ValueCopy(dst []byte) ([]byte, error)
+}
+
+// Iterator iterates over the keys of a Txn in lexicographical order, or reverse lexicographical
+// order if IteratorOptions.Reverse is true.
+type Iterator interface {
+	// Seek moves to the first key greater than or equal to key, or the last key less than or equal
+	// to key when iterating in reverse.
+	Seek(key []byte)
+	// Rewind moves to the first key, or the last key when iterating in reverse.
+	Rewind()
+	Valid() bool
+	ValidForPrefix(prefix []byte) bool
+	Item() Item
+	Next()
+	Close()
+}
+
+// IteratorOptions are options for creating an Iterator.
+type IteratorOptions struct {
+	// Reverse iterates keys in reverse lexicographical order.
+	Reverse bool
+	// PrefetchValues hints that values will be read during iteration.
+	PrefetchValues bool
+	// PrefetchSize is the number of values to prefetch if PrefetchValues is true.
+	PrefetchSize int
+}
+
+// DefaultIteratorOptions are the default options for an Iterator.
+var DefaultIteratorOptions = IteratorOptions{
+	PrefetchValues: true,
+	PrefetchSize:   100,
+}
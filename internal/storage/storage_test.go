@@ -0,0 +1,362 @@
+package storage
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/dgraph-io/badger"
+	"github.com/google/go-cmp/cmp"
+)
+
+// testDBs runs f against every DB implementation.
+func testDBs(t *testing.T, f func(t *testing.T, db DB)) {
+	t.Run("memory", func(t *testing.T) {
+		t.Parallel()
+
+		db := NewMemory()
+		defer db.Close()
+
+		f(t, db)
+	})
+	t.Run("badger", func(t *testing.T) {
+		t.Parallel()
+
+		dir, err := ioutil.TempDir("", "test-storage")
+		if err != nil {
+			t.Fatalf("create temp dir: %v", err)
+		}
+		defer os.RemoveAll(dir)
+
+		opts := badger.DefaultOptions
+		opts.Dir = dir
+		opts.ValueDir = dir
+		db, err := OpenBadger(opts)
+		if err != nil {
+			t.Fatalf("open db: %v", err)
+		}
+		defer db.Close()
+
+		f(t, db)
+	})
+}
+
+func set(t *testing.T, db DB, kvs ...string) {
+	txn := db.NewTransaction(true)
+	defer txn.Discard()
+
+	for i := 0; i < len(kvs); i += 2 {
+		err := txn.Set([]byte(kvs[i]), []byte(kvs[i+1]))
+		if err != nil {
+			t.Fatalf("set %s: %v", kvs[i], err)
+		}
+	}
+	err := txn.Commit()
+	if err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+}
+
+// keys returns the keys and values with prefix, seeking to seek.
+func keys(t *testing.T, txn Txn, seek, prefix string, reverse bool) []string {
+	it := txn.NewIterator(IteratorOptions{Reverse: reverse})
+	defer it.Close()
+
+	var actual []string
+	for it.Seek([]byte(seek)); it.ValidForPrefix([]byte(prefix)); it.Next() {
+		item := it.Item()
+		val, err := item.ValueCopy(nil)
+		if err != nil {
+			t.Fatalf("get value of %s: %v", item.Key(), err)
+		}
+		actual = append(actual, string(item.KeyCopy(nil))+"="+string(val))
+	}
+	return actual
+}
+
+func TestGetSetDelete(t *testing.T) {
+	t.Parallel()
+
+	testDBs(t, func(t *testing.T, db DB) {
+		set(t, db, "a", "1", "b", "2")
+
+		txn := db.NewTransaction(true)
+		defer txn.Discard()
+
+		err := txn.Delete([]byte("a"))
+		if err != nil {
+			t.Fatalf("delete: %v", err)
+		}
+		_, err = txn.Get([]byte("a"))
+		if err != ErrKeyNotFound {
+			t.Errorf("get deleted key: expected ErrKeyNotFound, got %v", err)
+		}
+		err = txn.Commit()
+		if err != nil {
+			t.Fatalf("commit: %v", err)
+		}
+
+		txn = db.NewTransaction(false)
+		defer txn.Discard()
+
+		_, err = txn.Get([]byte("a"))
+		if err != ErrKeyNotFound {
+			t.Errorf("get deleted key after commit: expected ErrKeyNotFound, got %v", err)
+		}
+		item, err := txn.Get([]byte("b"))
+		if err != nil {
+			t.Fatalf("get: %v", err)
+		}
+		val, err := item.Value()
+		if err != nil {
+			t.Fatalf("get value: %v", err)
+		}
+		if string(val) != "2" {
+			t.Errorf("expected value 2, got %s", val)
+		}
+	})
+}
+
+func TestIterator(t *testing.T) {
+	t.Parallel()
+
+	testDBs(t, func(t *testing.T, db DB) {
+		set(t, db, "a1", "1", "b1", "2", "b2", "3", "b4", "4", "c1", "5")
+
+		txn := db.NewTransaction(true)
+		defer txn.Discard()
+
+		// Pending writes should be visible to the iterator.
+		err := txn.Set([]byte("b3"), []byte("6"))
+		if err != nil {
+			t.Fatalf("set: %v", err)
+		}
+		err = txn.Delete([]byte("b2"))
+		if err != nil {
+			t.Fatalf("delete: %v", err)
+		}
+
+		expected := []string{"b1=2", "b3=6", "b4=4"}
+		if diff := cmp.Diff(expected, keys(t, txn, "b", "b", false)); diff != "" {
+			t.Errorf("forward:\n%s", diff)
+		}
+
+		expected = []string{"b3=6", "b1=2"}
+		if diff := cmp.Diff(expected, keys(t, txn, "b3", "b", true)); diff != "" {
+			t.Errorf("reverse:\n%s", diff)
+		}
+
+		expected = []string{"b4=4", "b3=6", "b1=2"}
+		if diff := cmp.Diff(expected, keys(t, txn, "b\xff", "b", true)); diff != "" {
+			t.Errorf("reverse from end of prefix:\n%s", diff)
+		}
+	})
+}
+
+func TestSnapshot(t *testing.T) {
+	t.Parallel()
+
+	testDBs(t, func(t *testing.T, db DB) {
+		set(t, db, "a", "1")
+
+		txn := db.NewTransaction(false)
+		defer txn.Discard()
+
+		set(t, db, "a", "2", "b", "3")
+
+		expected := []string{"a=1"}
+		if diff := cmp.Diff(expected, keys(t, txn, "", "", false)); diff != "" {
+			t.Errorf("\n%s", diff)
+		}
+	})
+}
+
+func TestConflict(t *testing.T) {
+	t.Parallel()
+
+	testDBs(t, func(t *testing.T, db DB) {
+		set(t, db, "a", "1")
+
+		txn := db.NewTransaction(true)
+		defer txn.Discard()
+
+		_, err := txn.Get([]byte("a"))
+		if err != nil {
+			t.Fatalf("get: %v", err)
+		}
+		err = txn.Set([]byte("b"), []byte("2"))
+		if err != nil {
+			t.Fatalf("set: %v", err)
+		}
+
+		set(t, db, "a", "3")
+
+		err = txn.Commit()
+		if err != ErrConflict {
+			t.Fatalf("expected ErrConflict, got %v", err)
+		}
+
+		// A transaction that didn't read the modified key shouldn't conflict.
+		txn = db.NewTransaction(true)
+		defer txn.Discard()
+
+		err = txn.Set([]byte("b"), []byte("2"))
+		if err != nil {
+			t.Fatalf("set: %v", err)
+		}
+
+		set(t, db, "a", "4")
+
+		err = txn.Commit()
+		if err != nil {
+			t.Fatalf("commit: %v", err)
+		}
+	})
+}
+
+func TestBackup(t *testing.T) {
+	t.Parallel()
+
+	testDBs(t, func(t *testing.T, db DB) {
+		set(t, db, "a", "1", "b", "2")
+
+		var full bytes.Buffer
+		version, err := db.Backup(&full, 0)
+		if err != nil {
+			t.Fatalf("backup: %v", err)
+		}
+
+		txn := db.NewTransaction(true)
+		defer txn.Discard()
+		err = txn.Delete([]byte("a"))
+		if err != nil {
+			t.Fatalf("delete: %v", err)
+		}
+		err = txn.Commit()
+		if err != nil {
+			t.Fatalf("commit: %v", err)
+		}
+		set(t, db, "c", "3")
+
+		var incremental bytes.Buffer
+		_, err = db.Backup(&incremental, version)
+		if err != nil {
+			t.Fatalf("incremental backup: %v", err)
+		}
+
+		restored := NewMemory()
+		defer restored.Close()
+
+		err = Load(restored, &full)
+		if err != nil {
+			t.Fatalf("load: %v", err)
+		}
+		err = Load(restored, &incremental)
+		if err != nil {
+			t.Fatalf("load incremental: %v", err)
+		}
+
+		txn = restored.NewTransaction(false)
+		defer txn.Discard()
+
+		expected := []string{"b=2", "c=3"}
+		if diff := cmp.Diff(expected, keys(t, txn, "", "", false)); diff != "" {
+			t.Errorf("\n%s", diff)
+		}
+	})
+}
+
+func TestMemoryPrune(t *testing.T) {
+	t.Parallel()
+
+	db := NewMemory().(*memDB)
+	defer db.Close()
+
+	set(t, db, "a", "1", "b", "2")
+
+	reader := db.NewTransaction(false)
+
+	txn := db.NewTransaction(true)
+	defer txn.Discard()
+	err := txn.Delete([]byte("a"))
+	if err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	err = txn.Commit()
+	if err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+	set(t, db, "b", "3")
+
+	// The open transaction can still read the old versions.
+	expected := []string{"a=1", "b=2"}
+	if diff := cmp.Diff(expected, keys(t, reader, "", "", false)); diff != "" {
+		t.Errorf("before discard:\n%s", diff)
+	}
+
+	reader.Discard()
+
+	if db.keys.has("a") {
+		t.Errorf("expected deleted key a to be pruned")
+	}
+	if len(db.versions["b"]) != 1 {
+		t.Errorf("expected one version of b, got %d", len(db.versions["b"]))
+	}
+	if len(db.stale) != 0 {
+		t.Errorf("expected no stale keys, got %d", len(db.stale))
+	}
+}
+
+func TestMemTree(t *testing.T) {
+	t.Parallel()
+
+	var root *memNode
+	var expected []string
+	for i := 0; i < 1000; i++ {
+		key := fmt.Sprintf("%03d", (i*389)%1000)
+		root = root.insert(key)
+	}
+	snapshot := root
+	for i := 0; i < 1000; i++ {
+		key := fmt.Sprintf("%03d", i)
+		if i%2 == 1 {
+			root = root.remove(key)
+			continue
+		}
+		expected = append(expected, key)
+	}
+
+	var actual []string
+	var c memCursor
+	for c.first(root); c.valid(); c.next() {
+		actual = append(actual, c.key())
+	}
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Errorf("keys:\n%s", diff)
+	}
+
+	// Removing keys doesn't change earlier versions of the tree.
+	count := 0
+	for c.first(snapshot); c.valid(); c.next() {
+		count++
+	}
+	if count != 1000 {
+		t.Errorf("expected 1000 keys in snapshot, got %d", count)
+	}
+
+	c.seek(root, "101")
+	if !c.valid() || c.key() != "102" {
+		t.Errorf("expected seek to 101 to find 102")
+	}
+	c = memCursor{reverse: true}
+	c.seek(root, "101")
+	if !c.valid() || c.key() != "100" {
+		t.Errorf("expected reverse seek to 101 to find 100")
+	}
+	c.next()
+	if !c.valid() || c.key() != "098" {
+		t.Errorf("expected reverse iteration to find 098 after 100")
+	}
+}
@@ -5,9 +5,9 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/dgraph-io/badger"
 	"github.com/gogo/protobuf/proto"
 	"gitlab.com/katcheCode/deq/internal/data"
+	"gitlab.com/katcheCode/deq/internal/storage"
 )
 
 // IterOpts is the available options for
@@ -54,13 +54,14 @@ Example usage:
 	}
 */
 type EventIter struct {
-	txn     *badger.Txn
-	it      *badger.Iterator
+	txn     storage.Txn
+	it      storage.Iterator
 	opts    IterOpts
 	current Event
 	err     error
 	end     []byte
 	channel string
+	keys    KeyProvider
 }
 
 // NewEventIter creates a new EventIter that iterates events on the topic and channel of c.
@@ -83,7 +84,7 @@ func (c *Channel) NewEventIter(opts IterOpts) *EventIter {
 	}
 
 	txn := c.db.NewTransaction(false)
-	it := txn.NewIterator(badger.IteratorOptions{
+	it := txn.NewIterator(storage.IteratorOptions{
 		Reverse:        opts.Reversed,
 		PrefetchValues: opts.PrefetchCount > 0,
 		// TODO: prefetch other event data too
@@ -98,6 +99,7 @@ func (c *Channel) NewEventIter(opts IterOpts) *EventIter {
 		it:      it,
 		end:     end,
 		channel: c.name,
+		keys:    c.store.keys,
 	}
 }
 
@@ -144,7 +146,7 @@ func (iter *EventIter) Next() bool {
 
 	createTime := time.Unix(0, eTime.CreateTime)
 
-	e, err := getEventPayload(iter.txn, data.EventKey{
+	e, err := getEventPayload(iter.txn, iter.keys, data.EventKey{
 		Topic:      key.Topic,
 		CreateTime: createTime,
 		ID:         key.ID,
@@ -172,6 +174,10 @@ func (iter *EventIter) Next() bool {
 		State:        protoToEventState(channel.EventState),
 		DefaultState: protoToEventState(e.DefaultEventState),
 		Indexes:      e.Indexes,
+		Metadata:     e.Metadata,
+		RequeueLimit: int(e.RequeueLimit),
+		DeliverAt:    data.FromUnixNano(e.DeliverAt),
+		Priority:     int(e.Priority),
 	}
 
 	return true
@@ -232,8 +238,8 @@ Example usage:
 
 */
 type TopicIter struct {
-	txn     *badger.Txn
-	it      *badger.Iterator
+	txn     storage.Txn
+	it      storage.Iterator
 	cursor  []byte
 	current string
 	opts    IterOpts
@@ -250,7 +256,7 @@ func (s *Store) NewTopicIter(opts IterOpts) *TopicIter {
 	return newTopicIter(txn, opts)
 }
 
-func newTopicIter(txn *badger.Txn, opts IterOpts) *TopicIter {
+func newTopicIter(txn storage.Txn, opts IterOpts) *TopicIter {
 	// Apply default options
 	max := opts.Max
 	if max == "" {
@@ -274,7 +280,7 @@ func newTopicIter(txn *badger.Txn, opts IterOpts) *TopicIter {
 	}
 
 	// Badger setup
-	it := txn.NewIterator(badger.IteratorOptions{
+	it := txn.NewIterator(storage.IteratorOptions{
 		Reverse:        opts.Reversed,
 		PrefetchValues: false,
 	})
@@ -375,13 +381,14 @@ Example usage:
 	}
 */
 type IndexIter struct {
-	txn     *badger.Txn
-	it      *badger.Iterator
+	txn     storage.Txn
+	it      storage.Iterator
 	opts    IterOpts
 	current Event
 	err     error
 	end     []byte
 	channel string
+	keys    KeyProvider
 }
 
 // NewIndexIter creates a new IndexIter that iterates events on the topic and channel of c.
@@ -404,7 +411,7 @@ func (c *Channel) NewIndexIter(opts IterOpts) *IndexIter {
 	}
 
 	txn := c.db.NewTransaction(false)
-	it := txn.NewIterator(badger.IteratorOptions{
+	it := txn.NewIterator(storage.IteratorOptions{
 		Reverse:        opts.Reversed,
 		PrefetchValues: opts.PrefetchCount > 0,
 		// TODO: prefetch other event data too
@@ -419,6 +426,7 @@ func (c *Channel) NewIndexIter(opts IterOpts) *IndexIter {
 		it:      it,
 		end:     end,
 		channel: c.name,
+		keys:    c.store.keys,
 	}
 }
 
@@ -464,7 +472,7 @@ func (iter *IndexIter) Next() bool {
 		return false
 	}
 
-	e, err := getEvent(iter.txn, key.Topic, payload.EventId, iter.channel)
+	e, err := getEvent(iter.txn, iter.keys, key.Topic, payload.EventId, iter.channel)
 	if err != nil {
 		iter.err = err
 		return false
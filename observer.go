@@ -0,0 +1,43 @@
+package deq
+
+// Observer is notified of changes to the events of a store. An Observer can be registered with
+// Options.Observer to build audit trails, record metrics or invalidate caches.
+//
+// Each method is called after its change is committed, from the goroutine that made the change, so
+// methods should return quickly. Methods may be called concurrently.
+type Observer interface {
+	// EventPublished is called when e is published. e.State is e's DefaultState.
+	EventPublished(e Event)
+	// EventDeleted is called when e is deleted, either with Store.Del, Store.DelTopic or by a
+	// RetentionPolicy. If e's payload can't be decrypted, e.Payload is nil.
+	EventDeleted(e Event)
+	// EventStateChanged is called when the state of e on channel changes from old to new, either by
+	// setting the event's state or rewinding the channel. e.State is new.
+	EventStateChanged(channel string, e Event, old, new EventState)
+	// EventRequeued is called when e is requeued on channel. e.RequeueCount is the event's new
+	// RequeueCount.
+	EventRequeued(channel string, e Event)
+	// RequeueLimitExhausted is called when e is dequeued on channel with EventStateDequeuedError
+	// instead of requeued, because it reached its requeue limit. EventStateChanged is called for the
+	// same change before RequeueLimitExhausted.
+	RequeueLimitExhausted(channel string, e Event)
+}
+
+// NopObserver is an Observer that ignores every change. It can be embedded to implement only some of
+// the methods of Observer.
+type NopObserver struct{}
+
+// EventPublished implements Observer.
+func (NopObserver) EventPublished(e Event) {}
+
+// EventDeleted implements Observer.
+func (NopObserver) EventDeleted(e Event) {}
+
+// EventStateChanged implements Observer.
+func (NopObserver) EventStateChanged(channel string, e Event, old, new EventState) {}
+
+// EventRequeued implements Observer.
+func (NopObserver) EventRequeued(channel string, e Event) {}
+
+// RequeueLimitExhausted implements Observer.
+func (NopObserver) RequeueLimitExhausted(channel string, e Event) {}
@@ -0,0 +1,153 @@
+package deq
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type recordingObserver struct {
+	mu      sync.Mutex
+	changes []string
+}
+
+func (o *recordingObserver) record(format string, args ...interface{}) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.changes = append(o.changes, fmt.Sprintf(format, args...))
+}
+
+func (o *recordingObserver) EventPublished(e Event) {
+	o.record("published %s/%s %v", e.Topic, e.ID, e.State)
+}
+
+func (o *recordingObserver) EventDeleted(e Event) {
+	o.record("deleted %s/%s %q", e.Topic, e.ID, e.Payload)
+}
+
+func (o *recordingObserver) EventStateChanged(channel string, e Event, old, new EventState) {
+	o.record("state %s %s/%s %v -> %v", channel, e.Topic, e.ID, old, new)
+}
+
+func (o *recordingObserver) EventRequeued(channel string, e Event) {
+	o.record("requeued %s %s/%s %d", channel, e.Topic, e.ID, e.RequeueCount)
+}
+
+func (o *recordingObserver) RequeueLimitExhausted(channel string, e Event) {
+	o.record("exhausted %s %s/%s %v", channel, e.Topic, e.ID, e.State)
+}
+
+func TestObserver(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	observer := &recordingObserver{}
+
+	db, err := Open(Options{
+		InMemory: true,
+		Observer: observer,
+	})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer db.Close()
+
+	_, err = db.Pub(ctx, Event{
+		ID:      "event1",
+		Topic:   "topic",
+		Payload: []byte("hello"),
+	})
+	if err != nil {
+		t.Fatalf("pub: %v", err)
+	}
+	_, err = db.Pub(ctx, Event{
+		ID:    "event2",
+		Topic: "topic",
+	})
+	if err != nil {
+		t.Fatalf("pub: %v", err)
+	}
+
+	channel := db.Channel("channel", "topic")
+	defer channel.Close()
+
+	err = channel.SetEventState("event1", EventStateDequeuedOK)
+	if err != nil {
+		t.Fatalf("set event state: %v", err)
+	}
+	// Setting the same state again isn't a change.
+	err = channel.SetEventState("event1", EventStateDequeuedOK)
+	if err != nil {
+		t.Fatalf("set event state again: %v", err)
+	}
+
+	err = channel.Rewind(time.Time{})
+	if err != nil {
+		t.Fatalf("rewind: %v", err)
+	}
+
+	err = db.Del("topic", "event1")
+	if err != nil {
+		t.Fatalf("del: %v", err)
+	}
+
+	// Requeue event2 immediately until it reaches the requeue limit.
+	channel.BackoffFunc(func(Event) time.Duration { return 0 })
+	for i := 0; i <= 40; i++ {
+		ctx, cancel := context.WithTimeout(ctx, time.Second)
+		_, err := channel.Next(ctx)
+		cancel()
+		if err != nil {
+			t.Fatalf("next %d: %v", i, err)
+		}
+	}
+
+	expected := []string{
+		"published topic/event1 Queued",
+		"published topic/event2 Queued",
+		"state channel topic/event1 Queued -> DequeuedOK",
+		"state channel topic/event1 DequeuedOK -> Queued",
+		`deleted topic/event1 "hello"`,
+	}
+	for i := 1; i <= 40; i++ {
+		expected = append(expected, fmt.Sprintf("requeued channel topic/event2 %d", i))
+	}
+	expected = append(expected,
+		"state channel topic/event2 Queued -> DequeuedError",
+		"exhausted channel topic/event2 DequeuedError",
+	)
+
+	observer.mu.Lock()
+	actual := observer.changes
+	observer.mu.Unlock()
+
+	if !cmp.Equal(expected, actual) {
+		t.Errorf("\n%s", cmp.Diff(expected, actual))
+	}
+
+	// The deleted event isn't counted as requeued.
+	stats, err := db.Stats()
+	if err != nil {
+		t.Fatalf("stats: %v", err)
+	}
+	if stats.Requeues != 40 {
+		t.Errorf("expected 40 requeues, got %d", stats.Requeues)
+	}
+	channelStats, err := channel.Stats()
+	if err != nil {
+		t.Fatalf("channel stats: %v", err)
+	}
+	expectedStats := ChannelStats{
+		Events:           1,
+		DequeuedError:    1,
+		RequeueHistogram: []int{0, 0, 0, 0, 0, 0, 1},
+	}
+	if !cmp.Equal(expectedStats, channelStats) {
+		t.Errorf("channel stats:\n%s", cmp.Diff(expectedStats, channelStats))
+	}
+}
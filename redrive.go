@@ -0,0 +1,217 @@
+package deq
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"gitlab.com/katcheCode/deq/internal/data"
+	"gitlab.com/katcheCode/deq/internal/storage"
+)
+
+// RedriveFilter selects the events redriven by Channel.Redrive. The zero value selects every event.
+type RedriveFilter struct {
+	// MinCreateTime and MaxCreateTime are inclusive bounds of the CreateTime of redriven events. A
+	// zero time leaves that end of the range unbounded.
+	MinCreateTime, MaxCreateTime time.Time
+	// MinID and MaxID are inclusive bounds of the IDs of redriven events. An empty ID leaves that
+	// end of the range unbounded.
+	MinID, MaxID string
+	// IndexPrefix selects events with at least one index that starts with IndexPrefix. If it is
+	// empty, events are selected regardless of their indexes.
+	IndexPrefix string
+}
+
+func (f RedriveFilter) matches(e *Event) bool {
+	if !f.MinCreateTime.IsZero() && e.CreateTime.Before(f.MinCreateTime) {
+		return false
+	}
+	if !f.MaxCreateTime.IsZero() && e.CreateTime.After(f.MaxCreateTime) {
+		return false
+	}
+	if f.IndexPrefix == "" {
+		return true
+	}
+	for _, index := range e.Indexes {
+		if strings.HasPrefix(index, f.IndexPrefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// redriveBatchSize is the maximum number of events requeued by Redrive in a single transaction.
+const redriveBatchSize = 100
+
+// Redrive requeues every event on c that is in EventStateDequeuedError and matches filter, and
+// returns the number of requeued events. The state of each event is reset to EventStateQueued and
+// its RequeueCount is reset to zero, and the events are sent to c's subscribers again.
+//
+// Like Rewind, events are updated in small batches, so if Redrive returns an error some of the
+// events may have already been requeued.
+func (c *Channel) Redrive(filter RedriveFilter) (int, error) {
+
+	prefix, err := data.ChannelKey{
+		Channel: c.name,
+		Topic:   c.topic,
+	}.Marshal(nil)
+	if err != nil {
+		return 0, err
+	}
+	cursor, err := data.ChannelKey{
+		Channel: c.name,
+		Topic:   c.topic,
+		ID:      filter.MinID,
+	}.Marshal(nil)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	for cursor != nil {
+		var candidates []string
+		candidates, cursor, err = c.redriveCandidates(prefix, cursor, filter)
+		if err != nil {
+			return count, err
+		}
+		if len(candidates) == 0 {
+			continue
+		}
+
+		ids, err := c.redriveBatch(candidates)
+		if err != nil {
+			return count, err
+		}
+
+		for _, id := range ids {
+			c.shared.broadcastEventUpdated(id, EventStateQueued)
+		}
+		count += len(ids)
+	}
+
+	c.shared.wakeUp()
+
+	return count, nil
+}
+
+// redriveCandidates returns the IDs of up to redriveBatchSize events in EventStateDequeuedError
+// that match filter, starting at cursor, and the cursor for the next batch, or nil if there are no
+// more events. The events are found in a read-only transaction, so they don't hold up the store's
+// writer.
+func (c *Channel) redriveCandidates(prefix, cursor []byte, filter RedriveFilter) ([]string, []byte, error) {
+
+	txn := c.db.NewTransaction(false)
+	defer txn.Discard()
+
+	var ids []string
+	var next []byte
+
+	it := txn.NewIterator(storage.IteratorOptions{})
+	defer it.Close()
+
+	for it.Seek(cursor); it.ValidForPrefix(prefix); it.Next() {
+		if len(ids) >= redriveBatchSize {
+			next = it.Item().KeyCopy(nil)
+			break
+		}
+
+		var key data.ChannelKey
+		err := data.UnmarshalChannelKey(it.Item().Key(), &key)
+		if err != nil {
+			return nil, nil, fmt.Errorf("parse channel key %s: %v", it.Item().Key(), err)
+		}
+		if filter.MaxID != "" && key.ID > filter.MaxID {
+			break
+		}
+
+		val, err := it.Item().Value()
+		if err != nil {
+			return nil, nil, err
+		}
+		var channelEvent data.ChannelPayload
+		err = proto.Unmarshal(val, &channelEvent)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unmarshal channel payload: %v", err)
+		}
+		if channelEvent.EventState != data.EventState_DEQUEUED_ERROR {
+			continue
+		}
+
+		e, err := getEvent(txn, c.store.keys, c.topic, key.ID, "")
+		if err == ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("get event: %v", err)
+		}
+		if !filter.matches(e) {
+			continue
+		}
+		ids = append(ids, key.ID)
+	}
+
+	return ids, next, nil
+}
+
+// redriveBatch requeues the events of candidates that are still in EventStateDequeuedError, and
+// returns their IDs.
+func (c *Channel) redriveBatch(candidates []string) ([]string, error) {
+
+	var ids []string
+	var changes []rewindChange
+
+	err := c.store.write(context.Background(), func(txn storage.Txn) error {
+		ids = nil
+		changes = nil
+
+		for _, id := range candidates {
+			key := data.ChannelKey{
+				Channel: c.name,
+				Topic:   c.topic,
+				ID:      id,
+			}
+
+			// The state may have changed since the candidates were found.
+			channelEvent, err := getChannelEvent(txn, key)
+			if err != nil {
+				return fmt.Errorf("get event state: %v", err)
+			}
+			if channelEvent.EventState != data.EventState_DEQUEUED_ERROR {
+				continue
+			}
+			e, err := getEvent(txn, c.store.keys, c.topic, id, "")
+			if err == ErrNotFound {
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("get event: %v", err)
+			}
+
+			err = setChannelEvent(txn, key, data.ChannelPayload{
+				EventState: data.EventState_QUEUED,
+			})
+			if err != nil {
+				return fmt.Errorf("set event state: %v", err)
+			}
+			ids = append(ids, id)
+
+			if c.store.observer != nil {
+				e.State = EventStateQueued
+				changes = append(changes, rewindChange{*e, EventStateDequeuedError})
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, change := range changes {
+		c.store.observer.EventStateChanged(c.name, change.event, change.old, EventStateQueued)
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,165 @@
+package deq
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestRedrive(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	dir, err := ioutil.TempDir("", "test-redrive")
+	if err != nil {
+		t.Fatalf("create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	db, err := Open(Options{Dir: dir})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer db.Close()
+
+	now := time.Now()
+	events := []Event{
+		{ID: "event1", Topic: "TopicA", CreateTime: now, Indexes: []string{"customer-1"}},
+		{ID: "event2", Topic: "TopicA", CreateTime: now.Add(time.Second), Indexes: []string{"customer-2"}},
+		{ID: "event3", Topic: "TopicA", CreateTime: now.Add(2 * time.Second), Indexes: []string{"customer-1"}},
+		{ID: "event4", Topic: "TopicA", CreateTime: now.Add(3 * time.Second)},
+	}
+	_, err = db.PubBatch(ctx, events)
+	if err != nil {
+		t.Fatalf("pub: %v", err)
+	}
+
+	channel := db.Channel("channel", "TopicA")
+	defer channel.Close()
+
+	err = channel.RequeueEvent(events[0], 0)
+	if err != nil {
+		t.Fatalf("requeue: %v", err)
+	}
+	for _, e := range events[:3] {
+		err = channel.SetEventState(e.ID, EventStateDequeuedError)
+		if err != nil {
+			t.Fatalf("set state of %s: %v", e.ID, err)
+		}
+	}
+	err = channel.SetEventState("event4", EventStateDequeuedOK)
+	if err != nil {
+		t.Fatalf("set state of event4: %v", err)
+	}
+
+	// Only event3 is after event1 and has a customer-1 index.
+	count, err := channel.Redrive(RedriveFilter{
+		MinCreateTime: now.Add(time.Millisecond),
+		IndexPrefix:   "customer-1",
+	})
+	if err != nil {
+		t.Fatalf("redrive: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("expected 1 event redriven by create time and index, got %d", count)
+	}
+
+	// event3 is already queued, so only event1 and event2 are redriven.
+	count, err = channel.Redrive(RedriveFilter{MaxID: "event3"})
+	if err != nil {
+		t.Fatalf("redrive: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("expected 2 events redriven by id, got %d", count)
+	}
+
+	e, err := channel.Get("event1")
+	if err != nil {
+		t.Fatalf("get event1: %v", err)
+	}
+	if e.State != EventStateQueued || e.RequeueCount != 0 {
+		t.Errorf("expected event1 to be queued with a fresh requeue count, got state %v and requeue count %d", e.State, e.RequeueCount)
+	}
+	e, err = channel.Get("event4")
+	if err != nil {
+		t.Fatalf("get event4: %v", err)
+	}
+	if e.State != EventStateDequeuedOK {
+		t.Errorf("expected event4 to stay %v, got %v", EventStateDequeuedOK, e.State)
+	}
+
+	// Redriven events are delivered to the channel again. event1 may also be delivered from its
+	// earlier requeue.
+	delivered := make(map[string]bool)
+	for len(delivered) < 3 {
+		ctx, cancel := context.WithTimeout(ctx, time.Second)
+		e, err := channel.Next(ctx)
+		cancel()
+		if err == context.DeadlineExceeded {
+			break
+		}
+		if err != nil {
+			t.Fatalf("next: %v", err)
+		}
+		delivered[e.ID] = true
+	}
+	for _, id := range []string{"event1", "event2", "event3"} {
+		if !delivered[id] {
+			t.Errorf("expected %s to be delivered after redrive", id)
+		}
+	}
+}
+
+func TestRedriveBatches(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	db, err := Open(Options{InMemory: true})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer db.Close()
+
+	channel := db.Channel("channel", "topic")
+	defer channel.Close()
+
+	// Spread the failed events over several batches, between events that don't need redriving.
+	expected := 0
+	for i := 0; i < 3*redriveBatchSize; i++ {
+		id := fmt.Sprintf("event%03d", i)
+		_, err := db.Pub(ctx, Event{ID: id, Topic: "topic"})
+		if err != nil {
+			t.Fatalf("pub %s: %v", id, err)
+		}
+		state := EventStateDequeuedOK
+		if i%3 != 0 {
+			state = EventStateDequeuedError
+			expected++
+		}
+		err = channel.SetEventState(id, state)
+		if err != nil {
+			t.Fatalf("set state of %s: %v", id, err)
+		}
+	}
+
+	count, err := channel.Redrive(RedriveFilter{})
+	if err != nil {
+		t.Fatalf("redrive: %v", err)
+	}
+	if count != expected {
+		t.Errorf("expected %d events redriven, got %d", expected, count)
+	}
+
+	stats, err := channel.Stats()
+	if err != nil {
+		t.Fatalf("stats: %v", err)
+	}
+	if stats.Queued != expected || stats.DequeuedError != 0 {
+		t.Errorf("expected %d queued and no failed events, got %d and %d", expected, stats.Queued, stats.DequeuedError)
+	}
+}
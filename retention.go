@@ -0,0 +1,231 @@
+package deq
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"gitlab.com/katcheCode/deq/internal/data"
+	"gitlab.com/katcheCode/deq/internal/storage"
+)
+
+// RetentionPolicy specifies how long the events of a topic are kept before they are deleted.
+//
+// Expired events are deleted in the background, so they may remain visible for a short time after
+// they expire. The zero value keeps events until they are deleted.
+type RetentionPolicy struct {
+	// MaxAge is the maximum age of an event, measured from its CreateTime. If MaxAge is zero, events
+	// are not deleted based on their age.
+	MaxAge time.Duration
+	// MaxCount is the maximum number of events kept on the topic. Once a topic has more than
+	// MaxCount events, the events with the earliest CreateTime are deleted first. If MaxCount is
+	// zero, events are not deleted based on the number of events in the topic.
+	MaxCount int
+}
+
+func (p RetentionPolicy) isZero() bool {
+	return p.MaxAge <= 0 && p.MaxCount <= 0
+}
+
+// delBatchSize is the maximum number of events deleted in a single transaction.
+const delBatchSize = 100
+
+// retentionPolicy returns the RetentionPolicy of a topic. The policy of the topic's TopicConfig
+// takes precedence over the policies from Options.
+func (s *Store) retentionPolicy(topic string) RetentionPolicy {
+	if policy := s.TopicConfig(topic).Retention; !policy.isZero() {
+		return policy
+	}
+
+	policy, ok := s.retention[topic]
+	if !ok {
+		return s.defaultRetention
+	}
+	return policy
+}
+
+func (s *Store) expireEvents(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			err := s.expire(time.Now())
+			if err != nil {
+				log.Printf("[WARN] expire events: %v", err)
+			}
+		}
+	}
+}
+
+// expire deletes every event that has expired at the time now according to its topic's
+// RetentionPolicy.
+func (s *Store) expire(now time.Time) error {
+
+	txn := s.db.NewTransaction(false)
+	iter := newTopicIter(txn, DefaultIterOpts)
+	var topics []string
+	for iter.Next() {
+		topics = append(topics, iter.Topic())
+	}
+	iter.Close()
+	// Discard the read transaction before expiring, so it doesn't pin old versions while the topics
+	// are swept.
+	txn.Discard()
+
+	for _, topic := range topics {
+		select {
+		case <-s.done:
+			return nil
+		default:
+		}
+
+		policy := s.retentionPolicy(topic)
+		if policy.isZero() {
+			continue
+		}
+
+		err := s.expireTopic(topic, policy, now)
+		if err != nil {
+			return fmt.Errorf("topic %s: %v", topic, err)
+		}
+	}
+
+	return nil
+}
+
+// expireTopic deletes the events of topic that have expired at the time now according to policy.
+func (s *Store) expireTopic(topic string, policy RetentionPolicy, now time.Time) error {
+
+	var excess int
+	if policy.MaxCount > 0 {
+		count, err := s.countEvents(topic)
+		if err != nil {
+			return fmt.Errorf("count events: %v", err)
+		}
+		excess = count - policy.MaxCount
+	}
+
+	var cutoff time.Time
+	if policy.MaxAge > 0 {
+		cutoff = now.Add(-policy.MaxAge)
+	}
+
+	for {
+		deleted, more, err := s.delEventsBatch(context.Background(), topic, cutoff, excess)
+		if err == storage.ErrConflict {
+			// The events will be retried during the next sweep.
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if !more {
+			return nil
+		}
+		excess -= deleted
+	}
+}
+
+// delEventsBatch deletes up to delBatchSize of the earliest events of topic that were either
+// created before cutoff, or are among the first excess events of the topic. It returns the number
+// of events deleted and whether there may be more events to delete. Fewer events are deleted if
+// reading them takes longer than maxWriteHold.
+func (s *Store) delEventsBatch(ctx context.Context, topic string, cutoff time.Time, excess int) (int, bool, error) {
+
+	prefix, err := data.EventPrefixTopic(topic)
+	if err != nil {
+		return 0, false, err
+	}
+
+	type expired struct {
+		key     data.EventKey
+		payload data.EventPayload
+	}
+	var batch []expired
+	var more bool
+
+	err = s.write(ctx, func(txn storage.Txn) error {
+		batch = nil
+		more = false
+		deadline := time.Now().Add(maxWriteHold)
+
+		it := txn.NewIterator(storage.DefaultIteratorOptions)
+		for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
+			if len(batch) >= delBatchSize || (len(batch) > 0 && time.Now().After(deadline)) {
+				more = true
+				break
+			}
+
+			item := it.Item()
+
+			var key data.EventKey
+			err := data.UnmarshalTo(item.Key(), &key)
+			if err != nil {
+				log.Printf("[WARN] delete events: parse event key %s: %v", item.Key(), err)
+				continue
+			}
+
+			// Events are sorted by create time, so once an event isn't expired none of the following
+			// events are either.
+			if len(batch) >= excess && !key.CreateTime.Before(cutoff) {
+				break
+			}
+
+			val, err := item.Value()
+			if err != nil {
+				it.Close()
+				return err
+			}
+			// The payload isn't decrypted, so events can be deleted after their key is gone.
+			var payload data.EventPayload
+			err = proto.Unmarshal(val, &payload)
+			if err != nil {
+				it.Close()
+				return fmt.Errorf("unmarshal event payload: %v", err)
+			}
+
+			batch = append(batch, expired{key, payload})
+		}
+		it.Close()
+
+		for i := range batch {
+			err := deleteEvent(txn, batch[i].key, &batch[i].payload)
+			if err != nil {
+				return fmt.Errorf("delete event %s: %v", batch[i].key.ID, err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, false, err
+	}
+
+	if s.observer != nil {
+		for i := range batch {
+			s.observer.EventDeleted(s.deletedEvent(batch[i].key, batch[i].payload))
+		}
+	}
+
+	return len(batch), more, nil
+}
+
+// countEvents returns the number of events on topic.
+func (s *Store) countEvents(topic string) (int, error) {
+	txn := s.db.NewTransaction(false)
+	defer txn.Discard()
+
+	var stats data.TopicStatsPayload
+	err := getStats(txn, data.TopicStatsKey{Topic: topic}, &stats)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(stats.EventCount), nil
+}
@@ -0,0 +1,131 @@
+package deq
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestExpire(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	dir, err := ioutil.TempDir("", "test-expire")
+	if err != nil {
+		t.Fatalf("create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	db, err := Open(Options{
+		Dir: dir,
+		Retention: map[string]RetentionPolicy{
+			"TopicA": {MaxAge: time.Hour},
+			"TopicB": {MaxCount: 2},
+		},
+		// Make sure the background sweep doesn't interfere with the test.
+		RetentionInterval: time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer db.Close()
+
+	// Round(0) gets rid of leap-second info, which will be lost in serialization
+	now := time.Now().Round(0)
+
+	var events []Event
+	for i := 0; i < 4; i++ {
+		events = append(events, Event{
+			ID:         fmt.Sprintf("event%d", i),
+			Topic:      "TopicA",
+			CreateTime: now.Add(-time.Duration(i) * 40 * time.Minute),
+			Indexes:    []string{fmt.Sprintf("index%d", i)},
+		})
+		events = append(events, Event{
+			ID:         fmt.Sprintf("event%d", i),
+			Topic:      "TopicB",
+			CreateTime: now.Add(-time.Duration(i) * time.Minute),
+		})
+		events = append(events, Event{
+			ID:         fmt.Sprintf("event%d", i),
+			Topic:      "TopicC",
+			CreateTime: now.Add(-time.Duration(i) * 24 * time.Hour),
+		})
+	}
+
+	for _, e := range events {
+		_, err := db.Pub(ctx, e)
+		if err != nil {
+			t.Fatalf("pub: %v", err)
+		}
+	}
+
+	// Save some state on a channel so we can verify it gets cleaned up.
+	channel := db.Channel("channel", "TopicA")
+	defer channel.Close()
+
+	err = channel.SetEventState("event3", EventStateDequeuedOK)
+	if err != nil {
+		t.Fatalf("set event state: %v", err)
+	}
+
+	err = db.expire(now)
+	if err != nil {
+		t.Fatalf("expire: %v", err)
+	}
+
+	expected := map[string][]string{
+		"TopicA": {"event0", "event1"},
+		"TopicB": {"event0", "event1"},
+		"TopicC": {"event0", "event1", "event2", "event3"},
+	}
+	for topic, ids := range expected {
+		channel := db.Channel("channel", topic)
+		defer channel.Close()
+
+		var actual []string
+		iter := channel.NewEventIter(DefaultIterOpts)
+		for iter.Next() {
+			actual = append(actual, iter.Event().ID)
+		}
+		iter.Close()
+		if iter.Err() != nil {
+			t.Fatalf("iterate %s: %v", topic, iter.Err())
+		}
+
+		if !cmp.Equal(ids, actual) {
+			t.Errorf("topic %s:\n%s", topic, cmp.Diff(ids, actual))
+		}
+	}
+
+	var indexes []string
+	iter := channel.NewIndexIter(DefaultIterOpts)
+	for iter.Next() {
+		indexes = append(indexes, iter.Event().Indexes...)
+	}
+	iter.Close()
+	if iter.Err() != nil {
+		t.Fatalf("iterate indexes: %v", iter.Err())
+	}
+	expectedIndexes := []string{"index0", "index1"}
+	if !cmp.Equal(expectedIndexes, indexes) {
+		t.Errorf("indexes:\n%s", cmp.Diff(expectedIndexes, indexes))
+	}
+
+	txn := db.db.NewTransaction(false)
+	defer txn.Discard()
+
+	channels, err := getChannelNames(txn)
+	if err != nil {
+		t.Fatalf("get channel names: %v", err)
+	}
+	if len(channels) != 0 {
+		t.Errorf("expected channel state to be deleted, found channels %v", channels)
+	}
+}
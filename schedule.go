@@ -0,0 +1,436 @@
+package deq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"gitlab.com/katcheCode/deq/internal/cron"
+	"gitlab.com/katcheCode/deq/internal/data"
+	"gitlab.com/katcheCode/deq/internal/storage"
+)
+
+// Schedule publishes an event to a topic on each of its ticks, which are either the times matched
+// by a cron expression or multiples of a fixed interval.
+//
+// The event published on a tick has the ID returned by ScheduledEventID and its CreateTime is the
+// time of the tick, so publishing the same tick twice, such as after a restart, doesn't create a
+// duplicate event. If the store is closed during one or more ticks, only the last missed tick is
+// published once the store is opened again.
+type Schedule struct {
+	// Name identifies the schedule. It follows the same rules as topics.
+	// Required.
+	Name string
+	// Cron is a cron expression of the schedule's ticks, in UTC, such as "*/15 * * * *" or "@daily".
+	// It has five fields: minute, hour, day-of-month, month and day-of-week. Exactly one of Cron and
+	// Interval is required.
+	Cron string
+	// Interval is the time between the schedule's ticks. Ticks are at multiples of Interval since the
+	// unix epoch. Exactly one of Cron and Interval is required.
+	Interval time.Duration
+	// Topic is the topic of the published events.
+	// Required.
+	Topic string
+	// Payload is the payload of the published events.
+	Payload []byte
+	// Metadata is the metadata of the published events.
+	Metadata map[string]string
+	// LastTick is the time of the last tick that published an event, or the time the schedule was
+	// created if it hasn't ticked yet. It is ignored by SetSchedule.
+	LastTick time.Time
+}
+
+// ScheduledEventID returns the ID of the event published by the schedule with the given name on
+// the tick at time tick.
+func ScheduledEventID(name string, tick time.Time) string {
+	return name + "/" + tick.UTC().Format(time.RFC3339Nano)
+}
+
+// activeSchedule is a schedule in the store's cache.
+type activeSchedule struct {
+	Schedule
+	// cron is the parsed Cron of the schedule, or nil if it ticks at an interval.
+	cron *cron.Schedule
+}
+
+// next returns the time of the first tick of s after t, or the zero time if it has none.
+func (s *activeSchedule) next(t time.Time) time.Time {
+	if s.cron != nil {
+		return s.cron.Next(t)
+	}
+	interval := int64(s.Interval)
+	nsec := t.UnixNano()
+	return time.Unix(0, nsec-nsec%interval+interval)
+}
+
+// event returns the event published by s on the tick at time tick.
+func (s *activeSchedule) event(tick time.Time) Event {
+	return Event{
+		ID:         ScheduledEventID(s.Name, tick),
+		Topic:      s.Topic,
+		Payload:    s.Payload,
+		Metadata:   s.Metadata,
+		CreateTime: tick,
+	}
+}
+
+// due returns the time of the last tick of s after s.LastTick and at or before now, or the zero
+// time if there are none.
+func (s *activeSchedule) due(now time.Time) time.Time {
+	if s.cron == nil {
+		interval := int64(s.Interval)
+		nsec := now.UnixNano()
+		tick := time.Unix(0, nsec-nsec%interval)
+		if !tick.After(s.LastTick) {
+			return time.Time{}
+		}
+		return tick
+	}
+
+	var due time.Time
+	for tick := s.cron.Next(s.LastTick); !tick.IsZero() && !tick.After(now); tick = s.cron.Next(tick) {
+		due = tick
+	}
+	return due
+}
+
+// Schedules returns every schedule of the store, sorted by name.
+func (s *Store) Schedules() []Schedule {
+	s.schedulesMu.RLock()
+	defer s.schedulesMu.RUnlock()
+
+	schedules := make([]Schedule, 0, len(s.schedules))
+	for _, schedule := range s.schedules {
+		schedules = append(schedules, schedule.Schedule)
+	}
+	sort.Slice(schedules, func(i, j int) bool {
+		return schedules[i].Name < schedules[j].Name
+	})
+	return schedules
+}
+
+// SetSchedule creates or updates a schedule, and returns the schedule as stored. A new schedule
+// first ticks after it is created, and an updated schedule keeps its LastTick.
+//
+// If the schedule's topic has a schema, Payload must match it, or SetSchedule returns an
+// *InvalidPayloadError.
+func (s *Store) SetSchedule(schedule Schedule) (Schedule, error) {
+	if !isValidTopic(schedule.Name) {
+		return Schedule{}, errors.New("Name is not valid")
+	}
+	if !isValidTopic(schedule.Topic) {
+		return Schedule{}, errors.New("Topic is not valid")
+	}
+	if (schedule.Cron == "") == (schedule.Interval == 0) {
+		return Schedule{}, errors.New("exactly one of Cron and Interval is required")
+	}
+	if schedule.Interval < 0 {
+		return Schedule{}, errors.New("Interval must not be negative")
+	}
+	active := &activeSchedule{Schedule: schedule}
+	if schedule.Cron != "" {
+		var err error
+		active.cron, err = cron.Parse(schedule.Cron)
+		if err != nil {
+			return Schedule{}, fmt.Errorf("parse Cron: %v", err)
+		}
+	}
+
+	// Validate the event as it would be published, so a schedule can't be set that never publishes.
+	e := active.event(time.Now())
+	err := s.prepareEvent(&e)
+	if err != nil {
+		return Schedule{}, err
+	}
+	err = s.validatePayload(&e)
+	if err != nil {
+		return Schedule{}, err
+	}
+
+	key, err := data.ScheduleKey{Name: schedule.Name}.Marshal(nil)
+	if err != nil {
+		return Schedule{}, err
+	}
+
+	err = s.write(context.Background(), func(txn storage.Txn) error {
+		active.LastTick = time.Now()
+
+		existing, err := getSchedulePayload(txn, key)
+		if err != nil && err != ErrNotFound {
+			return err
+		}
+		if existing != nil {
+			active.LastTick = time.Unix(0, existing.LastTick)
+		}
+
+		return setSchedulePayload(txn, key, &active.Schedule)
+	})
+	if err != nil {
+		return Schedule{}, err
+	}
+
+	s.schedulesMu.Lock()
+	s.schedules[schedule.Name] = active
+	s.schedulesMu.Unlock()
+
+	s.schedulesChanged()
+
+	return active.Schedule, nil
+}
+
+// DelSchedule deletes a schedule. Events that it already published are not deleted. DelSchedule
+// returns ErrNotFound if the schedule doesn't exist.
+func (s *Store) DelSchedule(name string) error {
+	key, err := data.ScheduleKey{Name: name}.Marshal(nil)
+	if err != nil {
+		return err
+	}
+
+	err = s.write(context.Background(), func(txn storage.Txn) error {
+		_, err := getSchedulePayload(txn, key)
+		if err != nil {
+			return err
+		}
+		return txn.Delete(key)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.schedulesMu.Lock()
+	delete(s.schedules, name)
+	s.schedulesMu.Unlock()
+
+	s.schedulesChanged()
+
+	return nil
+}
+
+// schedulesChanged wakes the store's scheduler to reschedule its next tick.
+func (s *Store) schedulesChanged() {
+	select {
+	case s.schedulesWake <- struct{}{}:
+	default: // A wake up is already pending
+	}
+}
+
+// runSchedules publishes the ticks of the store's schedules until the store is closed.
+func (s *Store) runSchedules() {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-timer.C:
+		case <-s.schedulesWake:
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+		}
+
+		next := s.tick(time.Now())
+
+		wait := time.Hour
+		if !next.IsZero() && time.Until(next) < wait {
+			wait = time.Until(next)
+		}
+		timer.Reset(wait)
+	}
+}
+
+// tick publishes the events of every schedule that is due at the time now, and returns the time of
+// the next tick of any schedule, or the zero time if there is none.
+func (s *Store) tick(now time.Time) time.Time {
+	s.schedulesMu.RLock()
+	schedules := make([]*activeSchedule, 0, len(s.schedules))
+	for _, schedule := range s.schedules {
+		schedules = append(schedules, schedule)
+	}
+	s.schedulesMu.RUnlock()
+
+	var next time.Time
+	for _, schedule := range schedules {
+		lastTick := schedule.LastTick
+		if due := schedule.due(now); !due.IsZero() {
+			err := s.publishTick(schedule, due)
+			if err != nil {
+				// The tick is retried the next time the schedules are checked, unless a later tick is due
+				// by then.
+				log.Printf("[WARN] publish tick %v of schedule %s: %v", due, schedule.Name, err)
+			}
+			lastTick = due
+		}
+
+		tick := schedule.next(lastTick)
+		if !tick.IsZero() && (next.IsZero() || tick.Before(next)) {
+			next = tick
+		}
+	}
+
+	return next
+}
+
+// publishTick publishes the event of schedule for tick, and records tick as its last tick.
+func (s *Store) publishTick(schedule *activeSchedule, tick time.Time) error {
+	e := schedule.event(tick)
+	err := s.prepareEvent(&e)
+	if err != nil {
+		return err
+	}
+	err = s.validatePayload(&e)
+	if err != nil {
+		return err
+	}
+
+	key, err := data.ScheduleKey{Name: schedule.Name}.Marshal(nil)
+	if err != nil {
+		return err
+	}
+
+	var published, deleted bool
+	err = s.write(context.Background(), func(txn storage.Txn) error {
+		published, deleted = false, false
+
+		payload, err := getSchedulePayload(txn, key)
+		if err == ErrNotFound {
+			deleted = true
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		existing, err := writeOrMatchEvent(txn, &e, s.compression(e.Topic), s.keys)
+		if err != nil {
+			return err
+		}
+		published = existing == nil
+
+		payload.LastTick = tick.UnixNano()
+		val, err := proto.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("marshal schedule payload: %v", err)
+		}
+		return txn.Set(key, val)
+	})
+	if err != nil {
+		return err
+	}
+	if deleted {
+		return nil
+	}
+
+	s.schedulesMu.Lock()
+	if current, ok := s.schedules[schedule.Name]; ok && current == schedule {
+		updated := *schedule
+		updated.LastTick = tick
+		s.schedules[schedule.Name] = &updated
+	}
+	s.schedulesMu.Unlock()
+
+	if published {
+		e.State = e.DefaultState
+		s.published(&e)
+	}
+
+	return nil
+}
+
+// loadSchedules loads every schedule from disk.
+func (s *Store) loadSchedules() error {
+	txn := s.db.NewTransaction(false)
+	defer txn.Discard()
+
+	schedules := make(map[string]*activeSchedule)
+
+	it := txn.NewIterator(storage.DefaultIteratorOptions)
+	defer it.Close()
+
+	for it.Seek(data.SchedulePrefix); it.ValidForPrefix(data.SchedulePrefix); it.Next() {
+		item := it.Item()
+
+		var key data.ScheduleKey
+		err := data.UnmarshalScheduleKey(item.Key(), &key)
+		if err != nil {
+			return fmt.Errorf("unmarshal schedule key: %v", err)
+		}
+		val, err := item.Value()
+		if err != nil {
+			return err
+		}
+		var payload data.SchedulePayload
+		err = proto.Unmarshal(val, &payload)
+		if err != nil {
+			return fmt.Errorf("unmarshal schedule %s: %v", key.Name, err)
+		}
+
+		schedule := &activeSchedule{
+			Schedule: Schedule{
+				Name:     key.Name,
+				Cron:     payload.Cron,
+				Interval: time.Duration(payload.Interval),
+				Topic:    payload.Topic,
+				Payload:  payload.Payload,
+				Metadata: payload.Metadata,
+				LastTick: time.Unix(0, payload.LastTick),
+			},
+		}
+		if payload.Cron != "" {
+			schedule.cron, err = cron.Parse(payload.Cron)
+			if err != nil {
+				return fmt.Errorf("parse cron of schedule %s: %v", key.Name, err)
+			}
+		}
+		schedules[key.Name] = schedule
+	}
+
+	s.schedulesMu.Lock()
+	s.schedules = schedules
+	s.schedulesMu.Unlock()
+
+	return nil
+}
+
+func getSchedulePayload(txn storage.Txn, key []byte) (*data.SchedulePayload, error) {
+	item, err := txn.Get(key)
+	if err == storage.ErrKeyNotFound {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	val, err := item.Value()
+	if err != nil {
+		return nil, err
+	}
+	var payload data.SchedulePayload
+	err = proto.Unmarshal(val, &payload)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal schedule payload: %v", err)
+	}
+	return &payload, nil
+}
+
+func setSchedulePayload(txn storage.Txn, key []byte, schedule *Schedule) error {
+	val, err := proto.Marshal(&data.SchedulePayload{
+		Cron:     schedule.Cron,
+		Interval: int64(schedule.Interval),
+		Topic:    schedule.Topic,
+		Payload:  schedule.Payload,
+		Metadata: schedule.Metadata,
+		LastTick: schedule.LastTick.UnixNano(),
+	})
+	if err != nil {
+		return fmt.Errorf("marshal schedule payload: %v", err)
+	}
+	return txn.Set(key, val)
+}
@@ -0,0 +1,103 @@
+package deq
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestSchedule(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	dir, err := ioutil.TempDir("", "test-schedule")
+	if err != nil {
+		t.Fatalf("create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	db, err := Open(Options{Dir: dir})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+
+	_, err = db.SetSchedule(Schedule{Name: "invalid", Topic: "TopicA", Cron: "@hourly", Interval: time.Hour})
+	if err == nil {
+		t.Errorf("set schedule with cron and interval: expected error")
+	}
+	_, err = db.SetSchedule(Schedule{Name: "invalid", Topic: "TopicA", Cron: "61 * * * *"})
+	if err == nil {
+		t.Errorf("set schedule with invalid cron: expected error")
+	}
+
+	interval := 100 * time.Millisecond
+	_, err = db.SetSchedule(Schedule{
+		Name:     "every-100ms",
+		Topic:    "TopicA",
+		Interval: interval,
+		Payload:  []byte("tick"),
+		Metadata: map[string]string{"key": "value"},
+	})
+	if err != nil {
+		t.Fatalf("set schedule: %v", err)
+	}
+
+	channel := db.Channel("channel", "TopicA")
+	nextCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	e, err := channel.Next(nextCtx)
+	cancel()
+	if err != nil {
+		t.Fatalf("next: %v", err)
+	}
+	if e.CreateTime.UnixNano()%int64(interval) != 0 {
+		t.Errorf("expected tick at a multiple of %v, got %v", interval, e.CreateTime)
+	}
+	if e.ID != ScheduledEventID("every-100ms", e.CreateTime) {
+		t.Errorf("expected ID %s, got %s", ScheduledEventID("every-100ms", e.CreateTime), e.ID)
+	}
+	if string(e.Payload) != "tick" || e.Metadata["key"] != "value" {
+		t.Errorf("expected event from template, got %+v", e)
+	}
+	channel.Close()
+
+	// Publishing the same tick again doesn't create a duplicate.
+	schedules := db.Schedules()
+	if len(schedules) != 1 {
+		t.Fatalf("expected 1 schedule, got %d", len(schedules))
+	}
+	db.schedulesMu.RLock()
+	active := db.schedules["every-100ms"]
+	db.schedulesMu.RUnlock()
+	err = db.publishTick(active, e.CreateTime)
+	if err != nil {
+		t.Errorf("publish tick again: %v", err)
+	}
+	db.Close()
+
+	// Schedules are persisted.
+	db, err = Open(Options{Dir: dir})
+	if err != nil {
+		t.Fatalf("reopen db: %v", err)
+	}
+	defer db.Close()
+
+	schedules = db.Schedules()
+	if len(schedules) != 1 || schedules[0].Name != "every-100ms" || schedules[0].Interval != interval {
+		t.Errorf("expected schedule every-100ms after reopen, got %+v", schedules)
+	}
+
+	err = db.DelSchedule("every-100ms")
+	if err != nil {
+		t.Fatalf("delete schedule: %v", err)
+	}
+	err = db.DelSchedule("every-100ms")
+	if err != ErrNotFound {
+		t.Errorf("delete missing schedule: expected ErrNotFound, got %v", err)
+	}
+	if schedules := db.Schedules(); len(schedules) != 0 {
+		t.Errorf("expected no schedules after delete, got %+v", schedules)
+	}
+}
@@ -0,0 +1,243 @@
+package deq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gogo/protobuf/proto"
+	"gitlab.com/katcheCode/deq/internal/data"
+	"gitlab.com/katcheCode/deq/internal/schema"
+	"gitlab.com/katcheCode/deq/internal/storage"
+)
+
+// Schema is the protobuf message type of the payloads of a topic.
+//
+// Once a topic has a schema, Pub, PubBatch, SetSchedule and the responses of
+// Channel.SetEventState reject events on the topic whose payloads aren't valid encodings of the
+// schema's message type, including payloads with fields that aren't in the schema. Events already
+// in the store are not checked when a schema is registered or updated, and dead letters published
+// to the topic are not checked at all.
+type Schema struct {
+	// MessageType is the full name of the protobuf message type of the topic's payloads, such as
+	// "example.Order". Defaults to the topic.
+	MessageType string
+	// FileDescriptorSet is a serialized google.protobuf.FileDescriptorSet that contains MessageType
+	// and every type it depends on, such as the output of:
+	//
+	//   protoc --include_imports --descriptor_set_out=order.pb order.proto
+	FileDescriptorSet []byte
+	// Version is incremented each time the topic's schema is updated, starting at 1. It is ignored
+	// by SetSchema.
+	Version int64
+}
+
+// InvalidPayloadError is returned when publishing an event whose payload doesn't match the schema
+// of its topic.
+type InvalidPayloadError struct {
+	Topic string
+	ID    string
+	Err   error
+}
+
+func (err *InvalidPayloadError) Error() string {
+	return fmt.Sprintf("payload of event %s on topic %s doesn't match schema: %v", err.ID, err.Topic, err.Err)
+}
+
+// IncompatibleSchemaError is returned by SetSchema when an updated schema can't read payloads
+// written with the current schema of the topic.
+type IncompatibleSchemaError struct {
+	Topic string
+	Err   error
+}
+
+func (err *IncompatibleSchemaError) Error() string {
+	return fmt.Sprintf("schema of topic %s is incompatible with the current schema: %v", err.Topic, err.Err)
+}
+
+// registeredSchema is a schema in a store's registry.
+type registeredSchema struct {
+	Schema
+	parsed *schema.Schema
+}
+
+// Schema returns the schema of a topic. Schema returns ErrNotFound if the topic has no schema.
+func (s *Store) Schema(topic string) (Schema, error) {
+	s.schemasMu.RLock()
+	defer s.schemasMu.RUnlock()
+
+	registered, ok := s.schemas[topic]
+	if !ok {
+		return Schema{}, ErrNotFound
+	}
+	return registered.Schema, nil
+}
+
+// SetSchema registers or updates the schema of a topic, and returns the schema as registered.
+//
+// If the topic already has a schema, the new schema must be backward compatible with it, so that
+// every event published with the current schema is still valid. Fields may be added, and fields may
+// be removed if their numbers are reserved, but existing fields can't change to a type with a
+// different encoding. If the new schema isn't backward compatible, SetSchema returns an
+// *IncompatibleSchemaError.
+//
+// Deleting a topic with DelTopic does not delete its schema.
+func (s *Store) SetSchema(topic string, sch Schema) (Schema, error) {
+	if !isValidTopic(topic) {
+		return Schema{}, errors.New("topic is not valid")
+	}
+	if sch.MessageType == "" {
+		sch.MessageType = topic
+	}
+
+	parsed, err := schema.Parse(sch.FileDescriptorSet, sch.MessageType)
+	if err != nil {
+		return Schema{}, err
+	}
+	sch.MessageType = parsed.MessageType
+
+	key, err := data.SchemaKey{Topic: topic}.Marshal(nil)
+	if err != nil {
+		return Schema{}, err
+	}
+
+	var registered *registeredSchema
+	err = s.write(context.Background(), func(txn storage.Txn) error {
+		registered = nil
+
+		current, err := getSchemaPayload(txn, key)
+		if err != nil && err != ErrNotFound {
+			return err
+		}
+		version := int64(1)
+		if err == nil {
+			old, err := schema.Parse(current.FileDescriptorSet, current.MessageType)
+			if err != nil {
+				return fmt.Errorf("parse current schema: %v", err)
+			}
+			err = schema.CheckCompatible(old, parsed)
+			if err != nil {
+				return &IncompatibleSchemaError{Topic: topic, Err: err}
+			}
+			version = current.Version + 1
+		}
+
+		val, err := proto.Marshal(&data.SchemaPayload{
+			MessageType:       sch.MessageType,
+			FileDescriptorSet: sch.FileDescriptorSet,
+			Version:           version,
+		})
+		if err != nil {
+			return fmt.Errorf("marshal schema payload: %v", err)
+		}
+		err = txn.Set(key, val)
+		if err != nil {
+			return err
+		}
+
+		registered = &registeredSchema{
+			Schema: Schema{
+				MessageType:       sch.MessageType,
+				FileDescriptorSet: sch.FileDescriptorSet,
+				Version:           version,
+			},
+			parsed: parsed,
+		}
+		return nil
+	})
+	if err != nil {
+		return Schema{}, err
+	}
+
+	s.schemasMu.Lock()
+	s.schemas[topic] = registered
+	s.schemasMu.Unlock()
+
+	return registered.Schema, nil
+}
+
+// validatePayload returns an *InvalidPayloadError if the payload of e doesn't match the schema of
+// its topic.
+func (s *Store) validatePayload(e *Event) error {
+	s.schemasMu.RLock()
+	registered, ok := s.schemas[e.Topic]
+	s.schemasMu.RUnlock()
+
+	if !ok {
+		return nil
+	}
+	err := registered.parsed.Validate(e.Payload)
+	if err != nil {
+		return &InvalidPayloadError{Topic: e.Topic, ID: e.ID, Err: err}
+	}
+	return nil
+}
+
+// loadSchemas loads the schema registry from disk.
+func (s *Store) loadSchemas() error {
+	txn := s.db.NewTransaction(false)
+	defer txn.Discard()
+
+	schemas := make(map[string]*registeredSchema)
+
+	it := txn.NewIterator(storage.DefaultIteratorOptions)
+	defer it.Close()
+
+	for it.Seek(data.SchemaPrefix); it.ValidForPrefix(data.SchemaPrefix); it.Next() {
+		item := it.Item()
+
+		var key data.SchemaKey
+		err := data.UnmarshalSchemaKey(item.Key(), &key)
+		if err != nil {
+			return fmt.Errorf("unmarshal schema key: %v", err)
+		}
+		val, err := item.Value()
+		if err != nil {
+			return err
+		}
+		var payload data.SchemaPayload
+		err = proto.Unmarshal(val, &payload)
+		if err != nil {
+			return fmt.Errorf("unmarshal schema of topic %s: %v", key.Topic, err)
+		}
+		parsed, err := schema.Parse(payload.FileDescriptorSet, payload.MessageType)
+		if err != nil {
+			return fmt.Errorf("parse schema of topic %s: %v", key.Topic, err)
+		}
+
+		schemas[key.Topic] = &registeredSchema{
+			Schema: Schema{
+				MessageType:       payload.MessageType,
+				FileDescriptorSet: payload.FileDescriptorSet,
+				Version:           payload.Version,
+			},
+			parsed: parsed,
+		}
+	}
+
+	s.schemasMu.Lock()
+	s.schemas = schemas
+	s.schemasMu.Unlock()
+
+	return nil
+}
+
+func getSchemaPayload(txn storage.Txn, key []byte) (*data.SchemaPayload, error) {
+	item, err := txn.Get(key)
+	if err == storage.ErrKeyNotFound {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	val, err := item.Value()
+	if err != nil {
+		return nil, err
+	}
+	var payload data.SchemaPayload
+	err = proto.Unmarshal(val, &payload)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal schema payload: %v", err)
+	}
+	return &payload, nil
+}
@@ -0,0 +1,304 @@
+package deq
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/gogo/protobuf/protoc-gen-gogo/descriptor"
+	"github.com/google/go-cmp/cmp"
+)
+
+// orderDescriptorSet returns a file descriptor set for:
+//
+//   syntax = "proto3";
+//   package test;
+//
+//   message Order {
+//     string id = 1;
+//     <quantityType> quantity = 2;
+//   }
+func orderDescriptorSet(t *testing.T, quantityType descriptor.FieldDescriptorProto_Type) []byte {
+	t.Helper()
+
+	optional := descriptor.FieldDescriptorProto_LABEL_OPTIONAL
+	set := &descriptor.FileDescriptorSet{
+		File: []*descriptor.FileDescriptorProto{
+			{
+				Name:    proto.String("order.proto"),
+				Package: proto.String("test"),
+				Syntax:  proto.String("proto3"),
+				MessageType: []*descriptor.DescriptorProto{
+					{
+						Name: proto.String("Order"),
+						Field: []*descriptor.FieldDescriptorProto{
+							{
+								Name:   proto.String("id"),
+								Number: proto.Int32(1),
+								Type:   descriptor.FieldDescriptorProto_TYPE_STRING.Enum(),
+								Label:  optional.Enum(),
+							},
+							{
+								Name:   proto.String("quantity"),
+								Number: proto.Int32(2),
+								Type:   quantityType.Enum(),
+								Label:  optional.Enum(),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	buf, err := proto.Marshal(set)
+	if err != nil {
+		t.Fatalf("marshal file descriptor set: %v", err)
+	}
+	return buf
+}
+
+func TestSchema(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	dir, err := ioutil.TempDir("", "test-schema")
+	if err != nil {
+		t.Fatalf("create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	db, err := Open(Options{Dir: dir})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+
+	_, err = db.Schema("TopicA")
+	if err != ErrNotFound {
+		t.Errorf("get missing schema: expected ErrNotFound, got %v", err)
+	}
+
+	expected := Schema{
+		MessageType:       "test.Order",
+		FileDescriptorSet: orderDescriptorSet(t, descriptor.FieldDescriptorProto_TYPE_INT64),
+		Version:           1,
+	}
+	schema, err := db.SetSchema("TopicA", Schema{
+		MessageType:       ".test.Order",
+		FileDescriptorSet: expected.FileDescriptorSet,
+	})
+	if err != nil {
+		t.Fatalf("set schema: %v", err)
+	}
+	if !cmp.Equal(expected, schema) {
+		t.Errorf("set schema:\n%s", cmp.Diff(expected, schema))
+	}
+
+	// id: "a", quantity: 3
+	valid := []byte{0x0a, 1, 'a', 0x10, 3}
+	// quantity encoded as fixed32.
+	invalid := []byte{0x0a, 1, 'a', 0x15, 3, 0, 0, 0}
+
+	_, err = db.Pub(ctx, Event{ID: "event1", Topic: "TopicA", Payload: valid})
+	if err != nil {
+		t.Errorf("pub valid payload: %v", err)
+	}
+	_, err = db.Pub(ctx, Event{ID: "event2", Topic: "TopicA", Payload: invalid})
+	if _, ok := err.(*InvalidPayloadError); !ok {
+		t.Errorf("pub invalid payload: expected *InvalidPayloadError, got %v", err)
+	}
+	_, err = db.PubBatch(ctx, []Event{
+		{ID: "event3", Topic: "TopicA", Payload: valid},
+		{ID: "event4", Topic: "TopicA", Payload: invalid},
+	})
+	if _, ok := err.(*InvalidPayloadError); !ok {
+		t.Errorf("pub batch with invalid payload: expected *InvalidPayloadError, got %v", err)
+	}
+	_, err = db.Pub(ctx, Event{ID: "event2", Topic: "TopicB", Payload: invalid})
+	if err != nil {
+		t.Errorf("pub on topic without schema: %v", err)
+	}
+
+	// Imported events are validated too.
+	var export bytes.Buffer
+	_, err = db.Export(ctx, &export, "TopicB", ExportOpts{Format: ExportFormatJSON})
+	if err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	imported := bytes.Replace(export.Bytes(), []byte(`"TopicB"`), []byte(`"TopicA"`), -1)
+	_, err = db.Import(ctx, bytes.NewReader(imported), ImportOpts{Format: ExportFormatJSON})
+	if _, ok := err.(*InvalidPayloadError); !ok {
+		t.Errorf("import invalid payload: expected *InvalidPayloadError, got %v", err)
+	}
+
+	channel := db.Channel("channel", "TopicA")
+	_, err = channel.Get("event3")
+	if err != ErrNotFound {
+		t.Errorf("get event3: expected ErrNotFound, got %v", err)
+	}
+	channel.Close()
+
+	// Changing quantity to a double is incompatible.
+	_, err = db.SetSchema("TopicA", Schema{
+		MessageType:       "test.Order",
+		FileDescriptorSet: orderDescriptorSet(t, descriptor.FieldDescriptorProto_TYPE_DOUBLE),
+	})
+	if _, ok := err.(*IncompatibleSchemaError); !ok {
+		t.Errorf("set incompatible schema: expected *IncompatibleSchemaError, got %v", err)
+	}
+
+	// Changing quantity to an int32 is compatible.
+	expected = Schema{
+		MessageType:       "test.Order",
+		FileDescriptorSet: orderDescriptorSet(t, descriptor.FieldDescriptorProto_TYPE_INT32),
+		Version:           2,
+	}
+	_, err = db.SetSchema("TopicA", Schema{
+		MessageType:       "test.Order",
+		FileDescriptorSet: expected.FileDescriptorSet,
+	})
+	if err != nil {
+		t.Fatalf("set compatible schema: %v", err)
+	}
+	db.Close()
+
+	// The registry is persisted.
+	db, err = Open(Options{Dir: dir})
+	if err != nil {
+		t.Fatalf("reopen db: %v", err)
+	}
+	defer db.Close()
+
+	schema, err = db.Schema("TopicA")
+	if err != nil {
+		t.Fatalf("get schema: %v", err)
+	}
+	if !cmp.Equal(expected, schema) {
+		t.Errorf("get schema:\n%s", cmp.Diff(expected, schema))
+	}
+	_, err = db.Pub(ctx, Event{ID: "event2", Topic: "TopicA", Payload: invalid})
+	if _, ok := err.(*InvalidPayloadError); !ok {
+		t.Errorf("pub invalid payload after reopen: expected *InvalidPayloadError, got %v", err)
+	}
+}
+
+func TestSchemaDeadLetter(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	db, err := Open(Options{InMemory: true})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer db.Close()
+
+	_, err = db.SetSchema("DLQ", Schema{
+		MessageType:       "test.Order",
+		FileDescriptorSet: orderDescriptorSet(t, descriptor.FieldDescriptorProto_TYPE_INT64),
+	})
+	if err != nil {
+		t.Fatalf("set schema: %v", err)
+	}
+	err = db.SetTopicConfig("TopicA", TopicConfig{RequeueLimit: 1, DeadLetterTopic: "DLQ"})
+	if err != nil {
+		t.Fatalf("set topic config: %v", err)
+	}
+
+	events, err := db.PubBatch(ctx, []Event{
+		// id: "a", quantity: 3
+		{ID: "valid", Topic: "TopicA", Payload: []byte{0x0a, 1, 'a', 0x10, 3}},
+		// quantity encoded as fixed32.
+		{ID: "invalid", Topic: "TopicA", Payload: []byte{0x0a, 1, 'a', 0x15, 3, 0, 0, 0}},
+	})
+	if err != nil {
+		t.Fatalf("pub: %v", err)
+	}
+
+	channel := db.Channel("channel", "TopicA")
+	defer channel.Close()
+
+	for _, e := range events {
+		for i := 0; i < 2; i++ {
+			err = channel.RequeueEvent(e, 0)
+			if err != nil {
+				t.Fatalf("requeue %s: %v", e.ID, err)
+			}
+		}
+		// The event is dequeued whether or not it matches the schema of the dead letter topic.
+		requeued, err := channel.Get(e.ID)
+		if err != nil {
+			t.Fatalf("get %s: %v", e.ID, err)
+		}
+		if requeued.State != EventStateDequeuedError {
+			t.Errorf("expected %s to be %v, got %v", e.ID, EventStateDequeuedError, requeued.State)
+		}
+	}
+
+	dlq := db.Channel("channel", "DLQ")
+	defer dlq.Close()
+
+	// Dead letters are exempt from the schema, so neither event is lost.
+	for _, e := range events {
+		deadLetter, err := dlq.Get("TopicA/channel/" + e.ID)
+		if err != nil {
+			t.Errorf("get dead letter of %s: %v", e.ID, err)
+			continue
+		}
+		if !cmp.Equal(e.Payload, deadLetter.Payload) {
+			t.Errorf("dead letter of %s: payload:\n%s", e.ID, cmp.Diff(e.Payload, deadLetter.Payload))
+		}
+	}
+}
+
+func TestSchemaSchedule(t *testing.T) {
+	t.Parallel()
+
+	db, err := Open(Options{InMemory: true})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer db.Close()
+
+	_, err = db.SetSchema("TopicA", Schema{
+		MessageType:       "test.Order",
+		FileDescriptorSet: orderDescriptorSet(t, descriptor.FieldDescriptorProto_TYPE_INT64),
+	})
+	if err != nil {
+		t.Fatalf("set schema: %v", err)
+	}
+
+	_, err = db.SetSchedule(Schedule{
+		Name:     "valid",
+		Topic:    "TopicA",
+		Interval: time.Hour,
+		// id: "a", quantity: 3
+		Payload: []byte{0x0a, 1, 'a', 0x10, 3},
+	})
+	if err != nil {
+		t.Errorf("set schedule with valid payload: %v", err)
+	}
+
+	_, err = db.SetSchedule(Schedule{
+		Name:     "invalid",
+		Topic:    "TopicA",
+		Interval: time.Hour,
+		// quantity encoded as fixed32.
+		Payload: []byte{0x0a, 1, 'a', 0x15, 3, 0, 0, 0},
+	})
+	if _, ok := err.(*InvalidPayloadError); !ok {
+		t.Errorf("set schedule with invalid payload: expected *InvalidPayloadError, got %v", err)
+	}
+
+	var names []string
+	for _, schedule := range db.Schedules() {
+		names = append(names, schedule.Name)
+	}
+	if !cmp.Equal([]string{"valid"}, names) {
+		t.Errorf("schedules:\n%s", cmp.Diff([]string{"valid"}, names))
+	}
+}
@@ -1,14 +1,17 @@
 package deq
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
-	"github.com/dgraph-io/badger"
 	"github.com/gogo/protobuf/proto"
 	"gitlab.com/katcheCode/deq/internal/data"
+	"gitlab.com/katcheCode/deq/internal/storage"
 )
 
 type channelKey struct {
@@ -16,18 +19,36 @@ type channelKey struct {
 	topic string
 }
 
+// outBufferSize is the number of events a sharedChannel buffers for its subscribers. Buffered
+// events are sent before any event that arrives after them, regardless of priority, so Priority
+// only orders the events behind the buffer.
+const outBufferSize = 20
+
 type sharedChannel struct {
 	name  string
 	topic string
-	db    *badger.DB
+	db    storage.DB
+	// write commits writes through the store's writer.
+	write func(ctx context.Context, apply func(txn storage.Txn) error) error
+	// counters are the counters of the store.
+	counters *counters
+	// observer is the store's observer, or nil if it doesn't have one.
+	observer Observer
+	// keys are the store's KeyProvider, or nil if it doesn't have one.
+	keys KeyProvider
+	// store writes and publishes dead letters.
+	store *Store
 
 	subscriptions int
 
 	missedMutex sync.Mutex
 	missed      bool
 
-	in  chan *Event
+	in chan *Event
+	// out buffers up to outBufferSize events for the sharedChannel's subscribers.
 	out chan *Event
+	// wake signals the sharedChannel to catch up from disk.
+	wake chan struct{}
 	// done signals goroutines created by the sharedChannel to terminate.
 	done chan struct{}
 	// wg waits on all goroutines created by the sharedChannel to be done
@@ -36,12 +57,20 @@ type sharedChannel struct {
 	idleMutex sync.RWMutex
 	idle      bool
 
+	scheduledMutex sync.Mutex
+	// scheduledTimer wakes the sharedChannel at scheduledAt, the earliest DeliverAt of the scheduled
+	// events it has seen, or is nil if it has seen no scheduled events.
+	scheduledTimer *time.Timer
+	scheduledAt    time.Time
+
 	stateSubsMutex sync.RWMutex
 	// Pass in a response channel, when the event is dequeued the new state will be sent back on the
 	// response channel
 	stateSubs map[string]map[*EventStateSubscription]struct{}
 
-	defaultRequeueLimit int
+	// requeueLimit returns the current requeue limit of the channel's topic, or -1 if it has no
+	// limit.
+	requeueLimit func() int
 }
 
 // addChannel adds a listener to a sharedChannel and returns the sharedChannel along with a done
@@ -80,14 +109,23 @@ func (s *Store) listenSharedChannel(name, topic string) (*sharedChannel, func())
 		name:  name,
 		topic: topic,
 		db:    s.db,
+		write: s.write,
 
-		in:  make(chan *Event, 20),
-		out: make(chan *Event, 20),
+		counters: s.counters,
+		observer: s.observer,
+		keys:     s.keys,
+		store:    s,
+
+		in:   make(chan *Event, 20),
+		out:  make(chan *Event, outBufferSize),
+		wake: make(chan struct{}, 1),
 
 		stateSubs: make(map[string]map[*EventStateSubscription]struct{}),
 		done:      make(chan struct{}),
 
-		defaultRequeueLimit: s.defaultRequeueLimit,
+		requeueLimit: func() int {
+			return s.requeueLimit(topic)
+		},
 	}
 	s.sharedChannels[key] = shared
 
@@ -118,47 +156,120 @@ func (s *sharedChannel) setMissed(m bool) {
 	s.missedMutex.Unlock()
 }
 
-func (s *sharedChannel) RequeueEvent(e Event, delay time.Duration) error {
-	requeue := func() error {
-		// retry for up to 10 conflicts.
-		for i := 0; i < 10; i++ {
-			// log.Printf("REQUEUING %s/%s count: %d", e.Topic, e.ID, e.RequeueCount)
+// wakeUp causes the sharedChannel to catch up from disk, even if it hasn't missed any events.
+func (s *sharedChannel) wakeUp() {
+	s.setMissed(true)
+	select {
+	case s.wake <- struct{}{}:
+	default: // A catch up is already pending
+	}
+}
+
+// scheduleWakeUp causes the sharedChannel to catch up from disk at deliverAt, so scheduled events
+// are sent once they are due.
+func (s *sharedChannel) scheduleWakeUp(deliverAt time.Time) {
+	s.scheduledMutex.Lock()
+	defer s.scheduledMutex.Unlock()
+
+	if s.scheduledTimer != nil {
+		if !deliverAt.Before(s.scheduledAt) {
+			// An earlier wake up is already pending, which will schedule the next one.
+			return
+		}
+		s.scheduledTimer.Stop()
+	}
+
+	s.scheduledAt = deliverAt
+	s.scheduledTimer = time.AfterFunc(time.Until(deliverAt), func() {
+		s.scheduledMutex.Lock()
+		s.scheduledTimer = nil
+		s.scheduledMutex.Unlock()
+
+		s.wakeUp()
+	})
+}
+
+// stopScheduledWakeUp stops the pending scheduled wake up, if any.
+func (s *sharedChannel) stopScheduledWakeUp() {
+	s.scheduledMutex.Lock()
+	defer s.scheduledMutex.Unlock()
+
+	if s.scheduledTimer != nil {
+		s.scheduledTimer.Stop()
+		s.scheduledTimer = nil
+	}
+}
 
-			txn := s.db.NewTransaction(true)
-			defer txn.Discard()
+// RequeueEvent requeues e after delay. If e reaches its requeue limit and deadLetterTopic isn't
+// empty, e's dead letter is published to deadLetterTopic. If e has been deleted by the time it is
+// requeued, it is dropped from the sharedChannel instead.
+func (s *sharedChannel) RequeueEvent(e Event, delay time.Duration, deadLetterTopic string) error {
+	requeue := func() error {
+		limit := e.RequeueLimit
+		if limit == 0 {
+			limit = s.requeueLimit()
+		}
 
-			channelPayload, err := incrementSavedRequeueCount(txn, s.name, s.topic, s.defaultRequeueLimit, &e)
+		var channelPayload *data.ChannelPayload
+		var deadLetter *Event
+		err := s.write(context.Background(), func(txn storage.Txn) error {
+			var err error
+			channelPayload, err = incrementSavedRequeueCount(txn, s.name, s.topic, limit, &e)
 			if err != nil {
 				return err
 			}
 
-			err = txn.Commit(nil)
-			if err == badger.ErrConflict {
-				log.Printf("[WARN] Requeue Event %s %s: %v: retrying", s.topic, e.ID, err)
-				txn.Discard()
-				time.Sleep(time.Millisecond * 20)
-				continue
+			deadLetter = nil
+			if channelPayload.EventState == data.EventState_QUEUED || deadLetterTopic == "" {
+				return nil
 			}
+			deadLetter, err = s.store.writeDeadLetter(txn, s.name, s.topic, e.ID, deadLetterTopic, channelPayload)
 			if err != nil {
-				return fmt.Errorf("commit channel event: %v", err)
+				return fmt.Errorf("write dead letter: %v", err)
 			}
+			return nil
+		})
+		if err == ErrNotFound {
+			// Deleted, don't send it again.
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("commit channel event: %v", err)
+		}
 
-			if channelPayload.EventState != data.EventState_QUEUED {
+		if channelPayload.EventState != data.EventState_QUEUED {
+			atomic.AddInt64(&s.counters.requeueLimitExhausted, 1)
+			if deadLetter != nil {
+				log.Printf("channel %s: requeue limit exceeded for topic: %s id: %s - dequeing, published dead letter to %s", s.name, s.topic, e.ID, deadLetterTopic)
+				s.store.published(deadLetter)
+			} else {
 				log.Printf("channel %s: requeue limit exceeded for topic: %s id: %s - dequeing", s.name, s.topic, e.ID)
-				s.broadcastEventUpdated(e.ID, protoToEventState(channelPayload.EventState))
-				return nil
 			}
-
-			e.RequeueCount = int(channelPayload.RequeueCount)
-			select {
-			case <-s.done:
-				return nil
-			case s.out <- &e:
-				return nil
+			newState := protoToEventState(channelPayload.EventState)
+			s.broadcastEventUpdated(e.ID, newState)
+			if s.observer != nil {
+				old := e.State
+				e.State = newState
+				s.observer.EventStateChanged(s.name, e, old, newState)
+				s.observer.RequeueLimitExhausted(s.name, e)
 			}
+			return nil
 		}
 
-		return badger.ErrConflict
+		atomic.AddInt64(&s.counters.requeues, 1)
+
+		e.RequeueCount = int(channelPayload.RequeueCount)
+		if s.observer != nil {
+			s.observer.EventRequeued(s.name, e)
+		}
+		// Requeued events are sent in order of priority along with new events, or read from disk if
+		// the sharedChannel is behind.
+		select {
+		case s.in <- &e:
+		default:
+			s.wakeUp()
+		}
+		return nil
 	}
 
 	if delay == 0 {
@@ -187,6 +298,7 @@ func (s *sharedChannel) RequeueEvent(e Event, delay time.Duration) error {
 }
 
 func (s *sharedChannel) start() {
+	defer s.stopScheduledWakeUp()
 
 	cursor, err := s.getCursor(s.topic)
 	if err != nil {
@@ -200,8 +312,14 @@ func (s *sharedChannel) start() {
 	// }
 	// defer timer.Stop()
 
+	// pending holds the events received from memory that haven't been sent yet. It is bounded by the
+	// capacity of s.in, so events keep being read from disk if the subscribers fall behind.
+	var pending pendingEvents
+
 	for {
 		s.setMissed(false)
+		// We'll read these off the disk too.
+		pending = pending[:0]
 
 		// Let's drain our events so have room for some that might come in while we catch up.
 		// We'll read these off the disk, so it's ok to discard them
@@ -221,7 +339,7 @@ func (s *sharedChannel) start() {
 			// if we're already idle, we don't want idle getting set over and over.
 			// by leaving the timer expired, it won't trigger again.
 			// if !s.Idle() && len(s.in) == 0 {
-			if len(s.in) == 0 {
+			if len(s.in) == 0 && len(pending) == 0 {
 				// Only show that we're idle if it lasts for more than a short time
 				// TODO: we could get this instead by having the store directly track if
 				// it's idle or not. then the channel would be idle only if it's not
@@ -235,27 +353,45 @@ func (s *sharedChannel) start() {
 				s.idleMutex.Unlock()
 			}
 
+			// Receive new events while sending the highest priority pending event, so events that
+			// arrive while the subscribers are busy can still be sent ahead of lower priority ones.
+			in := s.in
+			if len(pending) >= cap(s.in) {
+				in = nil
+			}
+			var out chan *Event
+			var next *Event
+			if len(pending) > 0 {
+				out = s.out
+				next = pending[0]
+			}
+
 			select {
 			case <-s.done:
 				return
+			case <-s.wake:
+				s.setMissed(true)
 			// The timer expired, we're idle
 			// case <-timer.C:
 			// We've got a new event, lets publish it
-			case e := <-s.in:
+			case e := <-in:
+				if e.Scheduled(time.Now()) {
+					// It will be read from disk once it is due.
+					s.scheduleWakeUp(e.DeliverAt)
+					continue
+				}
 				s.idleMutex.Lock()
 				s.idle = false
 				s.idleMutex.Unlock()
 				// log.Printf("READING FROM MEMORY %s/%s count: %d", e.Topic, e.ID, e.RequeueCount)
-				select {
-				case <-s.done:
-					return
-				case s.out <- e:
-					cursor, _ = data.EventKey{
-						Topic:      e.Topic,
-						CreateTime: e.CreateTime,
-						ID:         e.ID,
-					}.Marshal(nil)
-				}
+				pending.add(e)
+			case out <- next:
+				pending.remove()
+				cursor, _ = data.EventKey{
+					Topic:      next.Topic,
+					CreateTime: next.CreateTime,
+					ID:         next.ID,
+				}.Marshal(nil)
 			}
 		}
 
@@ -264,12 +400,77 @@ func (s *sharedChannel) start() {
 	}
 }
 
+// pendingEvents are events received by a sharedChannel from memory that haven't been sent to its
+// subscribers yet, ordered by descending Priority, then in the order they were received. Events
+// already buffered in the sharedChannel's out channel are sent before any pending events.
+type pendingEvents []*Event
+
+// add inserts e after every pending event with the same or a higher priority.
+func (p *pendingEvents) add(e *Event) {
+	events := *p
+	i := sort.Search(len(events), func(i int) bool {
+		return events[i].Priority < e.Priority
+	})
+	events = append(events, nil)
+	copy(events[i+1:], events[i:])
+	events[i] = e
+	*p = events
+}
+
+// remove removes the first pending event.
+func (p *pendingEvents) remove() {
+	events := *p
+	copy(events, events[1:])
+	events[len(events)-1] = nil
+	*p = events[:len(events)-1]
+}
+
+// catchUp sends the queued events of the sharedChannel's topic from disk. Events with a positive
+// priority are sent first, by descending priority, then events with the default priority in
+// EventKey order, then events with a negative priority.
+//
 // catchUp returns nil instead of new prefix when time to quit
 func (s *sharedChannel) catchUp(cursor []byte) ([]byte, error) {
 	txn := s.db.NewTransaction(false)
 	defer txn.Discard()
 
-	opts := badger.DefaultIteratorOptions
+	prefix, err := data.PriorityPrefixTopic(s.topic)
+	if err != nil {
+		return nil, err
+	}
+	done, err := s.catchUpPriority(txn, prefix, prefix, true)
+	if err != nil {
+		return nil, err
+	}
+	if done {
+		return cursor, nil
+	}
+
+	lastKey, done, err := s.catchUpDefaultPriority(txn)
+	if err != nil {
+		return nil, err
+	}
+	if done {
+		return lastKey, nil
+	}
+
+	start, err := data.PriorityCursorTopic(s.topic, -1)
+	if err != nil {
+		return nil, err
+	}
+	_, err = s.catchUpPriority(txn, prefix, start, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return lastKey, nil
+}
+
+// catchUpDefaultPriority sends the queued events of the sharedChannel's topic with the default
+// priority, in EventKey order. It returns the last key it read, and true if the sharedChannel is
+// done.
+func (s *sharedChannel) catchUpDefaultPriority(txn storage.Txn) ([]byte, bool, error) {
+	opts := storage.DefaultIteratorOptions
 	opts.PrefetchSize = 100
 
 	it := txn.NewIterator(opts)
@@ -278,7 +479,7 @@ func (s *sharedChannel) catchUp(cursor []byte) ([]byte, error) {
 	var lastKey []byte
 	prefix, err := data.EventPrefixTopic(s.topic)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
 	// TODO: is there any way to not read over all events when we get behind without losing requeued
@@ -311,7 +512,7 @@ func (s *sharedChannel) catchUp(cursor []byte) ([]byte, error) {
 
 		val, err := item.Value()
 		if err != nil {
-			return nil, err
+			return nil, false, err
 		}
 
 		var e data.EventPayload
@@ -320,31 +521,134 @@ func (s *sharedChannel) catchUp(cursor []byte) ([]byte, error) {
 			log.Printf("unmarshal event: %v", err)
 			continue
 		}
+		if e.Priority != 0 {
+			// Sent by catchUpPriority. The priority isn't encrypted, so the payload is only decoded
+			// for events that are sent here.
+			continue
+		}
+		err = decodeEventPayload(&e, lastKey, s.keys)
+		if err != nil {
+			log.Printf("decode event: %v", err)
+			continue
+		}
 
-		select {
-		case <-s.done:
-			return lastKey, nil
-		case s.out <- &Event{
-			ID:           key.ID,
-			Topic:        key.Topic,
-			CreateTime:   key.CreateTime,
-			Payload:      e.Payload,
-			RequeueCount: int(channel.RequeueCount),
-			State:        protoToEventState(channel.EventState),
-			DefaultState: protoToEventState(e.DefaultEventState),
-			Indexes:      e.Indexes,
-		}:
+		if !s.sendFromDisk(key, &channel, &e) {
+			return lastKey, true, nil
 		}
 	}
 
-	return lastKey, nil
+	return lastKey, false, nil
+}
+
+// catchUpPriority sends the queued events indexed by the PriorityKeys with prefix, starting at
+// start. If positive is true, it stops at the first event without a positive priority. It returns
+// true if the sharedChannel is done.
+func (s *sharedChannel) catchUpPriority(txn storage.Txn, prefix, start []byte, positive bool) (bool, error) {
+	opts := storage.DefaultIteratorOptions
+	opts.PrefetchValues = false
+
+	it := txn.NewIterator(opts)
+	defer it.Close()
+
+	for it.Seek(start); it.ValidForPrefix(prefix); it.Next() {
+		var key data.PriorityKey
+		err := data.UnmarshalTo(it.Item().Key(), &key)
+		if err != nil {
+			log.Printf("parse priority key %s: %v", it.Item().Key(), err)
+			continue
+		}
+		if positive && key.Priority <= 0 {
+			break
+		}
+
+		channel, err := getChannelEvent(txn, data.ChannelKey{
+			Channel: s.name,
+			Topic:   key.Topic,
+			ID:      key.ID,
+		})
+		if err != nil {
+			log.Printf("get channel event: %v", err)
+			continue
+		}
+		if channel.EventState != data.EventState_QUEUED {
+			// Not queued, don't send
+			continue
+		}
+
+		eventKey := data.EventKey{
+			Topic:      key.Topic,
+			CreateTime: key.CreateTime,
+			ID:         key.ID,
+		}
+		rawKey, err := eventKey.Marshal(nil)
+		if err != nil {
+			log.Printf("marshal event key: %v", err)
+			continue
+		}
+		item, err := txn.Get(rawKey)
+		if err == storage.ErrKeyNotFound {
+			// The event was deleted along with its priority key after it was read.
+			continue
+		}
+		if err != nil {
+			return false, err
+		}
+		val, err := item.Value()
+		if err != nil {
+			return false, err
+		}
+
+		var e data.EventPayload
+		err = unmarshalEventPayload(val, rawKey, s.keys, &e)
+		if err != nil {
+			log.Printf("unmarshal event: %v", err)
+			continue
+		}
+
+		if !s.sendFromDisk(eventKey, &channel, &e) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// sendFromDisk sends the event read from disk with key, channel and e to the sharedChannel's
+// subscribers, unless it is scheduled. It returns false if the sharedChannel is done.
+func (s *sharedChannel) sendFromDisk(key data.EventKey, channel *data.ChannelPayload, e *data.EventPayload) bool {
+	deliverAt := data.FromUnixNano(e.DeliverAt)
+	if deliverAt.After(time.Now()) {
+		// Scheduled, send it once it is due.
+		s.scheduleWakeUp(deliverAt)
+		return true
+	}
+
+	select {
+	case <-s.done:
+		return false
+	case s.out <- &Event{
+		ID:           key.ID,
+		Topic:        key.Topic,
+		CreateTime:   key.CreateTime,
+		Payload:      e.Payload,
+		RequeueCount: int(channel.RequeueCount),
+		State:        protoToEventState(channel.EventState),
+		DefaultState: protoToEventState(e.DefaultEventState),
+		Indexes:      e.Indexes,
+		Metadata:     e.Metadata,
+		RequeueLimit: int(e.RequeueLimit),
+		DeliverAt:    deliverAt,
+		Priority:     int(e.Priority),
+	}:
+		return true
+	}
 }
 
 func (s *sharedChannel) getCursor(topic string) ([]byte, error) {
 	// txn := s.db.NewTransaction(false)
 	// defer txn.Discard()
 	//
-	// opts := badger.DefaultIteratorOptions
+	// opts := storage.DefaultIteratorOptions
 	// iter := txn.NewIterator(opts)
 	// defer iter.Close()
 
@@ -361,7 +665,7 @@ func (s *sharedChannel) getCursor(topic string) ([]byte, error) {
 	// 		continue
 	// 	}
 	// 	_, err = txn.Get(current)
-	// 	if err == badger.ErrKeyNotFound {
+	// 	if err == storage.ErrKeyNotFound {
 	//
 	// 	}
 	// }
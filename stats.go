@@ -0,0 +1,501 @@
+package deq
+
+import (
+	"bytes"
+	"fmt"
+	"math/bits"
+	"sync/atomic"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"gitlab.com/katcheCode/deq/internal/data"
+	"gitlab.com/katcheCode/deq/internal/storage"
+)
+
+// Stats are statistics about the events of a store.
+type Stats struct {
+	// Events is the number of events in the store.
+	Events int
+	// PayloadBytes is the total size of the payloads of every event in the store.
+	PayloadBytes int64
+	// Topics holds the stats of every topic with at least one event.
+	Topics map[string]TopicStats
+
+	// Requeues is the number of times an event has been requeued on any channel since the store was
+	// opened.
+	Requeues int64
+	// RequeueLimitExhausted is the number of times an event has been dequeued with
+	// EventStateDequeuedError instead of requeued because it reached the requeue limit, since the
+	// store was opened.
+	RequeueLimitExhausted int64
+	// Commits is the number of transactions committed by the store's writer since the store was
+	// opened. Concurrent writes are grouped into a single transaction when possible.
+	Commits int64
+
+	// LSMSize and VLogSize are the on-disk sizes in bytes of the store's LSM tree and value log, or
+	// zero for in-memory stores.
+	LSMSize  int64
+	VLogSize int64
+}
+
+// counters count occurences of events on a store since it was opened.
+type counters struct {
+	requeues              int64
+	requeueLimitExhausted int64
+	commits               int64
+}
+
+// TopicStats are statistics about the events of a topic.
+type TopicStats struct {
+	// Events is the number of events on the topic.
+	Events int
+	// PayloadBytes is the total size of the payloads of the topic's events.
+	PayloadBytes int64
+}
+
+// ChannelStats are statistics about the events of a topic on a channel.
+type ChannelStats struct {
+	// Events is the number of events on the channel's topic.
+	Events int
+	// PayloadBytes is the total size of the payloads of the topic's events.
+	PayloadBytes int64
+
+	// Queued, DequeuedOK and DequeuedError are the number of events in each state on the channel.
+	Queued        int
+	DequeuedOK    int
+	DequeuedError int
+
+	// OldestQueuedAge is the time since the creation of the oldest queued event on the channel, or zero
+	// if there are no queued events.
+	OldestQueuedAge time.Duration
+
+	// RequeueHistogram counts the events of the topic by how many times they have been requeued on the
+	// channel. RequeueHistogram[0] is the number of events that have never been requeued, and
+	// RequeueHistogram[i] is the number of events that have been requeued at least 2^(i-1) times and
+	// fewer than 2^i times.
+	RequeueHistogram []int
+}
+
+// Stats returns statistics about the events of the store.
+//
+// Stats are maintained as events are published and deleted, so Stats is cheap to call regardless of
+// the number of events in the store.
+func (s *Store) Stats() (Stats, error) {
+
+	txn := s.db.NewTransaction(false)
+	defer txn.Discard()
+
+	stats := Stats{
+		Topics:                make(map[string]TopicStats),
+		Requeues:              atomic.LoadInt64(&s.counters.requeues),
+		RequeueLimitExhausted: atomic.LoadInt64(&s.counters.requeueLimitExhausted),
+		Commits:               atomic.LoadInt64(&s.counters.commits),
+	}
+	stats.LSMSize, stats.VLogSize = s.db.Size()
+
+	it := txn.NewIterator(storage.DefaultIteratorOptions)
+	defer it.Close()
+
+	for it.Seek(data.TopicStatsPrefix); it.ValidForPrefix(data.TopicStatsPrefix); it.Next() {
+		item := it.Item()
+
+		var key data.TopicStatsKey
+		err := data.UnmarshalTopicStatsKey(item.Key(), &key)
+		if err != nil {
+			return Stats{}, fmt.Errorf("parse topic stats key %s: %v", item.Key(), err)
+		}
+
+		val, err := item.Value()
+		if err != nil {
+			return Stats{}, err
+		}
+		var payload data.TopicStatsPayload
+		err = proto.Unmarshal(val, &payload)
+		if err != nil {
+			return Stats{}, fmt.Errorf("unmarshal topic stats payload: %v", err)
+		}
+
+		stats.Topics[key.Topic] = TopicStats{
+			Events:       int(payload.EventCount),
+			PayloadBytes: payload.PayloadBytes,
+		}
+		stats.Events += int(payload.EventCount)
+		stats.PayloadBytes += payload.PayloadBytes
+	}
+
+	return stats, nil
+}
+
+// Stats returns statistics about the events of c's topic on c.
+//
+// The stats are maintained as the state of events change, including a cursor at the oldest queued
+// event, so Stats is cheap to call regardless of the number of events on the topic.
+func (c *Channel) Stats() (ChannelStats, error) {
+
+	txn := c.db.NewTransaction(false)
+	defer txn.Discard()
+
+	var topicStats data.TopicStatsPayload
+	err := getStats(txn, data.TopicStatsKey{Topic: c.topic}, &topicStats)
+	if err != nil {
+		return ChannelStats{}, fmt.Errorf("get topic stats: %v", err)
+	}
+	var channelStats data.ChannelStatsPayload
+	err = getStats(txn, data.ChannelStatsKey{Channel: c.name, Topic: c.topic}, &channelStats)
+	if err != nil {
+		return ChannelStats{}, fmt.Errorf("get channel stats: %v", err)
+	}
+
+	saved := channelStats.QueuedCount + channelStats.DequeuedOkCount + channelStats.DequeuedErrorCount
+
+	stats := ChannelStats{
+		Events:        int(topicStats.EventCount),
+		PayloadBytes:  topicStats.PayloadBytes,
+		DequeuedOK:    int(channelStats.DequeuedOkCount),
+		DequeuedError: int(channelStats.DequeuedErrorCount),
+	}
+	// Events without any saved state on the channel are queued, and have never been requeued.
+	stats.Queued = stats.Events - stats.DequeuedOK - stats.DequeuedError
+	if stats.Queued < 0 {
+		stats.Queued = 0
+	}
+	if unsaved := int(topicStats.EventCount - saved); unsaved > 0 || len(channelStats.RequeueHistogram) > 0 {
+		stats.RequeueHistogram = make([]int, len(channelStats.RequeueHistogram))
+		for i, count := range channelStats.RequeueHistogram {
+			stats.RequeueHistogram[i] = int(count)
+		}
+		if unsaved > 0 {
+			if len(stats.RequeueHistogram) == 0 {
+				stats.RequeueHistogram = []int{0}
+			}
+			stats.RequeueHistogram[0] += unsaved
+		}
+	}
+
+	if stats.Queued > 0 {
+		prefix, err := data.EventPrefixTopic(c.topic)
+		if err != nil {
+			return ChannelStats{}, err
+		}
+		// The cursor is usually at the oldest queued event, so this only checks the events it hasn't
+		// passed yet.
+		cursor := queuedCursor(prefix, &topicStats, &channelStats)
+		_, oldest, found, err := findQueued(txn, c.name, c.topic, prefix, cursor, 0)
+		if err != nil {
+			return ChannelStats{}, fmt.Errorf("find oldest queued event: %v", err)
+		}
+		if found {
+			stats.OldestQueuedAge = time.Since(oldest)
+		}
+	}
+
+	return stats, nil
+}
+
+// Backlog returns the number of events queued on c. Unlike Stats, Backlog only reads the counters
+// of c and its topic.
+func (c *Channel) Backlog() (int, error) {
+
+	txn := c.db.NewTransaction(false)
+	defer txn.Discard()
+
+	var topicStats data.TopicStatsPayload
+	err := getStats(txn, data.TopicStatsKey{Topic: c.topic}, &topicStats)
+	if err != nil {
+		return 0, fmt.Errorf("get topic stats: %v", err)
+	}
+	var channelStats data.ChannelStatsPayload
+	err = getStats(txn, data.ChannelStatsKey{Channel: c.name, Topic: c.topic}, &channelStats)
+	if err != nil {
+		return 0, fmt.Errorf("get channel stats: %v", err)
+	}
+
+	queued := topicStats.EventCount - channelStats.DequeuedOkCount - channelStats.DequeuedErrorCount
+	if queued < 0 {
+		return 0, nil
+	}
+	return int(queued), nil
+}
+
+// queuedCursor returns an EventKey at or before the oldest queued event of a channel, given the
+// stats of the channel and its topic. prefix is the EventKey prefix of the topic.
+func queuedCursor(prefix []byte, topicStats *data.TopicStatsPayload, channelStats *data.ChannelStatsPayload) []byte {
+	cursor := channelStats.QueuedCursor
+	if len(cursor) == 0 {
+		cursor = prefix
+	}
+	// Events published since the cursor was saved may be before it.
+	if channelStats.BackdatedSeq != topicStats.BackdatedSeq && len(topicStats.BackdatedKey) > 0 &&
+		bytes.Compare(topicStats.BackdatedKey, cursor) < 0 {
+		cursor = topicStats.BackdatedKey
+	}
+	return cursor
+}
+
+// findQueued finds the first event of topic at or after cursor that is queued on channel, checking
+// at most limit events, or every event if limit is zero. It returns the key of the queued event and
+// its create time if one is found. Otherwise it returns a key to continue from, which is after every
+// event checked.
+func findQueued(txn storage.Txn, channel, topic string, prefix, cursor []byte, limit int) ([]byte, time.Time, bool, error) {
+
+	it := txn.NewIterator(storage.IteratorOptions{})
+	defer it.Close()
+
+	next := cursor
+	checked := 0
+	for it.Seek(cursor); it.ValidForPrefix(prefix); it.Next() {
+		item := it.Item()
+		if limit > 0 && checked >= limit {
+			return item.KeyCopy(nil), time.Time{}, false, nil
+		}
+		checked++
+
+		var key data.EventKey
+		err := data.UnmarshalTo(item.Key(), &key)
+		if err != nil {
+			return nil, time.Time{}, false, fmt.Errorf("parse event key %s: %v", item.Key(), err)
+		}
+
+		channelEvent, err := getChannelEvent(txn, data.ChannelKey{
+			Channel: channel,
+			Topic:   topic,
+			ID:      key.ID,
+		})
+		if err != nil {
+			return nil, time.Time{}, false, err
+		}
+		if isQueuedState(channelEvent.EventState) {
+			return item.KeyCopy(nil), key.CreateTime, true, nil
+		}
+
+		// Just after this event.
+		next = append(item.KeyCopy(nil), 0)
+	}
+
+	return next, time.Time{}, false, nil
+}
+
+func isQueuedState(state data.EventState) bool {
+	return state == data.EventState_QUEUED || state == data.EventState_UNSPECIFIED_STATE
+}
+
+// requeueBucket returns the index of the RequeueHistogram bucket for an event that has been requeued
+// requeueCount times.
+func requeueBucket(requeueCount int32) int {
+	if requeueCount <= 0 {
+		return 0
+	}
+	return bits.Len32(uint32(requeueCount))
+}
+
+// addTopicEventStats adds an event published with key to the saved stats of its topic. rawKey is
+// the marshalled key.
+func addTopicEventStats(txn storage.Txn, key data.EventKey, rawKey []byte, payloadBytes int64) error {
+
+	statsKey := data.TopicStatsKey{Topic: key.Topic}
+
+	var payload data.TopicStatsPayload
+	err := getStats(txn, statsKey, &payload)
+	if err != nil {
+		return err
+	}
+
+	createTime := key.CreateTime.UnixNano()
+	if payload.LastCreateTime == 0 || createTime <= payload.LastCreateTime {
+		// The queued cursors of the topic's channels may already be past the event.
+		if len(payload.BackdatedKey) == 0 || bytes.Compare(rawKey, payload.BackdatedKey) < 0 {
+			payload.BackdatedKey = rawKey
+		}
+		// The sequence must not repeat if the stats are deleted with the topic's last event, so it is
+		// based on the current time.
+		seq := time.Now().UnixNano()
+		if seq <= payload.BackdatedSeq {
+			seq = payload.BackdatedSeq + 1
+		}
+		payload.BackdatedSeq = seq
+	}
+	if createTime > payload.LastCreateTime {
+		payload.LastCreateTime = createTime
+	}
+	payload.EventCount++
+	payload.PayloadBytes += payloadBytes
+
+	return setStats(txn, statsKey, &payload, false)
+}
+
+// updateTopicStats adds events and payloadBytes to the saved stats of topic.
+func updateTopicStats(txn storage.Txn, topic string, events, payloadBytes int64) error {
+
+	key := data.TopicStatsKey{Topic: topic}
+
+	var payload data.TopicStatsPayload
+	err := getStats(txn, key, &payload)
+	if err != nil {
+		return err
+	}
+
+	payload.EventCount += events
+	payload.PayloadBytes += payloadBytes
+
+	return setStats(txn, key, &payload, payload.EventCount <= 0)
+}
+
+// updateChannelStats updates the saved stats of a channel after the saved state of the event with
+// eventKey changes from old to new. A nil payload means the event has no saved state on the channel,
+// and a nil new payload means the event or channel is being deleted.
+//
+// If the event is dequeued, the channel's queued cursor is moved to its next queued event by
+// iterating txn. If the event is queued again, the cursor is moved back to the event if needed.
+func updateChannelStats(txn storage.Txn, eventKey data.ChannelKey, old, new *data.ChannelPayload) error {
+
+	channel, topic := eventKey.Channel, eventKey.Topic
+	key := data.ChannelStatsKey{
+		Channel: channel,
+		Topic:   topic,
+	}
+
+	var payload data.ChannelStatsPayload
+	err := getStats(txn, key, &payload)
+	if err != nil {
+		return err
+	}
+
+	if old != nil {
+		addChannelStats(&payload, old, -1)
+	}
+	if new != nil {
+		addChannelStats(&payload, new, 1)
+	}
+
+	// Drop empty buckets from the end of the histogram.
+	hist := payload.RequeueHistogram
+	for len(hist) > 0 && hist[len(hist)-1] <= 0 {
+		hist = hist[:len(hist)-1]
+	}
+	payload.RequeueHistogram = hist
+
+	wasQueued := old == nil || isQueuedState(old.EventState)
+	if new != nil && wasQueued != isQueuedState(new.EventState) {
+		err := updateQueuedCursor(txn, eventKey, &payload, !wasQueued)
+		if err != nil {
+			return fmt.Errorf("update queued cursor: %v", err)
+		}
+	}
+
+	empty := payload.QueuedCount <= 0 && payload.DequeuedOkCount <= 0 && payload.DequeuedErrorCount <= 0
+
+	return setStats(txn, key, &payload, empty)
+}
+
+// maxQueuedCursorAdvance is the maximum number of events checked when advancing a queued cursor in
+// a single transaction. If there are more, the cursor is left before the oldest queued event, and
+// the rest are checked by Channel.Stats and later state changes.
+const maxQueuedCursorAdvance = 100
+
+// updateQueuedCursor updates the queued cursor saved in payload after the event with key is queued
+// or dequeued on its channel.
+func updateQueuedCursor(txn storage.Txn, key data.ChannelKey, payload *data.ChannelStatsPayload, queued bool) error {
+
+	var topicStats data.TopicStatsPayload
+	err := getStats(txn, data.TopicStatsKey{Topic: key.Topic}, &topicStats)
+	if err != nil {
+		return err
+	}
+	prefix, err := data.EventPrefixTopic(key.Topic)
+	if err != nil {
+		return err
+	}
+	cursor := queuedCursor(prefix, &topicStats, payload)
+
+	if queued {
+		eventTime, err := getEventTimePayload(txn, data.EventTimeKey{
+			Topic: key.Topic,
+			ID:    key.ID,
+		})
+		if err == ErrNotFound {
+			// Events that don't exist aren't counted as queued.
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("get event time: %v", err)
+		}
+		rawKey, err := data.EventKey{
+			Topic:      key.Topic,
+			CreateTime: time.Unix(0, eventTime.CreateTime),
+			ID:         key.ID,
+		}.Marshal(nil)
+		if err != nil {
+			return err
+		}
+		if bytes.Compare(rawKey, cursor) < 0 {
+			cursor = rawKey
+		}
+	} else {
+		cursor, _, _, err = findQueued(txn, key.Channel, key.Topic, prefix, cursor, maxQueuedCursorAdvance)
+		if err != nil {
+			return err
+		}
+	}
+
+	payload.QueuedCursor = cursor
+	payload.BackdatedSeq = topicStats.BackdatedSeq
+	return nil
+}
+
+func addChannelStats(stats *data.ChannelStatsPayload, channelEvent *data.ChannelPayload, delta int64) {
+	switch channelEvent.EventState {
+	case data.EventState_DEQUEUED_OK:
+		stats.DequeuedOkCount += delta
+	case data.EventState_DEQUEUED_ERROR:
+		stats.DequeuedErrorCount += delta
+	default:
+		stats.QueuedCount += delta
+	}
+
+	bucket := requeueBucket(channelEvent.RequeueCount)
+	for len(stats.RequeueHistogram) <= bucket {
+		stats.RequeueHistogram = append(stats.RequeueHistogram, 0)
+	}
+	stats.RequeueHistogram[bucket] += delta
+}
+
+// getStats reads the stats saved at key into payload. If there are no saved stats, payload is left
+// unmodified.
+func getStats(txn storage.Txn, key data.Key, payload proto.Message) error {
+	rawkey, err := key.Marshal(nil)
+	if err != nil {
+		return fmt.Errorf("marshal stats key: %v", err)
+	}
+	item, err := txn.Get(rawkey)
+	if err == storage.ErrKeyNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	val, err := item.Value()
+	if err != nil {
+		return err
+	}
+	err = proto.Unmarshal(val, payload)
+	if err != nil {
+		return fmt.Errorf("unmarshal stats payload: %v", err)
+	}
+	return nil
+}
+
+// setStats saves payload at key, or deletes key if empty is true.
+func setStats(txn storage.Txn, key data.Key, payload proto.Message, empty bool) error {
+	rawkey, err := key.Marshal(nil)
+	if err != nil {
+		return fmt.Errorf("marshal stats key: %v", err)
+	}
+	if empty {
+		return txn.Delete(rawkey)
+	}
+	buf, err := proto.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal stats payload: %v", err)
+	}
+	return txn.Set(rawkey, buf)
+}
@@ -0,0 +1,312 @@
+package deq
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"gitlab.com/katcheCode/deq/internal/data"
+	"gitlab.com/katcheCode/deq/internal/storage"
+)
+
+func TestStats(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	db, discard := newTestDB()
+	defer discard()
+
+	now := time.Now()
+
+	_, err := db.PubBatch(ctx, []Event{
+		{
+			ID:         "event1",
+			Topic:      "topic",
+			CreateTime: now.Add(-2 * time.Hour),
+			Payload:    []byte("hello"),
+		},
+		{
+			ID:         "event2",
+			Topic:      "topic",
+			CreateTime: now.Add(-time.Hour),
+			Payload:    []byte("abc"),
+		},
+		{
+			ID:      "other",
+			Topic:   "other-topic",
+			Payload: []byte("1"),
+		},
+	})
+	if err != nil {
+		t.Fatalf("pub: %v", err)
+	}
+
+	channel := db.Channel("channel", "topic")
+	defer channel.Close()
+
+	err = channel.SetEventState("event1", EventStateDequeuedError)
+	if err != nil {
+		t.Fatalf("set event state: %v", err)
+	}
+
+	// The channel exists now, so the default state is saved on it.
+	_, err = db.Pub(ctx, Event{
+		ID:           "event3",
+		Topic:        "topic",
+		DefaultState: EventStateDequeuedOK,
+	})
+	if err != nil {
+		t.Fatalf("pub event3: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		err = channel.RequeueEvent(Event{ID: "event2", Topic: "topic"}, 0)
+		if err != nil {
+			t.Fatalf("requeue: %v", err)
+		}
+	}
+
+	stats, err := db.Stats()
+	if err != nil {
+		t.Fatalf("store stats: %v", err)
+	}
+	expected := Stats{
+		Events:       4,
+		PayloadBytes: 9,
+		Topics: map[string]TopicStats{
+			"topic":       {Events: 3, PayloadBytes: 8},
+			"other-topic": {Events: 1, PayloadBytes: 1},
+		},
+		Requeues: 2,
+	}
+	// Commits depends on how the writes were grouped.
+	ignoreCommits := cmpopts.IgnoreFields(Stats{}, "Commits")
+	if !cmp.Equal(expected, stats, ignoreCommits) {
+		t.Errorf("store stats:\n%s", cmp.Diff(expected, stats, ignoreCommits))
+	}
+
+	channelStats, err := channel.Stats()
+	if err != nil {
+		t.Fatalf("channel stats: %v", err)
+	}
+	if channelStats.OldestQueuedAge < time.Hour || channelStats.OldestQueuedAge > 2*time.Hour {
+		t.Errorf("expected oldest queued age of about an hour, got %v", channelStats.OldestQueuedAge)
+	}
+	expectedChannel := ChannelStats{
+		Events:           3,
+		PayloadBytes:     8,
+		Queued:           1,
+		DequeuedOK:       1,
+		DequeuedError:    1,
+		RequeueHistogram: []int{2, 0, 1},
+	}
+	if !cmp.Equal(expectedChannel, channelStats, cmpopts.IgnoreFields(ChannelStats{}, "OldestQueuedAge")) {
+		t.Errorf("channel stats:\n%s", cmp.Diff(expectedChannel, channelStats, cmpopts.IgnoreFields(ChannelStats{}, "OldestQueuedAge")))
+	}
+	backlog, err := channel.Backlog()
+	if err != nil {
+		t.Fatalf("backlog: %v", err)
+	}
+	if backlog != 1 {
+		t.Errorf("expected backlog of 1, got %d", backlog)
+	}
+
+	// The cursor at the oldest queued event is saved with the channel's stats.
+	txn := db.db.NewTransaction(false)
+	var saved data.ChannelStatsPayload
+	err = getStats(txn, data.ChannelStatsKey{Channel: "channel", Topic: "topic"}, &saved)
+	txn.Discard()
+	if err != nil {
+		t.Fatalf("get channel stats: %v", err)
+	}
+	expectedCursor, err := data.EventKey{Topic: "topic", CreateTime: now.Add(-time.Hour), ID: "event2"}.Marshal(nil)
+	if err != nil {
+		t.Fatalf("marshal event key: %v", err)
+	}
+	if !cmp.Equal(expectedCursor, saved.QueuedCursor) {
+		t.Errorf("expected queued cursor at event2, got %q", saved.QueuedCursor)
+	}
+
+	// Events published or requeued before the cursor are found.
+	_, err = db.Pub(ctx, Event{ID: "event0", Topic: "topic", CreateTime: now.Add(-3 * time.Hour)})
+	if err != nil {
+		t.Fatalf("pub event0: %v", err)
+	}
+	channelStats, err = channel.Stats()
+	if err != nil {
+		t.Fatalf("channel stats: %v", err)
+	}
+	if channelStats.OldestQueuedAge < 3*time.Hour || channelStats.OldestQueuedAge > 4*time.Hour {
+		t.Errorf("expected oldest queued age of about three hours after backdated pub, got %v", channelStats.OldestQueuedAge)
+	}
+	err = channel.SetEventState("event0", EventStateDequeuedOK)
+	if err != nil {
+		t.Fatalf("set event state: %v", err)
+	}
+	err = channel.SetEventState("event1", EventStateQueued)
+	if err != nil {
+		t.Fatalf("set event state: %v", err)
+	}
+	channelStats, err = channel.Stats()
+	if err != nil {
+		t.Fatalf("channel stats: %v", err)
+	}
+	if channelStats.OldestQueuedAge < 2*time.Hour || channelStats.OldestQueuedAge > 3*time.Hour {
+		t.Errorf("expected oldest queued age of about two hours after requeue, got %v", channelStats.OldestQueuedAge)
+	}
+	err = db.Del("topic", "event0")
+	if err != nil {
+		t.Fatalf("del: %v", err)
+	}
+
+	err = db.Del("topic", "event1")
+	if err != nil {
+		t.Fatalf("del: %v", err)
+	}
+	err = db.DeleteChannel("channel", "topic")
+	if err != nil {
+		t.Fatalf("delete channel: %v", err)
+	}
+
+	channelStats, err = channel.Stats()
+	if err != nil {
+		t.Fatalf("channel stats after delete: %v", err)
+	}
+	expectedChannel = ChannelStats{
+		Events:           2,
+		PayloadBytes:     3,
+		Queued:           2,
+		RequeueHistogram: []int{2},
+	}
+	if !cmp.Equal(expectedChannel, channelStats, cmpopts.IgnoreFields(ChannelStats{}, "OldestQueuedAge")) {
+		t.Errorf("channel stats after delete:\n%s", cmp.Diff(expectedChannel, channelStats, cmpopts.IgnoreFields(ChannelStats{}, "OldestQueuedAge")))
+	}
+}
+
+func TestUpgradeStats(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	dir, err := ioutil.TempDir("", "test-upgrade-stats")
+	if err != nil {
+		t.Fatalf("create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	db, err := Open(Options{
+		Dir: dir,
+	})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+
+	for _, id := range []string{"event1", "event2"} {
+		_, err := db.Pub(ctx, Event{
+			ID:      id,
+			Topic:   "topic",
+			Payload: []byte(id),
+		})
+		if err != nil {
+			t.Fatalf("pub %s: %v", id, err)
+		}
+	}
+	// Enough topics that their stats are written in more than one batch.
+	var events []Event
+	topics := []string{"topic"}
+	for i := 0; i < upgradeStatsBatchSize; i++ {
+		topic := fmt.Sprintf("topic-%d", i)
+		topics = append(topics, topic)
+		events = append(events, Event{ID: "event", Topic: topic})
+	}
+	_, err = db.PubBatch(ctx, events)
+	if err != nil {
+		t.Fatalf("pub batch: %v", err)
+	}
+	channel := db.Channel("channel", "topic")
+	err = channel.SetEventState("event1", EventStateDequeuedOK)
+	if err != nil {
+		t.Fatalf("set event state: %v", err)
+	}
+
+	expected, err := db.Stats()
+	if err != nil {
+		t.Fatalf("stats: %v", err)
+	}
+	expectedChannel, err := channel.Stats()
+	if err != nil {
+		t.Fatalf("channel stats: %v", err)
+	}
+	channel.Close()
+	db.Close()
+
+	// Roll the db back to v1.1.0, which didn't save stats.
+	opts := badger.DefaultOptions
+	opts.Dir = dir
+	opts.ValueDir = dir
+	raw, err := storage.OpenBadger(opts)
+	if err != nil {
+		t.Fatalf("open badger: %v", err)
+	}
+	txn := raw.NewTransaction(true)
+	keys := []data.Key{data.ChannelStatsKey{Channel: "channel", Topic: "topic"}}
+	for _, topic := range topics {
+		keys = append(keys, data.TopicStatsKey{Topic: topic})
+	}
+	for _, key := range keys {
+		rawkey, err := key.Marshal(nil)
+		if err != nil {
+			t.Fatalf("marshal stats key: %v", err)
+		}
+		err = txn.Delete(rawkey)
+		if err != nil {
+			t.Fatalf("delete stats: %v", err)
+		}
+	}
+	err = txn.Set([]byte(dbVersionKey), []byte("1.1.0"))
+	if err != nil {
+		t.Fatalf("set version: %v", err)
+	}
+	err = txn.Commit()
+	if err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+	txn.Discard()
+	raw.Close()
+
+	db, err = Open(Options{
+		Dir:             dir,
+		UpgradeIfNeeded: true,
+	})
+	if err != nil {
+		t.Fatalf("open db for upgrade: %v", err)
+	}
+	defer db.Close()
+
+	channel = db.Channel("channel", "topic")
+	defer channel.Close()
+
+	actual, err := db.Stats()
+	if err != nil {
+		t.Fatalf("stats after upgrade: %v", err)
+	}
+	// The size of the db and the counters since it was opened aren't part of the saved stats.
+	ignoreUnsaved := cmpopts.IgnoreFields(Stats{}, "Requeues", "RequeueLimitExhausted", "Commits", "LSMSize", "VLogSize")
+	if !cmp.Equal(expected, actual, ignoreUnsaved) {
+		t.Errorf("stats after upgrade:\n%s", cmp.Diff(expected, actual, ignoreUnsaved))
+	}
+	actualChannel, err := channel.Stats()
+	if err != nil {
+		t.Fatalf("channel stats after upgrade: %v", err)
+	}
+	if !cmp.Equal(expectedChannel, actualChannel, cmpopts.IgnoreFields(ChannelStats{}, "OldestQueuedAge")) {
+		t.Errorf("channel stats after upgrade:\n%s", cmp.Diff(expectedChannel, actualChannel, cmpopts.IgnoreFields(ChannelStats{}, "OldestQueuedAge")))
+	}
+}
@@ -76,10 +76,14 @@ func (c *Channel) SyncTo(ctx context.Context, client Client) error {
 func syncWorker(ctx context.Context, client Client, queue <-chan Event) error {
 	for e := range queue {
 		_, err := client.Pub(ctx, Event{
-			ID:         e.ID,
-			CreateTime: e.CreateTime,
-			Topic:      e.Topic,
-			Payload:    e.Payload,
+			ID:           e.ID,
+			CreateTime:   e.CreateTime,
+			Topic:        e.Topic,
+			Payload:      e.Payload,
+			Metadata:     e.Metadata,
+			RequeueLimit: e.RequeueLimit,
+			DeliverAt:    e.DeliverAt,
+			Priority:     e.Priority,
 		})
 		if err != nil {
 			return err
@@ -35,6 +35,7 @@ func TestSyncTo(t *testing.T) {
 				ID:         "before-event2",
 				Topic:      "TopicA",
 				CreateTime: createTime,
+				Metadata:   map[string]string{"tenant": "tenant1"},
 			},
 			{
 				ID:         "before-event1",
@@ -85,6 +86,7 @@ func TestSyncTo(t *testing.T) {
 				ID:           "before-event2",
 				Topic:        "TopicA",
 				CreateTime:   createTime,
+				Metadata:     map[string]string{"tenant": "tenant1"},
 				DefaultState: EventStateQueued,
 				State:        EventStateQueued,
 			},
@@ -0,0 +1,240 @@
+package deq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"gitlab.com/katcheCode/deq/internal/data"
+	"gitlab.com/katcheCode/deq/internal/storage"
+)
+
+// Backoff selects one of the BackoffFuncs provided by this package.
+type Backoff int
+
+const (
+	// BackoffDefault uses the default backoff, which is BackoffExponential.
+	BackoffDefault Backoff = iota
+	// BackoffExponential selects ExponentialBackoff.
+	BackoffExponential
+	// BackoffLinear selects LinearBackoff.
+	BackoffLinear
+	// BackoffConstant selects ConstantBackoff.
+	BackoffConstant
+)
+
+func (b Backoff) String() string {
+	switch b {
+	case BackoffDefault:
+		return "Default"
+	case BackoffExponential:
+		return "Exponential"
+	case BackoffLinear:
+		return "Linear"
+	case BackoffConstant:
+		return "Constant"
+	default:
+		return fmt.Sprintf("Backoff(%d)", int(b))
+	}
+}
+
+// TopicConfig is the configuration of a topic, which is persisted in the store. The zero value of
+// each field uses the store's default.
+type TopicConfig struct {
+	// RequeueLimit is the number of times an event of the topic is requeued on a channel before it
+	// is dequeued with EventStateDequeuedError. Zero uses the store's DefaultRequeueLimit, and -1
+	// removes the limit.
+	RequeueLimit int
+	// Backoff selects the BackoffFunc that the topic's channels start with.
+	Backoff Backoff
+	// RequeueDelay is the base delay of Backoff. Zero uses the default delay of the channel.
+	RequeueDelay time.Duration
+	// DefaultState is the DefaultState of new events on the topic that don't specify one. Defaults
+	// to EventStateQueued.
+	DefaultState EventState
+	// Retention is the RetentionPolicy of the topic. If it is the zero value, the topic's policy
+	// from Options is used.
+	Retention RetentionPolicy
+	// DeadLetterTopic is the topic that the dead letters of the topic's events are published to when
+	// they reach their requeue limit on a channel. If it is empty, events that reach their requeue
+	// limit are only dequeued with EventStateDequeuedError. See Channel.DeadLetterTopic for details.
+	DeadLetterTopic string
+}
+
+// BackoffFunc returns the BackoffFunc selected by c. defaultDelay is used as the base delay if
+// c.RequeueDelay is zero.
+func (c TopicConfig) BackoffFunc(defaultDelay time.Duration) BackoffFunc {
+	delay := c.RequeueDelay
+	if delay == 0 {
+		delay = defaultDelay
+	}
+
+	switch c.Backoff {
+	case BackoffLinear:
+		return LinearBackoff(delay)
+	case BackoffConstant:
+		return ConstantBackoff(delay)
+	default:
+		return ExponentialBackoff(delay)
+	}
+}
+
+func (c TopicConfig) validate() error {
+	if c.RequeueLimit < -1 {
+		return errors.New("RequeueLimit must be -1 or greater")
+	}
+	if c.Backoff < BackoffDefault || c.Backoff > BackoffConstant {
+		return fmt.Errorf("unrecognized Backoff %v", c.Backoff)
+	}
+	if c.RequeueDelay < 0 {
+		return errors.New("RequeueDelay must not be negative")
+	}
+	if c.DefaultState < EventStateUnspecified || c.DefaultState > EventStateDequeuedError {
+		return fmt.Errorf("unrecognized DefaultState %v", c.DefaultState)
+	}
+	if c.Retention.MaxAge < 0 || c.Retention.MaxCount < 0 {
+		return errors.New("Retention must not be negative")
+	}
+	return nil
+}
+
+// TopicConfig returns the configuration of a topic. If the topic's configuration was never set,
+// TopicConfig returns the zero value, which uses the store's defaults.
+func (s *Store) TopicConfig(topic string) TopicConfig {
+	s.topicConfigsMu.RLock()
+	defer s.topicConfigsMu.RUnlock()
+
+	return s.topicConfigs[topic]
+}
+
+// SetTopicConfig sets the configuration of a topic. The configuration is consulted when:
+//   - An event is published on the topic without a DefaultState.
+//   - An event of the topic is requeued on a channel, for its requeue limit.
+//   - A Channel is created for the topic, for its BackoffFunc. Existing channels are not updated.
+//   - Expired events are deleted, for the topic's retention.
+//   - An event of the topic reaches its requeue limit, for its dead letter topic. Channels that
+//     already exist are not updated.
+//
+// Setting the zero value removes the topic's configuration. Deleting a topic with DelTopic does
+// not delete its configuration.
+func (s *Store) SetTopicConfig(topic string, config TopicConfig) error {
+	if !isValidTopic(topic) {
+		return errors.New("topic is not valid")
+	}
+	err := config.validate()
+	if err != nil {
+		return err
+	}
+	if config.DeadLetterTopic != "" && (!isValidTopic(config.DeadLetterTopic) || config.DeadLetterTopic == topic) {
+		return errors.New("DeadLetterTopic must be a valid topic other than the configured topic")
+	}
+
+	key, err := data.TopicConfigKey{Topic: topic}.Marshal(nil)
+	if err != nil {
+		return err
+	}
+
+	s.setTopicConfigMu.Lock()
+	defer s.setTopicConfigMu.Unlock()
+
+	err = s.write(context.Background(), func(txn storage.Txn) error {
+		if config == (TopicConfig{}) {
+			return txn.Delete(key)
+		}
+
+		val, err := proto.Marshal(topicConfigToProto(config))
+		if err != nil {
+			return fmt.Errorf("marshal topic config: %v", err)
+		}
+		return txn.Set(key, val)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.topicConfigsMu.Lock()
+	defer s.topicConfigsMu.Unlock()
+
+	if config == (TopicConfig{}) {
+		delete(s.topicConfigs, topic)
+	} else {
+		s.topicConfigs[topic] = config
+	}
+
+	return nil
+}
+
+// requeueLimit returns the requeue limit of a topic, or -1 if it has no limit.
+func (s *Store) requeueLimit(topic string) int {
+	limit := s.TopicConfig(topic).RequeueLimit
+	if limit == 0 {
+		return s.defaultRequeueLimit
+	}
+	return limit
+}
+
+// loadTopicConfigs loads the configuration of every topic from disk.
+func (s *Store) loadTopicConfigs() error {
+	txn := s.db.NewTransaction(false)
+	defer txn.Discard()
+
+	configs := make(map[string]TopicConfig)
+
+	it := txn.NewIterator(storage.DefaultIteratorOptions)
+	defer it.Close()
+
+	for it.Seek(data.TopicConfigPrefix); it.ValidForPrefix(data.TopicConfigPrefix); it.Next() {
+		item := it.Item()
+
+		var key data.TopicConfigKey
+		err := data.UnmarshalTopicConfigKey(item.Key(), &key)
+		if err != nil {
+			return fmt.Errorf("unmarshal topic config key: %v", err)
+		}
+		val, err := item.Value()
+		if err != nil {
+			return err
+		}
+		var payload data.TopicConfigPayload
+		err = proto.Unmarshal(val, &payload)
+		if err != nil {
+			return fmt.Errorf("unmarshal config of topic %s: %v", key.Topic, err)
+		}
+
+		configs[key.Topic] = protoToTopicConfig(&payload)
+	}
+
+	s.topicConfigsMu.Lock()
+	s.topicConfigs = configs
+	s.topicConfigsMu.Unlock()
+
+	return nil
+}
+
+func topicConfigToProto(c TopicConfig) *data.TopicConfigPayload {
+	return &data.TopicConfigPayload{
+		RequeueLimit:      int32(c.RequeueLimit),
+		Backoff:           data.Backoff(c.Backoff),
+		RequeueDelay:      int64(c.RequeueDelay),
+		DefaultState:      c.DefaultState.toProto(),
+		RetentionMaxAge:   int64(c.Retention.MaxAge),
+		RetentionMaxCount: int64(c.Retention.MaxCount),
+		DeadLetterTopic:   c.DeadLetterTopic,
+	}
+}
+
+func protoToTopicConfig(p *data.TopicConfigPayload) TopicConfig {
+	return TopicConfig{
+		RequeueLimit: int(p.RequeueLimit),
+		Backoff:      Backoff(p.Backoff),
+		RequeueDelay: time.Duration(p.RequeueDelay),
+		DefaultState: protoToEventState(p.DefaultState),
+		Retention: RetentionPolicy{
+			MaxAge:   time.Duration(p.RetentionMaxAge),
+			MaxCount: int(p.RetentionMaxCount),
+		},
+		DeadLetterTopic: p.DeadLetterTopic,
+	}
+}
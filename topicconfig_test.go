@@ -0,0 +1,146 @@
+package deq
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestTopicConfig(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	dir, err := ioutil.TempDir("", "test-topic-config")
+	if err != nil {
+		t.Fatalf("create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	db, err := Open(Options{Dir: dir})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+
+	if config := db.TopicConfig("TopicA"); config != (TopicConfig{}) {
+		t.Errorf("expected zero config for new topic, got %+v", config)
+	}
+
+	expected := TopicConfig{
+		RequeueLimit: 1,
+		Backoff:      BackoffConstant,
+		RequeueDelay: time.Minute,
+		DefaultState: EventStateDequeuedOK,
+		Retention:    RetentionPolicy{MaxCount: 10},
+	}
+	err = db.SetTopicConfig("TopicA", expected)
+	if err != nil {
+		t.Fatalf("set topic config: %v", err)
+	}
+
+	err = db.SetTopicConfig("TopicA", TopicConfig{RequeueLimit: -2})
+	if err == nil {
+		t.Errorf("set invalid topic config: expected error")
+	}
+
+	// New events use the topic's default state.
+	e, err := db.Pub(ctx, Event{ID: "event1", Topic: "TopicA"})
+	if err != nil {
+		t.Fatalf("pub: %v", err)
+	}
+	if e.DefaultState != EventStateDequeuedOK {
+		t.Errorf("expected default state %v, got %v", EventStateDequeuedOK, e.DefaultState)
+	}
+	e, err = db.Pub(ctx, Event{ID: "event2", Topic: "TopicA", DefaultState: EventStateQueued})
+	if err != nil {
+		t.Fatalf("pub: %v", err)
+	}
+	if e.DefaultState != EventStateQueued {
+		t.Errorf("expected explicit default state %v, got %v", EventStateQueued, e.DefaultState)
+	}
+
+	// Channels use the topic's backoff and requeue limit.
+	channel := db.Channel("channel", "TopicA")
+	if delay := channel.backoffFunc(Event{RequeueCount: 5}); delay != time.Minute {
+		t.Errorf("expected constant backoff of one minute, got %v", delay)
+	}
+	for i := 0; i < 2; i++ {
+		err = channel.RequeueEvent(e, 0)
+		if err != nil {
+			t.Fatalf("requeue: %v", err)
+		}
+	}
+	requeued, err := channel.Get("event2")
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if requeued.State != EventStateDequeuedError {
+		t.Errorf("expected %v once the requeue limit is reached, got %v", EventStateDequeuedError, requeued.State)
+	}
+	channel.Close()
+
+	if policy := db.retentionPolicy("TopicA"); policy != expected.Retention {
+		t.Errorf("expected retention %+v, got %+v", expected.Retention, policy)
+	}
+	db.Close()
+
+	// The configuration is persisted.
+	db, err = Open(Options{Dir: dir})
+	if err != nil {
+		t.Fatalf("reopen db: %v", err)
+	}
+	defer db.Close()
+
+	config := db.TopicConfig("TopicA")
+	if !cmp.Equal(expected, config) {
+		t.Errorf("get topic config:\n%s", cmp.Diff(expected, config))
+	}
+
+	// Setting the zero value removes the configuration.
+	err = db.SetTopicConfig("TopicA", TopicConfig{})
+	if err != nil {
+		t.Fatalf("reset topic config: %v", err)
+	}
+	if config := db.TopicConfig("TopicA"); config != (TopicConfig{}) {
+		t.Errorf("expected zero config after reset, got %+v", config)
+	}
+}
+
+func TestTopicConfigConcurrent(t *testing.T) {
+	t.Parallel()
+
+	db, err := Open(Options{InMemory: true})
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer db.Close()
+
+	var wg sync.WaitGroup
+	for i := 1; i <= 20; i++ {
+		wg.Add(1)
+		go func(limit int) {
+			defer wg.Done()
+			err := db.SetTopicConfig("TopicA", TopicConfig{RequeueLimit: limit})
+			if err != nil {
+				t.Errorf("set topic config: %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	// The cached configuration must match the one that was committed last.
+	cached := db.TopicConfig("TopicA")
+	err = db.loadTopicConfigs()
+	if err != nil {
+		t.Fatalf("load topic configs: %v", err)
+	}
+	stored := db.TopicConfig("TopicA")
+	if !cmp.Equal(stored, cached) {
+		t.Errorf("cached topic config:\n%s", cmp.Diff(stored, cached))
+	}
+}
@@ -0,0 +1,76 @@
+package trace
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// Exporter exports finished spans, for example to a tracing backend.
+//
+// ExportSpan is called from the goroutine that ended the span, so it should return quickly. It may
+// be called concurrently.
+type Exporter interface {
+	ExportSpan(s *Span)
+}
+
+// NopExporter is an Exporter that discards every span.
+type NopExporter struct{}
+
+// ExportSpan implements Exporter.
+func (NopExporter) ExportSpan(s *Span) {}
+
+// StdoutExporter is an Exporter that writes each span as a line of JSON.
+type StdoutExporter struct {
+	// Writer is where spans are written. Defaults to os.Stdout.
+	Writer io.Writer
+
+	mu sync.Mutex
+}
+
+type spanJSON struct {
+	Name         string            `json:"name"`
+	TraceID      string            `json:"trace_id"`
+	SpanID       string            `json:"span_id"`
+	ParentSpanID string            `json:"parent_span_id,omitempty"`
+	StartTime    time.Time         `json:"start_time"`
+	EndTime      time.Time         `json:"end_time"`
+	Attributes   map[string]string `json:"attributes,omitempty"`
+}
+
+// ExportSpan implements Exporter.
+func (e *StdoutExporter) ExportSpan(s *Span) {
+	j := spanJSON{
+		Name:       s.Name,
+		TraceID:    s.SpanContext.TraceID.String(),
+		SpanID:     s.SpanContext.SpanID.String(),
+		StartTime:  s.StartTime,
+		EndTime:    s.EndTime,
+		Attributes: s.Attributes(),
+	}
+	if s.Parent.IsValid() {
+		j.ParentSpanID = s.Parent.SpanID.String()
+	}
+
+	buf, err := json.Marshal(j)
+	if err != nil {
+		log.Printf("trace: marshal span: %v", err)
+		return
+	}
+	buf = append(buf, '\n')
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	w := e.Writer
+	if w == nil {
+		w = os.Stdout
+	}
+	_, err = w.Write(buf)
+	if err != nil {
+		log.Printf("trace: write span: %v", err)
+	}
+}
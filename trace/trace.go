@@ -0,0 +1,307 @@
+/*
+Package trace propagates W3C trace context (https://www.w3.org/TR/trace-context/) through DEQ
+events, so that a request can be traced as it fans out through any number of topics.
+
+The trace context of an event is carried in its metadata, under the keys TraceparentKey and
+TracestateKey. Publishers record the span context of the caller on each event with Inject, and
+subscribers start a span for each handled event that continues the event's trace. Finished spans are
+passed to an Exporter.
+*/
+package trace
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// TraceparentKey is the metadata key of an event's traceparent.
+	TraceparentKey = "traceparent"
+	// TracestateKey is the metadata key of an event's tracestate.
+	TracestateKey = "tracestate"
+)
+
+// TraceID identifies a trace.
+type TraceID [16]byte
+
+// String returns id in lowercase hex.
+func (id TraceID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+// SpanID identifies a span within a trace.
+type SpanID [8]byte
+
+// String returns id in lowercase hex.
+func (id SpanID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+// SpanContext is the part of a span that is propagated to its children.
+type SpanContext struct {
+	TraceID TraceID
+	SpanID  SpanID
+	// Sampled indicates that the caller may have recorded the span.
+	Sampled bool
+	// TraceState is vendor-specific trace information, in the format of the tracestate header.
+	TraceState string
+}
+
+// IsValid returns true if sc has a non-zero TraceID and SpanID.
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID != TraceID{} && sc.SpanID != SpanID{}
+}
+
+// Traceparent formats sc as a version 00 traceparent.
+func (sc SpanContext) Traceparent() string {
+	flags := "00"
+	if sc.Sampled {
+		flags = "01"
+	}
+	return "00-" + sc.TraceID.String() + "-" + sc.SpanID.String() + "-" + flags
+}
+
+// ErrInvalidTraceparent is returned when parsing a malformed traceparent.
+var ErrInvalidTraceparent = errors.New("invalid traceparent")
+
+// ParseTraceparent parses a traceparent. The TraceState of the returned SpanContext is empty.
+//
+// Traceparents with a version other than 00 are parsed as version 00, as required by the trace
+// context specification.
+func ParseTraceparent(traceparent string) (SpanContext, error) {
+	// version "-" trace-id "-" parent-id "-" trace-flags
+	const size = 2 + 1 + 32 + 1 + 16 + 1 + 2
+
+	if len(traceparent) < size {
+		return SpanContext{}, ErrInvalidTraceparent
+	}
+	version := traceparent[:2]
+	if version == "ff" || (version == "00" && len(traceparent) != size) ||
+		(len(traceparent) > size && traceparent[size] != '-') {
+		return SpanContext{}, ErrInvalidTraceparent
+	}
+	if traceparent[2] != '-' || traceparent[35] != '-' || traceparent[52] != '-' {
+		return SpanContext{}, ErrInvalidTraceparent
+	}
+
+	var sc SpanContext
+	var flags [1]byte
+	for _, field := range []struct {
+		dst []byte
+		src string
+	}{
+		{nil, version},
+		{sc.TraceID[:], traceparent[3:35]},
+		{sc.SpanID[:], traceparent[36:52]},
+		{flags[:], traceparent[53:55]},
+	} {
+		if strings.ToLower(field.src) != field.src {
+			return SpanContext{}, ErrInvalidTraceparent
+		}
+		dst := field.dst
+		if dst == nil {
+			dst = make([]byte, len(field.src)/2)
+		}
+		_, err := hex.Decode(dst, []byte(field.src))
+		if err != nil {
+			return SpanContext{}, ErrInvalidTraceparent
+		}
+	}
+	if !sc.IsValid() {
+		return SpanContext{}, ErrInvalidTraceparent
+	}
+	sc.Sampled = flags[0]&1 == 1
+
+	return sc, nil
+}
+
+// Inject records sc in metadata, returning the updated metadata. metadata is copied rather than
+// modified, and may be nil. If sc isn't valid, metadata is returned unchanged.
+func Inject(sc SpanContext, metadata map[string]string) map[string]string {
+	if !sc.IsValid() {
+		return metadata
+	}
+
+	injected := make(map[string]string, len(metadata)+2)
+	for k, v := range metadata {
+		injected[k] = v
+	}
+	injected[TraceparentKey] = sc.Traceparent()
+	delete(injected, TracestateKey)
+	if sc.TraceState != "" {
+		injected[TracestateKey] = sc.TraceState
+	}
+
+	return injected
+}
+
+// Extract returns the span context recorded in metadata. ok is false if metadata doesn't have a
+// valid traceparent.
+func Extract(metadata map[string]string) (sc SpanContext, ok bool) {
+	traceparent, ok := metadata[TraceparentKey]
+	if !ok {
+		return SpanContext{}, false
+	}
+	sc, err := ParseTraceparent(traceparent)
+	if err != nil {
+		return SpanContext{}, false
+	}
+	sc.TraceState = metadata[TracestateKey]
+	return sc, true
+}
+
+// ContextWithMetadata returns a copy of ctx that carries the span context recorded in metadata as
+// the current span context. If metadata doesn't carry a valid span context, ctx is returned.
+func ContextWithMetadata(ctx context.Context, metadata map[string]string) context.Context {
+	sc, ok := Extract(metadata)
+	if !ok {
+		return ctx
+	}
+	return NewContext(ctx, sc)
+}
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx that carries sc as the current span context.
+func NewContext(ctx context.Context, sc SpanContext) context.Context {
+	return context.WithValue(ctx, contextKey{}, sc)
+}
+
+// FromContext returns the current span context of ctx. ok is false if ctx doesn't carry a valid
+// span context.
+func FromContext(ctx context.Context) (sc SpanContext, ok bool) {
+	sc, ok = ctx.Value(contextKey{}).(SpanContext)
+	return sc, ok && sc.IsValid()
+}
+
+// Span is a timed operation within a trace.
+type Span struct {
+	// Name describes the operation.
+	Name string
+	// SpanContext identifies the span.
+	SpanContext SpanContext
+	// Parent is the span context of the span's parent, or the zero SpanContext if the span is the root
+	// of its trace.
+	Parent SpanContext
+
+	StartTime time.Time
+	// EndTime is the zero time until End is called.
+	EndTime time.Time
+
+	mu         sync.Mutex
+	attributes map[string]string
+	exporter   Exporter
+	ended      bool
+}
+
+// SetAttribute records an attribute describing the span. It is safe to call concurrently.
+func (s *Span) SetAttribute(key, value string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.attributes == nil {
+		s.attributes = make(map[string]string)
+	}
+	s.attributes[key] = value
+}
+
+// Attributes returns a copy of the span's attributes.
+func (s *Span) Attributes() map[string]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	attributes := make(map[string]string, len(s.attributes))
+	for k, v := range s.attributes {
+		attributes[k] = v
+	}
+	return attributes
+}
+
+// End ends the span and exports it. Calls after the first have no effect.
+func (s *Span) End() {
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	s.EndTime = time.Now()
+	s.mu.Unlock()
+
+	s.exporter.ExportSpan(s)
+}
+
+// Tracer starts spans and exports them once they end.
+//
+// All methods of Tracer are safe for concurrent use.
+type Tracer struct {
+	exporter Exporter
+}
+
+// NewTracer creates a Tracer that exports spans to exporter. If exporter is nil, spans are started
+// and propagated but not exported.
+func NewTracer(exporter Exporter) *Tracer {
+	if exporter == nil {
+		exporter = NopExporter{}
+	}
+	return &Tracer{exporter}
+}
+
+// StartSpan starts a span named name. If ctx carries a span context, the span is its child,
+// otherwise the span starts a new trace. The returned context carries the span's context.
+//
+// The span must be ended by calling its End method.
+func (t *Tracer) StartSpan(ctx context.Context, name string) (context.Context, *Span) {
+	span := &Span{
+		Name:      name,
+		StartTime: time.Now(),
+		exporter:  t.exporter,
+	}
+
+	parent, ok := FromContext(ctx)
+	if ok {
+		span.Parent = parent
+		span.SpanContext = SpanContext{
+			TraceID:    parent.TraceID,
+			Sampled:    parent.Sampled,
+			TraceState: parent.TraceState,
+		}
+	} else {
+		span.SpanContext.TraceID = newTraceID()
+		span.SpanContext.Sampled = true
+	}
+	span.SpanContext.SpanID = newSpanID()
+
+	return NewContext(ctx, span.SpanContext), span
+}
+
+func newTraceID() (id TraceID) {
+	randomID(id[:])
+	return id
+}
+
+func newSpanID() (id SpanID) {
+	randomID(id[:])
+	return id
+}
+
+// randomID fills id with random bytes, ensuring it isn't all zeros.
+func randomID(id []byte) {
+	for {
+		_, err := rand.Read(id)
+		if err != nil {
+			panic(fmt.Sprintf("trace: generate random id: %v", err))
+		}
+		for _, b := range id {
+			if b != 0 {
+				return
+			}
+		}
+	}
+}
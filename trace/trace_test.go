@@ -0,0 +1,166 @@
+package trace
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseTraceparent(t *testing.T) {
+	t.Parallel()
+
+	sc, err := ParseTraceparent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	expected := SpanContext{
+		TraceID: TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+		SpanID:  SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+		Sampled: true,
+	}
+	if !cmp.Equal(expected, sc) {
+		t.Errorf("parse:\n%s", cmp.Diff(expected, sc))
+	}
+	if traceparent := sc.Traceparent(); traceparent != "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01" {
+		t.Errorf("format: got %q", traceparent)
+	}
+
+	// Future versions may append fields.
+	_, err = ParseTraceparent("01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00-future")
+	if err != nil {
+		t.Errorf("parse future version: %v", err)
+	}
+
+	for _, invalid := range []string{
+		"",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+		"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e473x-00f067aa0ba902b7-01",
+		"00_4bf92f3577b34da6a3ce929d0e0e4736_00f067aa0ba902b7_01",
+	} {
+		_, err := ParseTraceparent(invalid)
+		if err != ErrInvalidTraceparent {
+			t.Errorf("parse %q: expected ErrInvalidTraceparent, got %v", invalid, err)
+		}
+	}
+}
+
+func TestInjectExtract(t *testing.T) {
+	t.Parallel()
+
+	tracer := NewTracer(nil)
+	_, span := tracer.StartSpan(context.Background(), "span")
+	sc := span.SpanContext
+	sc.TraceState = "vendor=value"
+
+	metadata := map[string]string{"tenant": "tenant1"}
+	injected := Inject(sc, metadata)
+
+	if len(metadata) != 1 {
+		t.Errorf("Inject modified metadata: %v", metadata)
+	}
+	expected := map[string]string{
+		"tenant":       "tenant1",
+		TraceparentKey: sc.Traceparent(),
+		TracestateKey:  "vendor=value",
+	}
+	if !cmp.Equal(expected, injected) {
+		t.Errorf("inject:\n%s", cmp.Diff(expected, injected))
+	}
+
+	extracted, ok := Extract(injected)
+	if !ok {
+		t.Fatalf("extract: no span context")
+	}
+	if !cmp.Equal(sc, extracted) {
+		t.Errorf("extract:\n%s", cmp.Diff(sc, extracted))
+	}
+
+	_, ok = Extract(metadata)
+	if ok {
+		t.Errorf("extract from metadata without traceparent: expected no span context")
+	}
+}
+
+func TestStartSpan(t *testing.T) {
+	t.Parallel()
+
+	var exported []*Span
+	tracer := NewTracer(exporterFunc(func(s *Span) {
+		exported = append(exported, s)
+	}))
+
+	ctx, root := tracer.StartSpan(context.Background(), "root")
+	if !root.SpanContext.IsValid() {
+		t.Fatalf("root span context is invalid")
+	}
+	if root.Parent.IsValid() {
+		t.Errorf("root span has parent %v", root.Parent)
+	}
+
+	_, child := tracer.StartSpan(ctx, "child")
+	if child.Parent != root.SpanContext {
+		t.Errorf("child parent: expected %v, got %v", root.SpanContext, child.Parent)
+	}
+	if child.SpanContext.TraceID != root.SpanContext.TraceID {
+		t.Errorf("child trace id: expected %v, got %v", root.SpanContext.TraceID, child.SpanContext.TraceID)
+	}
+	if child.SpanContext.SpanID == root.SpanContext.SpanID {
+		t.Errorf("child has the same span id as its parent")
+	}
+
+	child.End()
+	child.End()
+	root.End()
+
+	if len(exported) != 2 || exported[0] != child || exported[1] != root {
+		t.Errorf("expected child then root to be exported once, got %v", exported)
+	}
+	if child.EndTime.Before(child.StartTime) {
+		t.Errorf("child ended at %v, before it started at %v", child.EndTime, child.StartTime)
+	}
+}
+
+func TestStdoutExporter(t *testing.T) {
+	t.Parallel()
+
+	buf := new(bytes.Buffer)
+	tracer := NewTracer(&StdoutExporter{Writer: buf})
+
+	ctx, root := tracer.StartSpan(context.Background(), "root")
+	_, child := tracer.StartSpan(ctx, "child")
+	child.SetAttribute("key", "value")
+	child.End()
+
+	var got map[string]interface{}
+	err := json.Unmarshal(buf.Bytes(), &got)
+	if err != nil {
+		t.Fatalf("unmarshal exported span %q: %v", buf, err)
+	}
+	delete(got, "start_time")
+	delete(got, "end_time")
+
+	expected := map[string]interface{}{
+		"name":           "child",
+		"trace_id":       root.SpanContext.TraceID.String(),
+		"span_id":        child.SpanContext.SpanID.String(),
+		"parent_span_id": root.SpanContext.SpanID.String(),
+		"attributes":     map[string]interface{}{"key": "value"},
+	}
+	if !cmp.Equal(expected, got) {
+		t.Errorf("exported span:\n%s", cmp.Diff(expected, got))
+	}
+}
+
+type exporterFunc func(*Span)
+
+func (f exporterFunc) ExportSpan(s *Span) {
+	f(s)
+}
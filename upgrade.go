@@ -4,9 +4,9 @@ import (
 	"fmt"
 	"log"
 
-	"github.com/dgraph-io/badger"
 	"github.com/gogo/protobuf/proto"
 	"gitlab.com/katcheCode/deq/internal/data"
+	"gitlab.com/katcheCode/deq/internal/storage"
 )
 
 // upgradeDB upgrades the store's db to the current version.
@@ -27,6 +27,13 @@ func (s *Store) upgradeDB(currentVersion string) error {
 			log.Printf("[INFO] %d indexes upgraded, %d indexes failed", u.updated, u.failed)
 		}
 		log.Printf("[INFO] %d indexes upgraded, %d indexes failed", u.updated, u.failed)
+		fallthrough
+	case "1.1.0":
+		log.Printf("[INFO] computing event stats")
+		err := upgradeV1_1_0(s.db)
+		if err != nil {
+			return fmt.Errorf("compute event stats: %v", err)
+		}
 		log.Printf("[INFO] db upgraded to version %s", dbCodeVersion)
 
 	default:
@@ -38,7 +45,7 @@ func (s *Store) upgradeDB(currentVersion string) error {
 		return err
 	}
 
-	err = txn.Commit(nil)
+	err = txn.Commit()
 	if err != nil {
 		return fmt.Errorf("commit db upgrade: %v", err)
 	}
@@ -46,9 +53,16 @@ func (s *Store) upgradeDB(currentVersion string) error {
 	return nil
 }
 
-func (s *Store) getDBVersion(txn *badger.Txn) (string, error) {
+func (s *Store) getDBVersion(txn storage.Txn) (string, error) {
 	item, err := txn.Get([]byte(dbVersionKey))
-	if err == badger.ErrKeyNotFound {
+	if err == storage.ErrKeyNotFound {
+		// Databases written by v1.0.0 don't have a version key, so only an empty database is new.
+		it := txn.NewIterator(storage.IteratorOptions{})
+		defer it.Close()
+		it.Rewind()
+		if !it.Valid() {
+			return "", nil
+		}
 		return "1.0.0", nil
 	}
 	if err != nil {
@@ -70,14 +84,14 @@ type upgradeV1_0_0 struct {
 
 // NextBatch upgrades the database from v1.0.0 to the current version. It is the caller's
 // responsibility to commit the Txn.
-func (u *upgradeV1_0_0) NextBatch(txn *badger.Txn, batchSize int) bool {
+func (u *upgradeV1_0_0) NextBatch(txn storage.Txn, batchSize int) bool {
 
 	prefix := []byte{data.IndexTagV1_0_0, data.Sep}
 	if len(u.cursor) == 0 {
 		u.cursor = prefix
 	}
 
-	it := txn.NewIterator(badger.DefaultIteratorOptions)
+	it := txn.NewIterator(storage.DefaultIteratorOptions)
 	defer it.Close()
 
 	var key []byte
@@ -137,7 +151,127 @@ func (u *upgradeV1_0_0) NextBatch(txn *badger.Txn, batchSize int) bool {
 	return false
 }
 
+// upgradeStatsBatchSize is the maximum number of stats written in a single transaction by
+// upgradeV1_1_0.
+const upgradeStatsBatchSize = 1000
+
+// upgradeV1_1_0 computes the stats of every topic and channel, which weren't saved before v1.2.0,
+// and writes them to db.
+//
+// The stats are committed in batches so that large databases don't exceed the size of a
+// transaction. The caller must only write the new version once upgradeV1_1_0 returns, so that an
+// interrupted upgrade is run again from the start, overwriting the stats that were already
+// written.
+func upgradeV1_1_0(db storage.DB) error {
+
+	read := db.NewTransaction(false)
+	defer read.Discard()
+
+	topics := make(map[data.TopicStatsKey]*data.TopicStatsPayload)
+	channels := make(map[data.ChannelStatsKey]*data.ChannelStatsPayload)
+
+	it := read.NewIterator(storage.DefaultIteratorOptions)
+	defer it.Close()
+
+	for it.Seek(data.EventPrefix); it.ValidForPrefix(data.EventPrefix); it.Next() {
+		item := it.Item()
+
+		var key data.EventKey
+		err := data.UnmarshalTo(item.Key(), &key)
+		if err != nil {
+			log.Printf("[WARN] parse event key %s: %v", item.Key(), err)
+			continue
+		}
+		val, err := item.Value()
+		if err != nil {
+			return err
+		}
+		var payload data.EventPayload
+		// Databases older than v1.2.0 never have encrypted payloads.
+		err = unmarshalEventPayload(val, item.Key(), nil, &payload)
+		if err != nil {
+			log.Printf("[WARN] %v", err)
+			continue
+		}
+
+		statsKey := data.TopicStatsKey{Topic: key.Topic}
+		stats := topics[statsKey]
+		if stats == nil {
+			stats = new(data.TopicStatsPayload)
+			topics[statsKey] = stats
+		}
+		stats.EventCount++
+		stats.PayloadBytes += int64(len(payload.Payload))
+	}
+
+	prefix := []byte{data.ChannelTag, data.Sep}
+	for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
+		item := it.Item()
+
+		var key data.ChannelKey
+		err := data.UnmarshalChannelKey(item.Key(), &key)
+		if err != nil {
+			log.Printf("[WARN] parse channel key %s: %v", item.Key(), err)
+			continue
+		}
+		val, err := item.Value()
+		if err != nil {
+			return err
+		}
+		var payload data.ChannelPayload
+		err = proto.Unmarshal(val, &payload)
+		if err != nil {
+			log.Printf("[WARN] unmarshal channel payload: %v", err)
+			continue
+		}
+
+		statsKey := data.ChannelStatsKey{Channel: key.Channel, Topic: key.Topic}
+		stats := channels[statsKey]
+		if stats == nil {
+			stats = new(data.ChannelStatsPayload)
+			channels[statsKey] = stats
+		}
+		addChannelStats(stats, &payload, 1)
+	}
+
+	type keyedStats struct {
+		key     data.Key
+		payload proto.Message
+	}
+	all := make([]keyedStats, 0, len(topics)+len(channels))
+	for key, stats := range topics {
+		all = append(all, keyedStats{key, stats})
+	}
+	for key, stats := range channels {
+		all = append(all, keyedStats{key, stats})
+	}
+
+	for len(all) > 0 {
+		batch := all
+		if len(batch) > upgradeStatsBatchSize {
+			batch = batch[:upgradeStatsBatchSize]
+		}
+		all = all[len(batch):]
+
+		txn := db.NewTransaction(true)
+		for _, stats := range batch {
+			err := setStats(txn, stats.key, stats.payload, false)
+			if err != nil {
+				txn.Discard()
+				return err
+			}
+		}
+		err := txn.Commit()
+		txn.Discard()
+		if err != nil {
+			return fmt.Errorf("commit stats: %v", err)
+		}
+	}
+
+	return nil
+}
+
 const (
 	dbVersionKey  = "___DEQ_DB_VERSION___"
-	dbCodeVersion = "1.1.0"
+	dbCodeVersion = "1.2.0"
 )
@@ -0,0 +1,179 @@
+package deq
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"gitlab.com/katcheCode/deq/internal/storage"
+)
+
+// maxWriteBatch is the maximum number of writes committed in a single transaction by the store's
+// writer.
+const maxWriteBatch = 256
+
+// errStoreClosed is returned when writing to a store that has been closed.
+var errStoreClosed = errors.New("store is closed")
+
+// maxWriteHold is how long a single write of a batched operation, such as DelTopic or Reencrypt,
+// may spend reading before it commits what it has so far. This keeps long operations from holding
+// up the other writes of the store.
+const maxWriteHold = 20 * time.Millisecond
+
+// minConflictBackoff and maxConflictBackoff bound how long the writer waits before retrying a
+// transaction that conflicted. The wait doubles after each conflict.
+const (
+	minConflictBackoff = time.Millisecond
+	maxConflictBackoff = 50 * time.Millisecond
+)
+
+// writeRequest is a write to be committed by the store's writer.
+type writeRequest struct {
+	ctx context.Context
+	// apply performs the write on txn. The writes of several requests may share the same txn, and
+	// apply may be called more than once if the transaction is retried, so apply must only modify
+	// state outside of txn by overwriting it.
+	apply func(txn storage.Txn) error
+	done  chan error
+}
+
+// write commits the writes of apply. Concurrent writes are grouped into a single transaction by
+// the store's writer, so that many writes can share the cost of syncing the transaction to disk.
+// The writes of apply are committed before write returns, and if apply returns an error, none of
+// its writes are committed.
+//
+// If ctx is done before the writer applies the write, write returns ctx.Err() and nothing is
+// written. Once the write is applied, write waits for it to be committed regardless of ctx.
+func (s *Store) write(ctx context.Context, apply func(txn storage.Txn) error) error {
+	req := writeRequest{
+		ctx:   ctx,
+		apply: apply,
+		done:  make(chan error, 1),
+	}
+
+	select {
+	case <-s.done:
+		return errStoreClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	case s.in <- req:
+	}
+
+	return <-req.done
+}
+
+// writeLoop commits the requests sent to s.in until the store is closed.
+//
+// Requests sent while a transaction is being committed are grouped into the next transaction. If
+// window is positive, the writer also waits up to window after the first request of a transaction
+// for more requests before committing it.
+func (s *Store) writeLoop(window time.Duration) {
+	for {
+		var batch []writeRequest
+
+		select {
+		case <-s.done:
+			return
+		case req := <-s.in:
+			batch = append(batch, req)
+		}
+
+		if window > 0 {
+			timer := time.NewTimer(window)
+		wait:
+			for len(batch) < maxWriteBatch {
+				select {
+				case req := <-s.in:
+					batch = append(batch, req)
+				case <-timer.C:
+					break wait
+				}
+			}
+			timer.Stop()
+		}
+
+	drain:
+		for len(batch) < maxWriteBatch {
+			select {
+			case req := <-s.in:
+				batch = append(batch, req)
+			default:
+				break drain
+			}
+		}
+
+		s.commitWrites(batch)
+	}
+}
+
+// commitWrites commits batch in a single transaction and notifies each request of the result. If
+// a request fails, it is removed from the batch and the rest of the batch is retried, so one failed
+// request doesn't fail the others. If the commit itself fails, each request is retried in its own
+// transaction.
+func (s *Store) commitWrites(batch []writeRequest) {
+	for len(batch) > 0 {
+		failed, err := s.tryCommitWrites(batch)
+		if failed >= 0 {
+			batch[failed].done <- err
+			batch = append(batch[:failed], batch[failed+1:]...)
+			continue
+		}
+		if err == nil || len(batch) == 1 {
+			for _, req := range batch {
+				req.done <- err
+			}
+			return
+		}
+		for i := range batch {
+			s.commitWrites(batch[i : i+1])
+		}
+		return
+	}
+}
+
+// tryCommitWrites applies batch to a single transaction and commits it, retrying with a backoff if
+// the transaction conflicts. If a request's context is done or its apply returns an error,
+// tryCommitWrites returns the index of the request and its error without committing anything.
+// Otherwise it returns -1 and the result of the commit.
+func (s *Store) tryCommitWrites(batch []writeRequest) (int, error) {
+	backoff := minConflictBackoff
+	// Retry for up to 10 conflicts.
+	for i := 0; i < 10; i++ {
+		txn := s.db.NewTransaction(true)
+
+		for j, req := range batch {
+			err := req.ctx.Err()
+			if err == nil {
+				err = req.apply(txn)
+			}
+			if err != nil {
+				txn.Discard()
+				return j, err
+			}
+		}
+
+		err := txn.Commit()
+		txn.Discard()
+		if err == storage.ErrConflict {
+			timer := time.NewTimer(backoff)
+			select {
+			case <-s.done:
+				timer.Stop()
+				return -1, errStoreClosed
+			case <-timer.C:
+			}
+			backoff *= 2
+			if backoff > maxConflictBackoff {
+				backoff = maxConflictBackoff
+			}
+			continue
+		}
+		if err == nil {
+			atomic.AddInt64(&s.counters.commits, 1)
+		}
+		return -1, err
+	}
+
+	return -1, storage.ErrConflict
+}